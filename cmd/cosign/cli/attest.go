@@ -63,6 +63,12 @@ func Attest() *cobra.Command {
   # write attestation to stdout
   cosign attest --predicate <FILE> --type <TYPE> --key cosign.key --no-upload true <IMAGE>
 
+  # attach an attestation of a custom predicate type, validated against its JSON Schema
+  cosign attest --predicate <FILE> --predicate-schema mytype=<SCHEMA FILE> --type mytype --key cosign.key <IMAGE>
+
+  # sign and attach every statement of an in-toto attestation bundle in one pass
+  cosign attest --attestation-bundle attestations.jsonl --key cosign.key <IMAGE>
+
   # attach an attestation to a container image and honor the creation timestamp of the signature
   cosign attest --predicate <FILE> --type <TYPE> --key cosign.key --record-creation-timestamp <IMAGE>`,
 
@@ -75,6 +81,9 @@ func Attest() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Predicate.RegisterSchemas(); err != nil {
+				return err
+			}
 			oidcClientSecret, err := o.OIDC.ClientSecret()
 			if err != nil {
 				return err
@@ -82,7 +91,7 @@ func Attest() *cobra.Command {
 
 			ko := options.KeyOpts{
 				KeyRef:                         o.Key,
-				PassFunc:                       generate.GetPass,
+				PassFunc:                       generate.PassFunc(o.PasswordShare.Files, o.PasswordShare.Prompt),
 				Sk:                             o.SecurityKey.Use,
 				Slot:                           o.SecurityKey.Slot,
 				FulcioURL:                      o.Fulcio.URL,
@@ -119,6 +128,7 @@ func Attest() *cobra.Command {
 				CertChainPath:           o.CertChain,
 				NoUpload:                o.NoUpload,
 				PredicatePath:           o.Predicate.Path,
+				AttestationBundlePath:   o.AttestationBundle,
 				PredicateType:           o.Predicate.Type,
 				Replace:                 o.Replace,
 				Timeout:                 ro.Timeout,
@@ -16,10 +16,13 @@
 package attest
 
 import (
+	"bytes"
 	"context"
 	_ "crypto/sha256" // for `crypto.SHA256`
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
@@ -32,6 +35,7 @@ import (
 	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
 	cbundle "github.com/sigstore/cosign/v3/pkg/cosign/bundle"
 	cremote "github.com/sigstore/cosign/v3/pkg/cosign/remote"
+	"github.com/sigstore/cosign/v3/pkg/oci"
 	"github.com/sigstore/cosign/v3/pkg/oci/mutate"
 	ociremote "github.com/sigstore/cosign/v3/pkg/oci/remote"
 	"github.com/sigstore/cosign/v3/pkg/oci/static"
@@ -48,6 +52,7 @@ type AttestCommand struct {
 	CertChainPath           string
 	NoUpload                bool
 	PredicatePath           string
+	AttestationBundlePath   string
 	PredicateType           string
 	Replace                 bool
 	Timeout                 time.Duration
@@ -64,17 +69,23 @@ func (c *AttestCommand) Exec(ctx context.Context, imageRef string) error {
 		return &options.KeyParseError{}
 	}
 
-	if c.PredicatePath == "" {
+	if c.PredicatePath == "" && c.AttestationBundlePath == "" {
 		return fmt.Errorf("predicate cannot be empty")
 	}
+	if c.PredicatePath != "" && c.AttestationBundlePath != "" {
+		return fmt.Errorf("only one of predicate or attestation bundle may be provided")
+	}
 
 	if c.RekorEntryType != "dsse" && c.RekorEntryType != "intoto" {
 		return fmt.Errorf("unknown value for rekor-entry-type")
 	}
 
-	predicateURI, err := options.ParsePredicateType(c.PredicateType)
-	if err != nil {
-		return err
+	// Bundled statements carry their own predicate types.
+	var err error
+	if c.AttestationBundlePath == "" {
+		if _, err = options.ParsePredicateType(c.PredicateType); err != nil {
+			return err
+		}
 	}
 	ref, err := signcommon.ParseOCIReference(ctx, imageRef, c.NameOptions()...)
 	if err != nil {
@@ -104,9 +115,165 @@ func (c *AttestCommand) Exec(ctx context.Context, imageRef string) error {
 	// each access.
 	ref = digest // nolint
 
+	entries, err := c.attestations(h, digest)
+	if err != nil {
+		return err
+	}
+	// Attestations are annotated with the predicate type of their statement,
+	// which for some types, like spdxjson, depends on the predicate and not
+	// only on --type.
+	predicateTypeOf := func(e attestation.BundleEntry) string {
+		return e.Statement.PredicateType
+	}
+
+	var unsigned []attestation.BundleEntry
+	for _, e := range entries {
+		if e.Envelope == nil {
+			unsigned = append(unsigned, e)
+		}
+	}
+
+	// We don't actually need to access the remote entity to attach things to it
+	// so we use a placeholder here.
+	se := ociremote.SignedUnknown(digest, ociremoteOpts...)
+	attached := false
+
+	if len(unsigned) > 0 {
+		if c.SigningConfig == nil {
+			c.SigningConfig, err = signcommon.NewSigningConfigFromKeyOpts(c.KeyOpts)
+			if err != nil {
+				return fmt.Errorf("creating signing config: %w", err)
+			}
+			if !c.TlogUpload {
+				c.SigningConfig = c.SigningConfig.WithRekorLogURLs()
+			}
+		}
+
+		payloads := make([][]byte, len(unsigned))
+		for i, e := range unsigned {
+			payloads[i] = e.Payload
+		}
+		bundles, pubKey, hashAlgProto, err := signcommon.NewAttestationBundles(ctx, c.KeyOpts, c.CertPath, c.CertChainPath, payloads, c.SigningConfig, c.TrustedMaterial)
+		if err != nil {
+			return fmt.Errorf("creating bundle: %w", err)
+		}
+
+		if c.NewBundleFormat {
+			if err := c.writeBundleFile(ctx, bundles); err != nil {
+				return err
+			}
+			if !c.NoUpload {
+				for i, bundleBytes := range bundles {
+					if err := ociremote.WriteAttestationNewBundleFormat(digest, bundleBytes, predicateTypeOf(unsigned[i]), ociremoteOpts...); err != nil {
+						return fmt.Errorf("writing bundle: %w", err)
+					}
+				}
+			}
+		} else {
+			ddVerifier, err := signature.LoadVerifier(pubKey, signcommon.ProtoHashAlgoToHash(hashAlgProto))
+			if err != nil {
+				return fmt.Errorf("loading verifier: %w", err)
+			}
+			dd := cremote.NewDupeDetector(ddVerifier)
+
+			legacyBundles := make([][]byte, len(bundles))
+			for i, bundleBytes := range bundles {
+				var ociSig oci.Signature
+				ociSig, legacyBundles[i], err = legacyAttestation(bundleBytes, predicateTypeOf(unsigned[i]))
+				if err != nil {
+					return err
+				}
+				signOpts := []mutate.SignOption{
+					mutate.WithDupeDetector(dd),
+					mutate.WithRecordCreationTimestamp(c.RecordCreationTimestamp),
+				}
+				if c.Replace {
+					ro := cremote.NewReplaceOp(predicateTypeOf(unsigned[i]))
+					signOpts = append(signOpts, mutate.WithReplaceOp(ro))
+				}
+
+				// Attach the attestation to the entity.
+				se, err = mutate.AttachAttestationToEntity(se, ociSig, signOpts...)
+				if err != nil {
+					return fmt.Errorf("attaching attestation: %w", err)
+				}
+				attached = true
+			}
+			if err := c.writeBundleFile(ctx, legacyBundles); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Attestations signed elsewhere have no verification material to build
+	// a Sigstore bundle from, so they are attached as DSSE envelopes to the
+	// legacy attestation image. Their subjects were checked against the
+	// image when reading the bundle; their signatures are left to verifiers.
+	for _, e := range entries {
+		if e.Envelope == nil || c.NoUpload {
+			continue
+		}
+		payload, err := json.Marshal(e.Envelope)
+		if err != nil {
+			return err
+		}
+		ociSig, err := static.NewAttestation(payload,
+			static.WithLayerMediaType(types.DssePayloadType),
+			static.WithAnnotations(map[string]string{
+				"predicateType": e.Statement.PredicateType,
+			}))
+		if err != nil {
+			return fmt.Errorf("creating attestation: %w", err)
+		}
+		var signOpts []mutate.SignOption
+		if c.Replace {
+			signOpts = append(signOpts, mutate.WithReplaceOp(cremote.NewReplaceOp(e.Statement.PredicateType)))
+		}
+		se, err = mutate.AttachAttestationToEntity(se, ociSig, signOpts...)
+		if err != nil {
+			return fmt.Errorf("attaching attestation: %w", err)
+		}
+		attached = true
+	}
+
+	if c.NoUpload || !attached {
+		return nil
+	}
+
+	// Publish the attestations associated with this entity
+	return ociremote.WriteAttestations(digest.Repository, se, ociremoteOpts...)
+}
+
+// attestations returns the statement generated from the predicate, or the
+// attestations of the attestation bundle, which must all be about digest.
+func (c *AttestCommand) attestations(h v1.Hash, digest name.Digest) ([]attestation.BundleEntry, error) {
+	if c.AttestationBundlePath != "" {
+		fmt.Fprintln(os.Stderr, "Using attestation bundle from:", c.AttestationBundlePath)
+		f, err := os.Open(filepath.Clean(c.AttestationBundlePath))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		entries, err := attestation.ReadBundle(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading attestation bundle: %w", err)
+		}
+		for i, e := range entries {
+			if !e.HasSubject(h.Algorithm, h.Hex) {
+				return nil, fmt.Errorf("attestation %d in the bundle does not have %s as a subject", i+1, digest.String())
+			}
+			// Envelopes signed elsewhere have no verification material to
+			// build a Sigstore bundle from.
+			if e.Envelope != nil && c.NewBundleFormat {
+				return nil, fmt.Errorf("attestation %d in the bundle is a signed DSSE envelope, which cannot be attached with --new-bundle-format", i+1)
+			}
+		}
+		return entries, nil
+	}
+
 	predicate, err := predicateReader(c.PredicatePath)
 	if err != nil {
-		return fmt.Errorf("getting predicate reader: %w", err)
+		return nil, fmt.Errorf("getting predicate reader: %w", err)
 	}
 	defer predicate.Close()
 
@@ -117,78 +284,49 @@ func (c *AttestCommand) Exec(ctx context.Context, imageRef string) error {
 		Repo:      digest.Repository.String(),
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	payload, err := sh.MarshalJSON()
 	if err != nil {
-		return err
-	}
-
-	bundleOpts := signcommon.CommonBundleOpts{
-		Payload:       payload,
-		Digest:        digest,
-		PredicateType: predicateURI,
-		BundlePath:    c.BundlePath,
-		Upload:        !c.NoUpload,
-		OCIRemoteOpts: ociremoteOpts,
+		return nil, err
 	}
+	return []attestation.BundleEntry{{Statement: sh, Payload: payload}}, nil
+}
 
-	if c.SigningConfig == nil {
-		c.SigningConfig, err = signcommon.NewSigningConfigFromKeyOpts(c.KeyOpts)
-		if err != nil {
-			return fmt.Errorf("creating signing config: %w", err)
-		}
-		if !c.TlogUpload {
-			c.SigningConfig = c.SigningConfig.WithRekorLogURLs()
-		}
+// writeBundleFile writes the bundles to the --bundle path, one per line when
+// there are several.
+func (c *AttestCommand) writeBundleFile(ctx context.Context, bundles [][]byte) error {
+	if c.BundlePath == "" {
+		return nil
 	}
-
-	bundleBytes, pubKey, hashAlgProto, err := signcommon.NewAttestationBundle(ctx, c.KeyOpts, c.CertPath, c.CertChainPath, bundleOpts, c.SigningConfig, c.TrustedMaterial)
-	if err != nil {
-		return fmt.Errorf("creating bundle: %w", err)
+	contents := bundles[0]
+	if len(bundles) > 1 {
+		contents = append(bytes.Join(bundles, []byte("\n")), '\n')
 	}
-
-	if c.NewBundleFormat {
-		if c.BundlePath != "" {
-			if err := os.WriteFile(c.BundlePath, bundleBytes, 0600); err != nil {
-				return fmt.Errorf("create bundle file: %w", err)
-			}
-			ui.Infof(ctx, "Wrote bundle to file %s", c.BundlePath)
-		}
-
-		if !c.NoUpload {
-			if err := ociremote.WriteAttestationNewBundleFormat(digest, bundleBytes, bundleOpts.PredicateType, ociremoteOpts...); err != nil {
-				return fmt.Errorf("writing bundle: %w", err)
-			}
-		}
-		return nil
+	if err := os.WriteFile(c.BundlePath, contents, 0600); err != nil {
+		return fmt.Errorf("create bundle file: %w", err)
 	}
+	ui.Infof(ctx, "Wrote bundle to file %s", c.BundlePath)
+	return nil
+}
 
+// legacyAttestation converts a Sigstore bundle to an OCI attestation and
+// the legacy bundle format.
+func legacyAttestation(bundleBytes []byte, predicateURI string) (oci.Signature, []byte, error) {
 	var pb protobundle.Bundle
 	if err := protojson.Unmarshal(bundleBytes, &pb); err != nil {
-		return fmt.Errorf("unmarshalling bundle: %w", err)
+		return nil, nil, fmt.Errorf("unmarshalling bundle: %w", err)
 	}
 
 	bundleComponents, err := signcommon.ExtractComponentsFromProtoBundle(&pb)
 	if err != nil {
-		return fmt.Errorf("extracting components from bundle: %w", err)
+		return nil, nil, fmt.Errorf("extracting components from bundle: %w", err)
 	}
 
 	legacyBundleBytes, err := signcommon.NewLegacyBundleFromProtoBundleComponents(bundleComponents)
 	if err != nil {
-		return fmt.Errorf("creating legacy bundle: %w", err)
-	}
-
-	if c.BundlePath != "" {
-		if err := os.WriteFile(c.BundlePath, legacyBundleBytes, 0600); err != nil {
-			return fmt.Errorf("create bundle file: %w", err)
-		}
-		ui.Infof(ctx, "Wrote bundle to file %s", c.BundlePath)
-	}
-
-	if c.NoUpload {
-		return nil
+		return nil, nil, fmt.Errorf("creating legacy bundle: %w", err)
 	}
 
 	certPem, chainPem := signcommon.EncodeCertificatesToPEM(bundleComponents.Certificates)
@@ -219,34 +357,7 @@ func (c *AttestCommand) Exec(ctx context.Context, imageRef string) error {
 
 	ociSig, err := static.NewAttestation(bundleComponents.Signature, opts...)
 	if err != nil {
-		return fmt.Errorf("creating attestation: %w", err)
-	}
-
-	// We don't actually need to access the remote entity to attach things to it
-	// so we use a placeholder here.
-	se := ociremote.SignedUnknown(digest, ociremoteOpts...)
-
-	ddVerifier, err := signature.LoadVerifier(pubKey, signcommon.ProtoHashAlgoToHash(hashAlgProto))
-	if err != nil {
-		return fmt.Errorf("loading verifier: %w", err)
-	}
-	dd := cremote.NewDupeDetector(ddVerifier)
-	signOpts := []mutate.SignOption{
-		mutate.WithDupeDetector(dd),
-		mutate.WithRecordCreationTimestamp(c.RecordCreationTimestamp),
-	}
-
-	if c.Replace {
-		ro := cremote.NewReplaceOp(predicateURI)
-		signOpts = append(signOpts, mutate.WithReplaceOp(ro))
-	}
-
-	// Attach the attestation to the entity.
-	newSE, err := mutate.AttachAttestationToEntity(se, ociSig, signOpts...)
-	if err != nil {
-		return fmt.Errorf("attaching attestation: %w", err)
+		return nil, nil, fmt.Errorf("creating attestation: %w", err)
 	}
-
-	// Publish the attestations associated with this entity
-	return ociremote.WriteAttestations(digest.Repository, newSE, ociremoteOpts...)
+	return ociSig, legacyBundleBytes, nil
 }
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -46,6 +47,10 @@ type AttestBlobCommand struct {
 	CertChainPath string
 
 	ArtifactHash string
+	// Subjects are files, or NAME@ALGORITHM:HEX digests, attested in the
+	// same statement as the blob.
+	Subjects             []string
+	SubjectChecksumsPath string
 
 	StatementPath string
 	PredicatePath string
@@ -71,6 +76,9 @@ func (c *AttestBlobCommand) Exec(ctx context.Context, artifactPath string) error
 	if options.NOf(c.PredicatePath, c.StatementPath) != 1 {
 		return fmt.Errorf("one of --predicate or --statement must be set")
 	}
+	if c.StatementPath != "" && (len(c.Subjects) > 0 || c.SubjectChecksumsPath != "") {
+		return fmt.Errorf("--subject and --subject-checksums cannot be used with --statement, whose subjects are attested as is")
+	}
 
 	if c.RekorEntryType != "dsse" && c.RekorEntryType != "intoto" {
 		return fmt.Errorf("unknown value for rekor-entry-type")
@@ -98,40 +106,32 @@ func (c *AttestBlobCommand) Exec(ctx context.Context, artifactPath string) error
 		}
 
 	} else {
-		var artifact []byte
-		var hexDigest string
-		if c.ArtifactHash == "" {
-			if artifactPath == "-" {
-				artifact, err = io.ReadAll(os.Stdin)
-			} else {
-				fmt.Fprintln(os.Stderr, "Using payload from:", artifactPath)
-				artifact, err = os.ReadFile(filepath.Clean(artifactPath))
-			}
-			if err != nil {
-				return err
-			}
+		var opts attestation.GenerateOpts
+		subjects, err := c.subjects()
+		if err != nil {
+			return err
 		}
-
-		if c.ArtifactHash == "" {
-			digest, _, err := signature.ComputeDigestForSigning(bytes.NewReader(artifact), crypto.SHA256, []crypto.Hash{crypto.SHA256, crypto.SHA384})
+		if artifactPath != "" || c.ArtifactHash != "" {
+			hexDigest, err := c.artifactDigest(artifactPath)
 			if err != nil {
 				return err
 			}
-			hexDigest = strings.ToLower(hex.EncodeToString(digest))
-		} else {
-			hexDigest = c.ArtifactHash
+			if len(subjects) > 0 {
+				subjects = append([]attestation.Subject{{Name: base, Digest: map[string]string{"sha256": hexDigest}}}, subjects...)
+			}
+			opts.Digest, opts.Repo = hexDigest, base
+		} else if len(subjects) == 0 {
+			return fmt.Errorf("a blob, --hash, --subject or --subject-checksums is required")
 		}
+		opts.Subjects = subjects
 		predicate, err := predicateReader(c.PredicatePath)
 		if err != nil {
 			return fmt.Errorf("getting predicate reader: %w", err)
 		}
 		defer predicate.Close()
-		sh, err := attestation.GenerateStatement(attestation.GenerateOpts{
-			Predicate: predicate,
-			Type:      c.PredicateType,
-			Digest:    hexDigest,
-			Repo:      base,
-		})
+		opts.Predicate = predicate
+		opts.Type = c.PredicateType
+		sh, err := attestation.GenerateStatement(opts)
 		if err != nil {
 			return err
 		}
@@ -237,6 +237,68 @@ func (c *AttestBlobCommand) Exec(ctx context.Context, artifactPath string) error
 	return nil
 }
 
+// artifactDigest returns the hex encoded SHA-256 digest of the blob, or
+// --hash.
+func (c *AttestBlobCommand) artifactDigest(artifactPath string) (string, error) {
+	if c.ArtifactHash != "" {
+		return c.ArtifactHash, nil
+	}
+	var artifact []byte
+	var err error
+	if artifactPath == "-" {
+		artifact, err = io.ReadAll(os.Stdin)
+	} else {
+		fmt.Fprintln(os.Stderr, "Using payload from:", artifactPath)
+		artifact, err = os.ReadFile(filepath.Clean(artifactPath))
+	}
+	if err != nil {
+		return "", err
+	}
+	digest, _, err := signature.ComputeDigestForSigning(bytes.NewReader(artifact), crypto.SHA256, []crypto.Hash{crypto.SHA256, crypto.SHA384})
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(hex.EncodeToString(digest)), nil
+}
+
+// subjects returns the subjects of --subject and --subject-checksums.
+func (c *AttestBlobCommand) subjects() ([]attestation.Subject, error) {
+	var subjects []attestation.Subject
+	for _, s := range c.Subjects {
+		subject, ok, err := attestation.ParseDigestSubject(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject %q: %w", s, err)
+		}
+		if !ok {
+			f, err := os.Open(filepath.Clean(s))
+			if err != nil {
+				return nil, fmt.Errorf("reading subject: %w", err)
+			}
+			h := sha256.New()
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return nil, fmt.Errorf("hashing %s: %w", s, err)
+			}
+			subject = attestation.Subject{Name: path.Base(s), Digest: map[string]string{"sha256": hex.EncodeToString(h.Sum(nil))}}
+		}
+		subjects = append(subjects, subject)
+	}
+	if c.SubjectChecksumsPath != "" {
+		f, err := os.Open(filepath.Clean(c.SubjectChecksumsPath))
+		if err != nil {
+			return nil, fmt.Errorf("reading subject checksums: %w", err)
+		}
+		defer f.Close()
+		checksums, err := attestation.ParseChecksums(f)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", c.SubjectChecksumsPath, err)
+		}
+		subjects = append(subjects, checksums...)
+	}
+	return subjects, nil
+}
+
 func validateStatement(payload []byte) (string, error) {
 	var statement *intotov1.Statement
 	if err := json.Unmarshal(payload, &statement); err != nil {
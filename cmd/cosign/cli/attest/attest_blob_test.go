@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
@@ -25,6 +26,7 @@ import (
 	"encoding/pem"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -347,4 +349,99 @@ func TestStatementPath(t *testing.T) {
 	}
 	err := at.Exec(ctx, "")
 	assert.NoError(t, err)
+
+	// The statement is attested as is, so subjects cannot be added to it.
+	for _, extra := range []AttestBlobCommand{
+		{Subjects: []string{statementPath}},
+		{SubjectChecksumsPath: statementPath},
+	} {
+		extra.KeyOpts = at.KeyOpts
+		extra.StatementPath = statementPath
+		extra.RekorEntryType = "dsse"
+		err := extra.Exec(ctx, "")
+		assert.ErrorContains(t, err, "cannot be used with --statement")
+	}
+}
+
+func TestAttestBlobSubjects(t *testing.T) {
+	ctx := context.Background()
+	td := t.TempDir()
+
+	keys, _ := cosign.GenerateKeyPair(nil)
+	keyRef := writeFile(t, td, string(keys.PrivateBytes), "key.pem")
+	predicatePath := makeSLSA1PredicateFile(t, td)
+
+	blobPath := writeFile(t, td, "foo", "foo.txt")
+	otherPath := writeFile(t, td, "bar", "bar.txt")
+	otherDigest := sha256.Sum256([]byte("bar"))
+	manifest := strings.Repeat("a", 64)
+	release := strings.Repeat("b", 128)
+	checksumsPath := writeFile(t, td, release+"  release.zip\n", "SHA512SUMS")
+
+	subjects := func(t *testing.T, at AttestBlobCommand, artifactPath string) map[string]map[string]string {
+		t.Helper()
+		at.KeyOpts = options.KeyOpts{KeyRef: keyRef}
+		at.PredicatePath = predicatePath
+		at.PredicateType = "slsaprovenance1"
+		at.OutputSignature = filepath.Join(td, "dsse.intoto.jsonl")
+		at.RekorEntryType = "dsse"
+		if err := at.Exec(ctx, artifactPath); err != nil {
+			t.Fatal(err)
+		}
+		dsseBytes, err := os.ReadFile(at.OutputSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env := &ssldsse.Envelope{}
+		if err := json.Unmarshal(dsseBytes, env); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(env.Payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statement := &attestation.Statement{}
+		if err := statement.UnmarshalJSON(decoded); err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]map[string]string{}
+		for _, s := range statement.Subject {
+			got[s.Name] = s.Digest
+		}
+		return got
+	}
+
+	got := subjects(t, AttestBlobCommand{
+		Subjects:             []string{otherPath, "ghcr.io/org/app@sha256:" + manifest},
+		SubjectChecksumsPath: checksumsPath,
+	}, blobPath)
+	want := map[string]map[string]string{
+		"foo.txt":         {"sha256": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"},
+		"bar.txt":         {"sha256": hex.EncodeToString(otherDigest[:])},
+		"ghcr.io/org/app": {"sha256": manifest},
+		"release.zip":     {"sha512": release},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subjects = %v, want %v", got, want)
+	}
+
+	// The blob is optional when subjects are given.
+	got = subjects(t, AttestBlobCommand{SubjectChecksumsPath: checksumsPath}, "")
+	if len(got) != 1 || got["release.zip"] == nil {
+		t.Errorf("subjects = %v", got)
+	}
+
+	at := AttestBlobCommand{
+		KeyOpts:        options.KeyOpts{KeyRef: keyRef},
+		PredicatePath:  predicatePath,
+		PredicateType:  "slsaprovenance1",
+		RekorEntryType: "dsse",
+	}
+	if err := at.Exec(ctx, ""); err == nil || !strings.Contains(err.Error(), "--subject") {
+		t.Errorf("Exec() without blob or subjects error = %v", err)
+	}
+	at.Subjects = []string{"app@sha256:abcd"}
+	if err := at.Exec(ctx, ""); err == nil {
+		t.Error("expected error for an invalid subject digest")
+	}
 }
@@ -0,0 +1,320 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/download"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+	ociremote "github.com/sigstore/cosign/v3/pkg/oci/remote"
+)
+
+func testStatement(hex, predicateType string) string {
+	return fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"image","digest":{"sha256":%q}}],"predicateType":%q,"predicate":{"ok":true}}`, hex, predicateType)
+}
+
+func TestAttestBundle(t *testing.T) {
+	reg := registry.New()
+	var attManifestPuts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") && strings.HasSuffix(r.URL.Path, ".att") {
+			attManifestPuts.Add(1)
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ref, err := name.ParseReference(strings.TrimPrefix(srv.URL, "http://")+"/test/image:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := ref.Context().Digest(d.String())
+
+	td := t.TempDir()
+	keys, err := cosign.GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(td, "cosign.key")
+	if err := os.WriteFile(keyPath, keys.PrivateBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signed := fmt.Sprintf(`{"payloadType":"application/vnd.in-toto+json","payload":%q,"signatures":[{"keyid":"","sig":"c2ln"}]}`,
+		base64.StdEncoding.EncodeToString([]byte(testStatement(d.Hex, "https://example.com/c/v1"))))
+	bundlePath := filepath.Join(td, "attestations.jsonl")
+	contents := strings.Join([]string{
+		testStatement(d.Hex, "https://example.com/a/v1"),
+		testStatement(d.Hex, "https://example.com/b/v1"),
+		signed,
+	}, "\n")
+	if err := os.WriteFile(bundlePath, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	regOpts := options.RegistryOptions{AllowHTTPRegistry: true}
+	ac := AttestCommand{
+		KeyOpts:               options.KeyOpts{KeyRef: keyPath},
+		RegistryOptions:       regOpts,
+		AttestationBundlePath: bundlePath,
+		RekorEntryType:        "dsse",
+	}
+	if err := ac.Exec(t.Context(), digest.String()); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if got := attManifestPuts.Load(); got != 1 {
+		t.Errorf("attestation manifest written %d times, want 1", got)
+	}
+
+	out := filepath.Join(td, "downloaded.jsonl")
+	if err := download.AttestationCmd(t.Context(), regOpts, options.AttestationDownloadOptions{Output: out}, digest.String(), io.Discard); err != nil {
+		t.Fatalf("AttestationCmd() error = %v", err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	entries, err := attestation.ReadBundle(f)
+	if err != nil {
+		t.Fatalf("ReadBundle() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("downloaded %d attestations, want 3", len(entries))
+	}
+	for _, e := range entries {
+		if e.Envelope == nil || !e.HasSubject("sha256", d.Hex) {
+			t.Errorf("unexpected attestation %s", e.Payload)
+		}
+	}
+
+	var projected bytes.Buffer
+	query := options.AttestationQueryOptions{
+		Filters:  []string{`@.predicateType=="https://example.com/b/v1"`},
+		JSONPath: "{.subject[0].name}",
+	}
+	if err := download.AttestationCmd(t.Context(), regOpts, options.AttestationDownloadOptions{Query: query}, digest.String(), &projected); err != nil {
+		t.Fatalf("AttestationCmd() with query error = %v", err)
+	}
+	if projected.String() != "image\n" {
+		t.Errorf("AttestationCmd() with query wrote %q", projected.String())
+	}
+	query.Filters = []string{`@.predicateType=="https://example.com/none/v1"`}
+	if err := download.AttestationCmd(t.Context(), regOpts, options.AttestationDownloadOptions{Query: query}, digest.String(), io.Discard); err == nil {
+		t.Error("expected an error when no attestation matches the filters")
+	}
+
+	// With the new bundle format, statements are attached as Sigstore
+	// bundles and downloaded as their envelopes.
+	img2, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d2, err := img2.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref.Context().Digest(d2.String()), img2); err != nil {
+		t.Fatal(err)
+	}
+	bundlePath2 := filepath.Join(td, "attestations2.jsonl")
+	if err := os.WriteFile(bundlePath2, []byte(testStatement(d2.Hex, "https://example.com/a/v1")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ac2 := ac
+	ac2.NewBundleFormat = true
+	ac2.AttestationBundlePath = bundlePath2
+	if err := ac2.Exec(t.Context(), ref.Context().Digest(d2.String()).String()); err != nil {
+		t.Fatalf("Exec() with new bundle format error = %v", err)
+	}
+	out2 := filepath.Join(td, "downloaded2.jsonl")
+	if err := download.AttestationCmd(t.Context(), regOpts, options.AttestationDownloadOptions{Output: out2}, ref.Context().Digest(d2.String()).String(), io.Discard); err != nil {
+		t.Fatalf("AttestationCmd() error = %v", err)
+	}
+	b, err := os.ReadFile(out2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err = attestation.ReadBundle(bytes.NewReader(b))
+	if err != nil || len(entries) != 1 || !entries[0].HasSubject("sha256", d2.Hex) {
+		t.Errorf("ReadBundle() = %d entries, %v", len(entries), err)
+	}
+
+	other := filepath.Join(td, "other.jsonl")
+	if err := os.WriteFile(other, []byte(testStatement(strings.Repeat("0", 64), "https://example.com/a/v1")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ac.AttestationBundlePath = other
+	if err := ac.Exec(t.Context(), digest.String()); err == nil || !strings.Contains(err.Error(), "subject") {
+		t.Errorf("Exec() error = %v, want subject mismatch", err)
+	}
+}
+
+// TestAttestSPDX3PredicateTypeAnnotation verifies that the predicateType
+// annotation follows the statement, which for an SPDX 3 document is not the
+// URI --type spdxjson resolves to.
+func TestAttestSPDX3PredicateTypeAnnotation(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+
+	ref, err := name.ParseReference(strings.TrimPrefix(srv.URL, "http://")+"/test/image:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := ref.Context().Digest(d.String())
+
+	td := t.TempDir()
+	keys, err := cosign.GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(td, "cosign.key")
+	if err := os.WriteFile(keyPath, keys.PrivateBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	predicatePath := filepath.Join(td, "sbom.spdx3.json")
+	if err := os.WriteFile(predicatePath, []byte(`{"@context":"https://spdx.org/rdf/3.0.1/spdx-context.jsonld","@graph":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ac := AttestCommand{
+		KeyOpts:         options.KeyOpts{KeyRef: keyPath},
+		RegistryOptions: options.RegistryOptions{AllowHTTPRegistry: true},
+		PredicatePath:   predicatePath,
+		PredicateType:   "spdxjson",
+		RekorEntryType:  "dsse",
+	}
+	if err := ac.Exec(t.Context(), digest.String()); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	se, err := ociremote.SignedEntity(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	atts, err := se.Attestations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigs, err := atts.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 1 {
+		t.Fatalf("got %d attestations, want 1", len(sigs))
+	}
+	annotations, err := sigs[0].Annotations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := annotations["predicateType"]; got != attestation.SPDXDocumentV3 {
+		t.Errorf("predicateType annotation = %q, want %q", got, attestation.SPDXDocumentV3)
+	}
+}
+
+func TestAttestBundleRejected(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+
+	ref, err := name.ParseReference(strings.TrimPrefix(srv.URL, "http://")+"/test/image:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := ref.Context().Digest(d.String())
+
+	envelope := func(hex string) string {
+		return fmt.Sprintf(`{"payloadType":"application/vnd.in-toto+json","payload":%q,"signatures":[{"keyid":"","sig":"c2ln"}]}`,
+			base64.StdEncoding.EncodeToString([]byte(testStatement(hex, "https://example.com/a/v1"))))
+	}
+	other := strings.Repeat("0", len(d.Hex))
+
+	tests := []struct {
+		name            string
+		bundle          string
+		newBundleFormat bool
+		wantErr         string
+	}{
+		{"envelope about another image", envelope(other), false, "does not have"},
+		{"statement about another image", testStatement(other, "https://example.com/a/v1"), false, "does not have"},
+		{"envelope with the new bundle format", envelope(d.Hex), true, "cannot be attached with --new-bundle-format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundlePath := filepath.Join(t.TempDir(), "attestations.jsonl")
+			if err := os.WriteFile(bundlePath, []byte(tt.bundle), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			ac := AttestCommand{
+				KeyOpts:               options.KeyOpts{NewBundleFormat: tt.newBundleFormat},
+				RegistryOptions:       options.RegistryOptions{AllowHTTPRegistry: true},
+				AttestationBundlePath: bundlePath,
+				RekorEntryType:        "dsse",
+			}
+			if err := ac.Exec(t.Context(), digest.String()); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Exec() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
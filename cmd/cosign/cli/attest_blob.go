@@ -47,6 +47,12 @@ func AttestBlob() *cobra.Command {
   # attach an attestation to a blob with a key pair stored in Hashicorp Vault
   cosign attest-blob --predicate <FILE> --type <TYPE> --key hashivault://[KEY] <BLOB>
 
+  # attest all files of a release, listed in a checksums file, in one statement
+  cosign attest-blob --predicate <FILE> --type <TYPE> --key cosign.key --bundle release.sigstore.json --subject-checksums SHA256SUMS
+
+  # attest the platform manifests of an index in one statement
+  cosign attest-blob --predicate <FILE> --type <TYPE> --key cosign.key --bundle <path> --subject <IMAGE>@sha256:<DIGEST> --subject <IMAGE>@sha256:<DIGEST>
+
   # supply attestation via stdin
   echo <PAYLOAD> | cosign attest-blob --predicate - --yes`,
 
@@ -58,7 +64,10 @@ func AttestBlob() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if o.Predicate.Statement == "" && len(args) != 1 {
+			if err := o.Predicate.RegisterSchemas(); err != nil {
+				return err
+			}
+			if o.Predicate.Statement == "" && len(o.Subjects) == 0 && o.SubjectChecksums == "" && len(args) != 1 {
 				return cobra.ExactArgs(1)(cmd, args)
 			}
 			oidcClientSecret, err := o.OIDC.ClientSecret()
@@ -68,7 +77,7 @@ func AttestBlob() *cobra.Command {
 
 			ko := options.KeyOpts{
 				KeyRef:                         o.Key,
-				PassFunc:                       generate.GetPass,
+				PassFunc:                       generate.PassFunc(o.PasswordShare.Files, o.PasswordShare.Prompt),
 				Sk:                             o.SecurityKey.Use,
 				Slot:                           o.SecurityKey.Slot,
 				FulcioURL:                      o.Fulcio.URL,
@@ -100,19 +109,21 @@ func AttestBlob() *cobra.Command {
 			}
 
 			v := attest.AttestBlobCommand{
-				KeyOpts:           ko,
-				CertPath:          o.Cert,
-				CertChainPath:     o.CertChain,
-				ArtifactHash:      o.Hash,
-				TlogUpload:        o.TlogUpload,
-				PredicateType:     o.Predicate.Type,
-				PredicatePath:     o.Predicate.Path,
-				StatementPath:     o.Predicate.Statement,
-				OutputSignature:   o.OutputSignature,
-				OutputAttestation: o.OutputAttestation,
-				OutputCertificate: o.OutputCertificate,
-				Timeout:           ro.Timeout,
-				RekorEntryType:    o.RekorEntryType,
+				KeyOpts:              ko,
+				CertPath:             o.Cert,
+				CertChainPath:        o.CertChain,
+				ArtifactHash:         o.Hash,
+				Subjects:             o.Subjects,
+				SubjectChecksumsPath: o.SubjectChecksums,
+				TlogUpload:           o.TlogUpload,
+				PredicateType:        o.Predicate.Type,
+				PredicatePath:        o.Predicate.Path,
+				StatementPath:        o.Predicate.Statement,
+				OutputSignature:      o.OutputSignature,
+				OutputAttestation:    o.OutputAttestation,
+				OutputCertificate:    o.OutputCertificate,
+				Timeout:              ro.Timeout,
+				RekorEntryType:       o.RekorEntryType,
 			}
 			var artifactPath string
 			if len(args) == 1 {
@@ -104,13 +104,16 @@ func New() *cobra.Command {
 	cmd.AddCommand(Download())
 	cmd.AddCommand(Generate())
 	cmd.AddCommand(GenerateKeyPair())
+	cmd.AddCommand(GitSign())
 	cmd.AddCommand(ImportKeyPair())
 	cmd.AddCommand(Initialize())
+	cmd.AddCommand(Key())
 	cmd.AddCommand(Load())
 	cmd.AddCommand(Manifest())
 	cmd.AddCommand(PIVTool())
 	cmd.AddCommand(PKCS11Tool())
 	cmd.AddCommand(PublicKey())
+	cmd.AddCommand(Revoke())
 	cmd.AddCommand(Save())
 	cmd.AddCommand(Sign())
 	cmd.AddCommand(SignBlob())
@@ -119,6 +122,7 @@ func New() *cobra.Command {
 	cmd.AddCommand(VerifyAttestation())
 	cmd.AddCommand(VerifyBlob())
 	cmd.AddCommand(VerifyBlobAttestation())
+	cmd.AddCommand(VerifyCommit())
 	cmd.AddCommand(Triangulate())
 	cmd.AddCommand(TrustedRoot())
 	cmd.AddCommand(SigningConfig())
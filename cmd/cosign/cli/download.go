@@ -88,9 +88,21 @@ func downloadAttestation() *cobra.Command {
 	ao := &options.AttestationDownloadOptions{}
 
 	cmd := &cobra.Command{
-		Use:              "attestation",
-		Short:            "Download in-toto attestations from the supplied container image",
-		Example:          "  cosign download attestation <image uri> [--predicate-type]",
+		Use:   "attestation",
+		Short: "Download in-toto attestations from the supplied container image",
+		Example: `  cosign download attestation <image uri> [--predicate-type]
+
+  # export the attestations as an in-toto attestation bundle
+  cosign download attestation --output attestations.jsonl <image uri>
+
+  # print the scanners of vulnerability scans finished after a date
+  cosign download attestation --predicate-type vuln --filter '@.predicate.metadata.scanFinishedOn>"2024-01-01T00:00:00Z"' --jsonpath '{.predicate.scanner.uri}' <image uri>
+
+  # convert the SPDX SBOM attestations to CycloneDX
+  cosign download attestation --type spdxjson --convert cyclonedx <image uri>
+
+  # list the components and licenses of all SBOM attestations, whatever their format
+  cosign download attestation --convert components <image uri>`,
 		Args:             cobra.ExactArgs(1),
 		PersistentPreRun: options.BindViper,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -21,12 +21,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	ssldsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
 	"github.com/sigstore/cosign/v3/pkg/oci/platform"
 	ociremote "github.com/sigstore/cosign/v3/pkg/oci/remote"
+	sgbundle "github.com/sigstore/sigstore-go/pkg/bundle"
 )
 
 func AttestationCmd(ctx context.Context, regOpts options.RegistryOptions, attOptions options.AttestationDownloadOptions, imageRef string, out io.Writer) error {
@@ -47,34 +53,54 @@ func AttestationCmd(ctx context.Context, regOpts options.RegistryOptions, attOpt
 		}
 	}
 
+	query, err := attOptions.Query.Query()
+	if err != nil {
+		return err
+	}
+	if attOptions.Convert != "" && !slices.Contains(attestation.SBOMConversions(), attOptions.Convert) {
+		return fmt.Errorf("invalid --convert %q, expected one of %s", attOptions.Convert, strings.Join(attestation.SBOMConversions(), ", "))
+	}
+
+	if attOptions.Output != "" {
+		f, err := os.Create(attOptions.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
 	var foundMatches bool
 
 	// Try bundles first
 	newBundles, _, err := cosign.GetBundles(ctx, ref, ociremoteOpts)
 	if err == nil && len(newBundles) > 0 {
 		for _, eachBundle := range newBundles {
-			if predicateType != "" {
-				envelope, err := eachBundle.Envelope()
+			var envelope *sgbundle.Envelope
+			if predicateType != "" || attOptions.Output != "" || attOptions.Convert != "" || query != nil {
+				envelope, err = eachBundle.Envelope()
 				if err != nil || envelope == nil {
 					continue
 				}
+			}
+			if predicateType != "" {
 				statement, err := envelope.Statement()
 				if err != nil || statement == nil {
 					continue
 				}
-				if statement.PredicateType != predicateType {
+				if !attestation.MatchesPredicateType(attOptions.PredicateType, statement.PredicateType) {
 					continue
 				}
 			}
-			b, err := json.Marshal(eachBundle)
-			if err != nil {
-				return err
+			var env *ssldsse.Envelope
+			if envelope != nil {
+				env = envelope.Envelope
 			}
-			_, err = out.Write(append(b, byte('\n')))
+			matched, err := writeAttestation(out, attOptions, query, env, eachBundle)
 			if err != nil {
 				return err
 			}
-			foundMatches = true
+			foundMatches = foundMatches || matched
 		}
 	}
 
@@ -104,20 +130,85 @@ func AttestationCmd(ctx context.Context, regOpts options.RegistryOptions, attOpt
 	}
 
 	for _, att := range attestations {
-		b, err := json.Marshal(att)
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(append(b, byte('\n')))
+		matched, err := writeAttestation(out, attOptions, query, attestationEnvelope(att), att)
 		if err != nil {
 			return err
 		}
-		foundMatches = true
+		foundMatches = foundMatches || matched
 	}
 
 	if predicateType != "" && !foundMatches {
 		return fmt.Errorf("no attestations with predicate type '%s' found", predicateType)
 	}
+	if len(attOptions.Query.Filters) > 0 && !foundMatches {
+		return errors.New("no attestations matching the filters found")
+	}
+	if attOptions.Convert != "" && !foundMatches {
+		return errors.New("no SBOM attestations found")
+	}
 
 	return nil
 }
+
+// writeAttestation writes the attestation to out if it matches the query:
+// as a line of an in-toto attestation bundle with --output, as the query
+// projection with --jsonpath, as the converted SBOM with --convert, or as
+// is. It reports whether it matched.
+func writeAttestation(out io.Writer, attOptions options.AttestationDownloadOptions, query *attestation.Query, env *ssldsse.Envelope, att any) (bool, error) {
+	if query != nil || attOptions.Convert != "" {
+		statement, err := env.DecodeB64Payload()
+		if err != nil {
+			return false, fmt.Errorf("decoding attestation payload: %w", err)
+		}
+		matched, err := query.Matches(statement)
+		if err != nil || !matched {
+			return false, err
+		}
+		if query.HasProjection() {
+			return true, query.Project(out, statement)
+		}
+		if attOptions.Convert != "" {
+			return convertSBOM(out, attOptions, statement)
+		}
+	}
+	if attOptions.Output != "" {
+		// An in-toto attestation bundle holds the envelopes only.
+		return true, attestation.WriteBundleEnvelope(out, env)
+	}
+	b, err := json.Marshal(att)
+	if err != nil {
+		return false, err
+	}
+	_, err = out.Write(append(b, byte('\n')))
+	return true, err
+}
+
+func attestationEnvelope(att cosign.AttestationPayload) *ssldsse.Envelope {
+	env := &ssldsse.Envelope{
+		PayloadType: att.PayloadType,
+		Payload:     att.PayLoad,
+	}
+	for _, sig := range att.Signatures {
+		env.Signatures = append(env.Signatures, ssldsse.Signature{KeyID: sig.KeyID, Sig: sig.Sig})
+	}
+	return env
+}
+
+// convertSBOM writes the SBOM of the statement in the --convert format.
+// Attestations that are not SBOMs are skipped unless a predicate type was
+// requested.
+func convertSBOM(out io.Writer, attOptions options.AttestationDownloadOptions, statement []byte) (bool, error) {
+	sbom, err := attestation.StatementSBOM(statement)
+	if errors.Is(err, attestation.ErrUnknownSBOMFormat) && attOptions.PredicateType == "" {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading SBOM attestation: %w", err)
+	}
+	b, err := sbom.Convert(attOptions.Convert)
+	if err != nil {
+		return false, err
+	}
+	_, err = out.Write(append(b, byte('\n')))
+	return true, err
+}
@@ -0,0 +1,191 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package download
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+	"github.com/sigstore/cosign/v3/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/v3/pkg/oci/remote"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	"github.com/sigstore/cosign/v3/pkg/types"
+)
+
+const syftSBOM = `{
+	"artifacts": [
+		{"name": "openssl", "version": "3.1.0", "purl": "pkg:apk/openssl@3.1.0", "licenses": [{"value": "Apache-2.0"}]},
+		{"name": "zlib", "version": "1.3", "licenses": ["Zlib"]}
+	],
+	"descriptor": {"name": "syft"},
+	"schema": {"version": "16.0.0"}
+}`
+
+// attachAttestations attaches unsigned envelopes of statements with the
+// predicates to the image, keyed by predicate type.
+func attachAttestations(t *testing.T, ref name.Reference, predicates map[string]string) name.Digest {
+	t.Helper()
+	img, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := ref.Context().Digest(d.String())
+
+	se, err := ociremote.SignedEntity(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for predicateType, predicate := range predicates {
+		statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"image","digest":{"sha256":%q}}],"predicateType":%q,"predicate":%s}`,
+			d.Hex, predicateType, predicate)
+		envelope, err := json.Marshal(map[string]any{
+			"payloadType": types.IntotoPayloadType,
+			"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+			"signatures":  []map[string]string{{"keyid": "", "sig": "c2ln"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		att, err := static.NewAttestation(envelope,
+			static.WithLayerMediaType(types.DssePayloadType),
+			static.WithAnnotations(map[string]string{"predicateType": predicateType}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if se, err = mutate.AttachAttestationToEntity(se, att); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ociremote.WriteAttestations(digest.Repository, se); err != nil {
+		t.Fatal(err)
+	}
+	return digest
+}
+
+func TestAttestationCmdConvert(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+	repo := strings.TrimPrefix(srv.URL, "http://") + "/test/image"
+	regOpts := options.RegistryOptions{AllowHTTPRegistry: true}
+
+	ref, err := name.ParseReference(repo+":sbom", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := attachAttestations(t, ref, map[string]string{
+		"https://syft.dev/bom":     syftSBOM,
+		"https://example.com/a/v1": `{"builder":{}}`,
+	})
+
+	var out bytes.Buffer
+	if err := AttestationCmd(t.Context(), regOpts, options.AttestationDownloadOptions{Convert: attestation.SBOMConvertComponents}, digest.String(), &out); err != nil {
+		t.Fatalf("AttestationCmd() with --convert error = %v", err)
+	}
+	// The statement that is not an SBOM is skipped.
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("AttestationCmd() with --convert wrote %d documents, want 1", len(lines))
+	}
+	var sbom attestation.SBOM
+	if err := json.Unmarshal([]byte(lines[0]), &sbom); err != nil {
+		t.Fatal(err)
+	}
+	if sbom.Format != attestation.SBOMFormatSyft || len(sbom.Components) != 2 {
+		t.Errorf("AttestationCmd() with --convert = %s", lines[0])
+	}
+
+	// Unless its predicate type was requested.
+	convertOpts := options.AttestationDownloadOptions{Convert: attestation.SBOMConvertComponents, PredicateType: "https://example.com/a/v1"}
+	if err := AttestationCmd(t.Context(), regOpts, convertOpts, digest.String(), io.Discard); err == nil || !strings.Contains(err.Error(), "reading SBOM attestation") {
+		t.Errorf("AttestationCmd() with --convert and --predicate-type error = %v", err)
+	}
+
+	if err := AttestationCmd(t.Context(), regOpts, options.AttestationDownloadOptions{Convert: "spdx"}, digest.String(), io.Discard); err == nil || !strings.Contains(err.Error(), "invalid --convert") {
+		t.Errorf("AttestationCmd() with an unknown conversion error = %v", err)
+	}
+
+	ref, err = name.ParseReference(repo+":nosbom", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest = attachAttestations(t, ref, map[string]string{"https://example.com/a/v1": `{"builder":{}}`})
+	if err := AttestationCmd(t.Context(), regOpts, options.AttestationDownloadOptions{Convert: attestation.SBOMConvertComponents}, digest.String(), io.Discard); err == nil || !strings.Contains(err.Error(), "no SBOM") {
+		t.Errorf("AttestationCmd() with --convert error = %v, want no SBOM", err)
+	}
+}
+
+func TestAttestationCmdAlternativePredicateTypes(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+	repo := strings.TrimPrefix(srv.URL, "http://") + "/test/image"
+	regOpts := options.RegistryOptions{AllowHTTPRegistry: true}
+
+	ref, err := name.ParseReference(repo+":sbom", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := attachAttestations(t, ref, map[string]string{
+		attestation.SPDXDocumentV3: `{
+			"@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld",
+			"@graph": [{"type": "software_Package", "spdxId": "urn:openssl", "name": "openssl", "software_packageVersion": "3.1.0"}]
+		}`,
+		"https://cyclonedx.org/bom/v1.6": `{"bomFormat": "CycloneDX", "specVersion": "1.6", "components": [{"type": "library", "name": "zlib", "version": "1.3"}]}`,
+		"https://syft.dev/bom":           syftSBOM,
+	})
+
+	// The SPDX and CycloneDX predicate types match the versioned URIs
+	// producers use, as they do when verifying.
+	for predicateType, want := range map[string]attestation.SBOMFormat{
+		"spdxjson":  attestation.SBOMFormatSPDX3,
+		"cyclonedx": attestation.SBOMFormatCycloneDXJSON,
+	} {
+		var out bytes.Buffer
+		opts := options.AttestationDownloadOptions{PredicateType: predicateType, Convert: attestation.SBOMConvertComponents}
+		if err := AttestationCmd(t.Context(), regOpts, opts, digest.String(), &out); err != nil {
+			t.Fatalf("AttestationCmd() with --type %s error = %v", predicateType, err)
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != 1 {
+			t.Fatalf("AttestationCmd() with --type %s wrote %d documents, want 1", predicateType, len(lines))
+		}
+		var sbom attestation.SBOM
+		if err := json.Unmarshal([]byte(lines[0]), &sbom); err != nil {
+			t.Fatal(err)
+		}
+		if sbom.Format != want || len(sbom.Components) != 1 {
+			t.Errorf("AttestationCmd() with --type %s = %s", predicateType, lines[0])
+		}
+	}
+}
@@ -26,8 +26,15 @@ import (
 
 	"github.com/sigstore/cosign/v3/pkg/cosign/env"
 	"github.com/sigstore/cosign/v3/pkg/cosign/git"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/azuredevops"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/bitbucket"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/gitea"
 	"github.com/sigstore/cosign/v3/pkg/cosign/git/github"
 	"github.com/sigstore/cosign/v3/pkg/cosign/git/gitlab"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets/awssm"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets/gcpsm"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets/vault"
 
 	icos "github.com/sigstore/cosign/v3/internal/pkg/cosign"
 	"github.com/sigstore/cosign/v3/internal/ui"
@@ -44,6 +51,13 @@ var (
 
 // nolint
 func GenerateKeyPairCmd(ctx context.Context, kmsVal string, outputKeyPrefixVal string, args []string) error {
+	return GenerateKeyPairCmdWithOptions(ctx, kmsVal, outputKeyPrefixVal, kubernetes.KeyPairOptions{}, args)
+}
+
+// GenerateKeyPairCmdWithOptions is GenerateKeyPairCmd with the options used
+// to store key pairs generated for k8s:// references.
+// nolint
+func GenerateKeyPairCmdWithOptions(ctx context.Context, kmsVal string, outputKeyPrefixVal string, k8sOpts kubernetes.KeyPairOptions, args []string) error {
 	privateKeyFileName := outputKeyPrefixVal + ".key"
 	publicKeyFileName := outputKeyPrefixVal + ".pub"
 
@@ -75,12 +89,18 @@ func GenerateKeyPairCmd(ctx context.Context, kmsVal string, outputKeyPrefixVal s
 		}
 
 		provider, targetRef := split[0], split[1]
+		if provider != "k8s" && k8sOpts != (kubernetes.KeyPairOptions{}) {
+			return errors.New("--dry-run, --sealed-secrets-cert and --external-secret-store require a k8s:// reference")
+		}
 
 		switch provider {
 		case "k8s":
-			return kubernetes.KeyPairSecret(ctx, targetRef, GetPass)
-		case gitlab.ReferenceScheme, github.ReferenceScheme:
+			return kubernetes.KeyPairSecretWithOptions(ctx, targetRef, GetPass, k8sOpts)
+		case gitlab.ReferenceScheme, github.ReferenceScheme, gitea.ReferenceScheme, gitea.ForgejoReferenceScheme,
+			bitbucket.ReferenceScheme, azuredevops.ReferenceScheme:
 			return git.GetProvider(provider).PutSecret(ctx, targetRef, GetPass)
+		case vault.ReferenceScheme, awssm.ReferenceScheme, gcpsm.ReferenceScheme:
+			return secrets.GetProvider(provider).PutSecret(ctx, targetRef, GetPass)
 		}
 
 		return fmt.Errorf("undefined provider: %s", provider)
@@ -0,0 +1,152 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	icos "github.com/sigstore/cosign/v3/internal/pkg/cosign"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/shamir"
+	"golang.org/x/term"
+)
+
+// readShare reads one password share at a prompt. It is a variable so that
+// tests can answer the prompts.
+var readShare = readShareFromTerm
+
+// GenerateSplitKeyPairCmd generates a key pair encrypted with a random
+// password that is never shown. The password is written as the given number
+// of Shamir shares, any threshold of which decrypt the private key.
+func GenerateSplitKeyPairCmd(ctx context.Context, outputKeyPrefixVal string, shares, threshold int) error {
+	privateKeyFileName := outputKeyPrefixVal + ".key"
+	publicKeyFileName := outputKeyPrefixVal + ".pub"
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return err
+	}
+	password := []byte(base64.RawStdEncoding.EncodeToString(random))
+	split, err := shamir.Split(password, shares, threshold)
+	if err != nil {
+		return fmt.Errorf("splitting password: %w", err)
+	}
+	keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) {
+		return password, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fileExists, err := icos.FileExists(privateKeyFileName)
+	if err != nil {
+		return fmt.Errorf("failed checking if %s exists: %w", privateKeyFileName, err)
+	}
+	if fileExists {
+		ui.Warnf(ctx, "File %s already exists. Overwrite?", privateKeyFileName)
+		if err := ui.ConfirmContinue(ctx); err != nil {
+			return err
+		}
+	}
+	if err := writeKeyFiles(privateKeyFileName, publicKeyFileName, keys); err != nil {
+		return err
+	}
+
+	for _, s := range split {
+		shareFileName := fmt.Sprintf("%s.share-%d", outputKeyPrefixVal, s.Index)
+		if err := os.WriteFile(shareFileName, []byte(s.String()+"\n"), 0600); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Password share written to", shareFileName)
+	}
+	ui.Infof(ctx, "Any %d of the %d password shares are needed to use %s. Hand each share to a different operator and remove it from this machine.",
+		threshold, shares, privateKeyFileName)
+
+	return nil
+}
+
+// PassFunc returns GetPass, unless password shares are given as files or
+// prompt is set. The password is then reconstructed in memory from the
+// shares, prompting for the ones missing from the files when prompt is set.
+func PassFunc(shareFiles []string, prompt bool) cosign.PassFunc {
+	if len(shareFiles) == 0 && !prompt {
+		return GetPass
+	}
+	// Signing with several keys asks for the password once per key, but the
+	// shares should only be collected once.
+	combine := sync.OnceValues(func() ([]byte, error) {
+		return combineShares(shareFiles, prompt)
+	})
+	return func(bool) ([]byte, error) {
+		return combine()
+	}
+}
+
+func combineShares(shareFiles []string, prompt bool) ([]byte, error) {
+	var shares []shamir.Share
+	for _, f := range shareFiles {
+		b, err := os.ReadFile(filepath.Clean(f))
+		if err != nil {
+			return nil, err
+		}
+		s, err := shamir.ParseShare(string(b))
+		if err != nil {
+			return nil, fmt.Errorf("reading password share %s: %w", f, err)
+		}
+		shares = append(shares, s)
+	}
+	for prompt && (len(shares) == 0 || len(shares) < shares[0].Threshold) {
+		p := fmt.Sprintf("Enter password share %d: ", len(shares)+1)
+		if len(shares) > 0 {
+			p = fmt.Sprintf("Enter password share %d of %d: ", len(shares)+1, shares[0].Threshold)
+		}
+		line, err := readShare(p)
+		if err != nil {
+			return nil, err
+		}
+		s, err := shamir.ParseShare(line)
+		if err != nil {
+			return nil, fmt.Errorf("reading password share: %w", err)
+		}
+		shares = append(shares, s)
+	}
+	password, err := shamir.Combine(shares)
+	if err != nil {
+		return nil, fmt.Errorf("combining password shares: %w", err)
+	}
+	return password, nil
+}
+
+func readShareFromTerm(prompt string) (string, error) {
+	if !cosign.IsTerminal() {
+		return "", errors.New("prompting for password shares requires a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	// Unnecessary convert of syscall.Stdin on *nix, but Windows is a uintptr
+	// nolint:unconvert
+	b, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr)
+	return string(b), err
+}
@@ -0,0 +1,115 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+)
+
+func TestGenerateSplitKeyPairCmd(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "root")
+	if err := GenerateSplitKeyPairCmd(context.Background(), prefix, 3, 2); err != nil {
+		t.Fatal(err)
+	}
+	key, err := os.ReadFile(prefix + ".key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	share := func(i string) string { return prefix + ".share-" + i }
+
+	for _, files := range [][]string{{share("1"), share("2")}, {share("3"), share("1")}, {share("2"), share("3"), share("1")}} {
+		pw, err := PassFunc(files, false)(false)
+		if err != nil {
+			t.Fatalf("PassFunc(%v): %v", files, err)
+		}
+		if _, err := cosign.LoadPrivateKey(key, pw, nil); err != nil {
+			t.Errorf("LoadPrivateKey() with password from %v: %v", files, err)
+		}
+	}
+
+	if _, err := PassFunc([]string{share("1")}, false)(false); err == nil {
+		t.Error("PassFunc() with a single share should fail")
+	}
+	if _, err := PassFunc([]string{prefix + ".key", share("1")}, false)(false); err == nil {
+		t.Error("PassFunc() with a file that is not a share should fail")
+	}
+}
+
+func TestPassFuncPrompt(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "root")
+	if err := GenerateSplitKeyPairCmd(context.Background(), prefix, 5, 3); err != nil {
+		t.Fatal(err)
+	}
+	key, err := os.ReadFile(prefix + ".key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers := map[string]string{}
+	for _, i := range []string{"2", "4", "5"} {
+		b, err := os.ReadFile(prefix + ".share-" + i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		answers[i] = string(b)
+	}
+
+	var prompts []string
+	next := []string{"4", "5"}
+	readShare = func(p string) (string, error) {
+		prompts = append(prompts, p)
+		a := answers[next[0]]
+		next = next[1:]
+		return a, nil
+	}
+	t.Cleanup(func() { readShare = readShareFromTerm })
+
+	pf := PassFunc([]string{prefix + ".share-2"}, true)
+	pw, err := pf(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cosign.LoadPrivateKey(key, pw, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Enter password share 2 of 3: ", "Enter password share 3 of 3: "}
+	if len(prompts) != len(want) || prompts[0] != want[0] || prompts[1] != want[1] {
+		t.Errorf("prompts = %q, want %q", prompts, want)
+	}
+
+	// The shares are only collected once.
+	if _, err := pf(false); err != nil {
+		t.Fatal(err)
+	}
+	if len(prompts) != 2 {
+		t.Errorf("got %d prompts after a second call, want 2", len(prompts))
+	}
+}
+
+func TestPassFuncWithoutShares(t *testing.T) {
+	t.Setenv("COSIGN_PASSWORD", "foo")
+	pw, err := PassFunc(nil, false)(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(pw) != "foo" {
+		t.Errorf("PassFunc() = %q, want the COSIGN_PASSWORD value", pw)
+	}
+}
@@ -16,8 +16,11 @@
 package cli
 
 import (
+	"errors"
+
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/cosign/kubernetes"
 	"github.com/spf13/cobra"
 )
 
@@ -36,6 +39,9 @@ func GenerateKeyPair() *cobra.Command {
   # generate key-pair and write to custom named my-name.key and my-name.pub files
   cosign generate-key-pair --output-key-prefix my-name
 
+  # encrypt the private key with a random password written as 5 shares, any 3 of which are needed to sign
+  cosign generate-key-pair --output-key-prefix root --password-shares 5 --password-threshold 3
+
   # generate a key-pair in Azure Key Vault
   cosign generate-key-pair --kms azurekms://[VAULT_NAME][VAULT_URI]/[KEY]
 
@@ -51,6 +57,18 @@ func GenerateKeyPair() *cobra.Command {
   # generate a key-pair in Kubernetes Secret
   cosign generate-key-pair k8s://[NAMESPACE]/[NAME]
 
+  # generate a key-pair in Kubernetes Secret with custom data keys
+  cosign generate-key-pair 'k8s://[NAMESPACE]/[NAME]?key=[KEY]&password=[KEY]&pub=[KEY]'
+
+  # print the Kubernetes Secret manifest instead of creating it
+  cosign generate-key-pair --dry-run k8s://[NAMESPACE]/[NAME]
+
+  # print a SealedSecret manifest sealed with the sealed-secrets controller certificate
+  cosign generate-key-pair --sealed-secrets-cert cert.pem k8s://[NAMESPACE]/[NAME]
+
+  # store a key-pair in Hashicorp Vault and print an ExternalSecret manifest syncing it to Kubernetes
+  cosign generate-key-pair --external-secret-store ClusterSecretStore/[STORE] --external-secret-ref vault://[MOUNT]/[PATH] k8s://[NAMESPACE]/[NAME]
+
   # generate a key-pair in GitHub
   cosign generate-key-pair github://[OWNER]/[PROJECT_NAME]
 
@@ -66,13 +84,46 @@ func GenerateKeyPair() *cobra.Command {
   # generate a key-pair in GitLab with subgroup name
   cosign generate-key-pair gitlab://[GROUP_NAME]/[SUBGROUP_NAME]
 
+  # generate a key-pair in Gitea or Forgejo Actions secrets of an organization or repository
+  cosign generate-key-pair gitea://[OWNER]
+  cosign generate-key-pair forgejo://[OWNER]/[REPO]
+
+  # generate a key-pair in Bitbucket pipeline variables of a workspace or repository
+  cosign generate-key-pair bitbucket://[WORKSPACE]
+  cosign generate-key-pair bitbucket://[WORKSPACE]/[REPO]
+
+  # generate a key-pair in an Azure DevOps variable group (named cosign by default)
+  cosign generate-key-pair azuredevops://[ORGANIZATION]/[PROJECT]
+  cosign generate-key-pair azuredevops://[ORGANIZATION]/[PROJECT]/[VARIABLE_GROUP]
+
+  # store an encrypted key-pair and its password in a Hashicorp Vault KV v2 secret
+  cosign generate-key-pair vault://[MOUNT]/[PATH]
+
+  # store an encrypted key-pair and its password in AWS Secrets Manager
+  cosign generate-key-pair awssm://[SECRET_NAME]
+
+  # store an encrypted key-pair and its password in GCP Secret Manager
+  cosign generate-key-pair gcpsm://projects/[PROJECT]/secrets/[SECRET]
+
 CAVEATS:
   This command interactively prompts for a password. You can use
   the COSIGN_PASSWORD environment variable to provide one.`,
 
 		PersistentPreRun: options.BindViper,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return generate.GenerateKeyPairCmd(cmd.Context(), o.KMS, o.OutputKeyPrefix, args)
+			if o.PasswordShares > 0 || o.PasswordThreshold > 0 {
+				if o.KMS != "" || len(args) > 0 {
+					return errors.New("--password-shares can only be used when writing the key pair to files")
+				}
+				return generate.GenerateSplitKeyPairCmd(cmd.Context(), o.OutputKeyPrefix, o.PasswordShares, o.PasswordThreshold)
+			}
+			k8sOpts := kubernetes.KeyPairOptions{
+				DryRun:              o.DryRun,
+				SealedSecretsCert:   o.SealedSecretsCert,
+				ExternalSecretStore: o.ExternalSecretStore,
+				ExternalSecretRef:   o.ExternalSecretRef,
+			}
+			return generate.GenerateKeyPairCmdWithOptions(cmd.Context(), o.KMS, o.OutputKeyPrefix, k8sOpts, args)
 		},
 	}
 
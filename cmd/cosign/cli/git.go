@@ -0,0 +1,194 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/gitcli"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/signcommon"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/verify"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func GitSign() *cobra.Command {
+	o := &options.GitSignOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "git-sign",
+		Short: "Sign git commits and tags, invoked by git as its signing program",
+		Long: `Sign git commits and tags with cosign key references or keyless identities.
+
+git does not call this command directly with cosign flags, so configure it through a
+small wrapper script that passes any cosign flags and then the arguments from git.
+
+With gpg.format=x509 the signature is a Sigstore bundle, using Fulcio, Rekor and the
+timestamp authority exactly as sign-blob does. With gpg.format=ssh the signature is
+an SSH signature made with the key reference in user.signingkey, which must be an
+ECDSA or Ed25519 key. Signatures of either kind are verified with cosign verify-commit.
+
+git supplies the payload on stdin, so confirmation prompts are skipped.`,
+		Example: `  # sign commits keylessly with Fulcio and Rekor
+  printf '#!/bin/sh\nexec cosign git-sign "$@"\n' > ~/bin/cosign-git && chmod +x ~/bin/cosign-git
+  git config gpg.format x509
+  git config gpg.x509.program ~/bin/cosign-git
+  git commit -S -m "signed commit"
+
+  # sign commits and tags with a key pair stored in a KMS, recorded in Rekor
+  printf '#!/bin/sh\nexec cosign git-sign --key awskms://[ENDPOINT]/[ID/ALIAS/ARN] "$@"\n' > ~/bin/cosign-git
+  git tag -s v1.0.0 -m "release v1.0.0"
+
+  # produce SSH signatures with any cosign key reference
+  git config gpg.format ssh
+  git config gpg.ssh.program ~/bin/cosign-git
+  git config user.signingkey gcpkms://projects/[PROJECT]/locations/global/keyRings/[KEYRING]/cryptoKeys/[KEY]`,
+		Args:             cobra.MaximumNArgs(1),
+		PersistentPreRun: options.BindViper,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.SSHOperation != "" {
+				if o.SSHOperation != "sign" {
+					return fmt.Errorf("unsupported operation -Y %s, verify SSH signatures with cosign verify-commit", o.SSHOperation)
+				}
+				if o.SSHAgent {
+					return fmt.Errorf("keys held by ssh-agent are not supported, set user.signingkey to a cosign key reference")
+				}
+				if len(args) != 1 {
+					return fmt.Errorf("requires the file to sign")
+				}
+				keyRef := o.Key
+				if keyRef == "" {
+					keyRef = o.SSHKeyFile
+				}
+				return gitcli.SignSSHCmd(cmd.Context(), keyRef, generate.GetPass, o.SSHNamespace, args[0])
+			}
+
+			if !o.Sign || !o.DetachSign {
+				return fmt.Errorf("only detached signing (-bs) is supported, verify signatures with cosign verify-commit")
+			}
+			if o.Key != "" && o.SecurityKey.Use {
+				return &options.KeyParseError{}
+			}
+			oidcClientSecret, err := o.OIDC.ClientSecret()
+			if err != nil {
+				return err
+			}
+			ko := options.KeyOpts{
+				KeyRef:                         o.Key,
+				PassFunc:                       generate.GetPass,
+				Sk:                             o.SecurityKey.Use,
+				Slot:                           o.SecurityKey.Slot,
+				FulcioURL:                      o.Fulcio.URL,
+				IDToken:                        o.Fulcio.IdentityToken,
+				FulcioAuthFlow:                 o.Fulcio.AuthFlow,
+				InsecureSkipFulcioVerify:       o.Fulcio.InsecureSkipFulcioVerify,
+				RekorURL:                       o.Rekor.URL,
+				OIDCIssuer:                     o.OIDC.Issuer,
+				OIDCClientID:                   o.OIDC.ClientID,
+				OIDCClientSecret:               oidcClientSecret,
+				OIDCRedirectURL:                o.OIDC.RedirectURL,
+				OIDCDisableProviders:           o.OIDC.DisableAmbientProviders,
+				TSAClientCACert:                o.TSAClientCACert,
+				TSAClientCert:                  o.TSAClientCert,
+				TSAClientKey:                   o.TSAClientKey,
+				TSAServerName:                  o.TSAServerName,
+				IssueCertificateForExistingKey: o.IssueCertificate,
+				SigningAlgorithm:               o.SigningAlgorithm,
+				NewBundleFormat:                true,
+			}
+			if err := signcommon.LoadTrustedMaterialAndSigningConfig(cmd.Context(), &ko, o.UseSigningConfig, o.SigningConfigPath,
+				o.Rekor.URL, o.Fulcio.URL, o.OIDC.Issuer, "", o.TrustedRootPath, o.TlogUpload,
+				true, "", o.Key, o.IssueCertificate, "", "", "", "", "", ""); err != nil {
+				return err
+			}
+			return gitcli.SignX509Cmd(cmd.Context(), ro, ko, "-", os.Stdout, o.StatusFD, o.TlogUpload)
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func VerifyCommit() *cobra.Command {
+	o := &options.VerifyCommitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify-commit",
+		Short: "Verify the signature on a git commit or annotated tag and print the signer identity",
+		Long: `Verify the signature on a git commit or annotated tag in a local repository.
+
+Signatures made by cosign git-sign are supported. x509 signatures are Sigstore bundles
+and are verified against a key or a certificate identity, like verify-blob. SSH
+signatures are verified against --key. On success the signer identity, or the
+fingerprint of the signing key, is printed.`,
+		Example: `  # verify the latest commit was signed keylessly by a given identity
+  cosign verify-commit --certificate-identity foo@example.com --certificate-oidc-issuer https://accounts.google.com HEAD
+
+  # verify a commit signed with a key pair, reporting the signer as JSON
+  cosign verify-commit --key cosign.pub --output json 1c1f7e6
+
+  # verify an annotated tag in another repository
+  cosign verify-commit --repository ../project --key cosign.pub v1.0.0`,
+		Args:             cobra.ExactArgs(1),
+		PersistentPreRun: options.BindViper,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.CommonVerifyOptions.PrivateInfrastructure {
+				o.CommonVerifyOptions.IgnoreTlog = true
+			}
+			v := &gitcli.VerifyCommitCmd{
+				VerifyBlobCmd: verify.VerifyBlobCmd{
+					KeyOpts: options.KeyOpts{
+						KeyRef:           o.Key,
+						TSACertChainPath: o.CommonVerifyOptions.TSACertChainPath,
+					},
+					CertVerifyOptions:            o.CertVerify,
+					CertRef:                      o.CertVerify.Cert,
+					CertChain:                    o.CertVerify.CertChain,
+					CARoots:                      o.CertVerify.CARoots,
+					CAIntermediates:              o.CertVerify.CAIntermediates,
+					CertGithubWorkflowTrigger:    o.CertVerify.CertGithubWorkflowTrigger,
+					CertGithubWorkflowSHA:        o.CertVerify.CertGithubWorkflowSha,
+					CertGithubWorkflowName:       o.CertVerify.CertGithubWorkflowName,
+					CertGithubWorkflowRepository: o.CertVerify.CertGithubWorkflowRepository,
+					CertGithubWorkflowRef:        o.CertVerify.CertGithubWorkflowRef,
+					IgnoreSCT:                    o.CertVerify.IgnoreSCT,
+					Offline:                      o.CommonVerifyOptions.Offline,
+					IgnoreTlog:                   o.CommonVerifyOptions.IgnoreTlog,
+					UseSignedTimestamps:          o.CommonVerifyOptions.UseSignedTimestamps,
+					TrustedRootPath:              o.CommonVerifyOptions.TrustedRootPath,
+					AllowCertificateChain:        o.CommonVerifyOptions.AllowCertificateChain,
+				},
+				Repository: o.Repository,
+				Output:     o.Output,
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), ro.Timeout)
+			defer cancel()
+
+			if o.CommonVerifyOptions.IgnoreTlog && !o.CommonVerifyOptions.PrivateInfrastructure {
+				ui.Warnf(ctx, ignoreTLogMessage, "commit")
+			}
+			return v.Exec(ctx, args[0])
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
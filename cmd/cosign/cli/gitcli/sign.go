@@ -0,0 +1,98 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/sign"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/gitsig"
+	sigs "github.com/sigstore/cosign/v3/pkg/signature"
+)
+
+// SignX509Cmd signs the commit or tag payload at payloadPath, which is "-"
+// when git writes it to stdin, and writes an armored Sigstore bundle to out.
+// Key references, Fulcio, Rekor and the timestamp authority are used exactly
+// as for sign-blob. If statusFD is not negative, the gpg status lines git
+// expects are written to that file descriptor.
+func SignX509Cmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, payloadPath string, out io.Writer, statusFD int, tlogUpload bool) error {
+	td, err := os.MkdirTemp("", "cosign-git-sign")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(td)
+
+	// git reads the payload from our stdin, so prompts cannot be answered.
+	ko.SkipConfirmation = true
+	ko.NewBundleFormat = true
+	ko.BundlePath = filepath.Join(td, "bundle.sigstore.json")
+	if _, err := sign.SignBlobCmd(ctx, ro, ko, payloadPath, "", "", false, "", "", tlogUpload); err != nil {
+		return err
+	}
+	bundle, err := os.ReadFile(ko.BundlePath)
+	if err != nil {
+		return fmt.Errorf("reading bundle: %w", err)
+	}
+	if _, err := out.Write(gitsig.ArmorBundle(bundle)); err != nil {
+		return err
+	}
+	return writeStatus(statusFD)
+}
+
+// writeStatus reports success the way gpg does; git refuses a signature
+// unless it sees a SIG_CREATED status line.
+func writeStatus(statusFD int) error {
+	if statusFD < 0 {
+		return nil
+	}
+	f := os.NewFile(uintptr(statusFD), "status")
+	if f == nil {
+		return fmt.Errorf("invalid status file descriptor %d", statusFD)
+	}
+	_, err := fmt.Fprintf(f, "\n[GNUPG:] BEGIN_SIGNING\n[GNUPG:] SIG_CREATED D 0 0 00 %d cosign\n", time.Now().Unix())
+	return err
+}
+
+// SignSSHCmd signs bufferPath with keyRef in the given namespace and writes
+// the armored signature to bufferPath.sig, as `ssh-keygen -Y sign` does.
+func SignSSHCmd(ctx context.Context, keyRef string, pf cosign.PassFunc, namespace, bufferPath string) error {
+	if keyRef == "" {
+		return fmt.Errorf("SSH signatures require a key, set user.signingkey or pass --key")
+	}
+	sv, err := sigs.SignerVerifierFromKeyRef(ctx, keyRef, pf, nil)
+	if err != nil {
+		return fmt.Errorf("loading key %s: %w", keyRef, err)
+	}
+	if closer, ok := sv.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+	payload, err := os.ReadFile(filepath.Clean(bufferPath))
+	if err != nil {
+		return err
+	}
+	sig, err := gitsig.SignSSH(sv, namespace, payload)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(bufferPath+".sig", sig, 0600)
+}
@@ -0,0 +1,211 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitcli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/verify"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/gitsig"
+	sigs "github.com/sigstore/cosign/v3/pkg/signature"
+	sgbundle "github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"golang.org/x/crypto/ssh"
+)
+
+// CommitSigner describes who signed a verified commit or tag.
+type CommitSigner struct {
+	Object         string        `json:"object"`
+	Type           string        `json:"type"`
+	Format         gitsig.Format `json:"format"`
+	Identity       string        `json:"identity,omitempty"`
+	Issuer         string        `json:"issuer,omitempty"`
+	KeyFingerprint string        `json:"keyFingerprint,omitempty"`
+}
+
+// VerifyCommitCmd verifies the signature on a commit or annotated tag in a
+// local repository. x509 signatures are Sigstore bundles and are verified
+// like blobs, with the key or certificate identity options of the embedded
+// VerifyBlobCmd; SSH signatures are verified against KeyRef.
+type VerifyCommitCmd struct {
+	verify.VerifyBlobCmd
+	Repository string
+	Output     string
+}
+
+// Exec verifies rev and writes the signer to stdout.
+func (c *VerifyCommitCmd) Exec(ctx context.Context, rev string) error {
+	return c.exec(ctx, rev, os.Stdout)
+}
+
+func (c *VerifyCommitCmd) exec(ctx context.Context, rev string, out io.Writer) error {
+	sha, err := gitsig.ResolveRev(ctx, c.Repository, rev)
+	if err != nil {
+		return err
+	}
+	obj, err := gitsig.ReadObject(ctx, c.Repository, sha)
+	if errors.Is(err, gitsig.ErrUnsigned) {
+		return fmt.Errorf("%s %s is not signed", rev, sha)
+	}
+	if err != nil {
+		return err
+	}
+	format, err := gitsig.DetectFormat(obj.Signature)
+	if err != nil {
+		return err
+	}
+
+	signer := &CommitSigner{Object: sha, Type: obj.Type, Format: format}
+	switch format {
+	case gitsig.FormatSSH:
+		err = c.verifySSH(ctx, obj, signer)
+	case gitsig.FormatX509:
+		err = c.verifyX509(ctx, obj, signer)
+	default:
+		err = fmt.Errorf("%s signatures are not supported, use git verify-commit", format)
+	}
+	if err != nil {
+		return err
+	}
+	return printSigner(out, c.Output, signer)
+}
+
+func (c *VerifyCommitCmd) verifySSH(ctx context.Context, obj *gitsig.Object, signer *CommitSigner) error {
+	if c.KeyRef == "" {
+		return fmt.Errorf("SSH signatures can only be verified with --key")
+	}
+	pub, err := gitsig.VerifySSH(obj.Signature, gitsig.Namespace, obj.Payload)
+	if err != nil {
+		return err
+	}
+	trusted, err := loadSSHKeys(ctx, c.KeyRef)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(trusted, func(k ssh.PublicKey) bool { return bytes.Equal(pub.Marshal(), k.Marshal()) }) {
+		return fmt.Errorf("signed by %s, which does not match --key", ssh.FingerprintSHA256(pub))
+	}
+	signer.KeyFingerprint = ssh.FingerprintSHA256(pub)
+	return nil
+}
+
+func (c *VerifyCommitCmd) verifyX509(ctx context.Context, obj *gitsig.Object, signer *CommitSigner) error {
+	bundleBytes, err := gitsig.DearmorBundle(obj.Signature)
+	if err != nil {
+		return err
+	}
+	td, err := os.MkdirTemp("", "cosign-verify-commit")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(td)
+	payloadPath := filepath.Join(td, "payload")
+	if err := os.WriteFile(payloadPath, obj.Payload, 0600); err != nil {
+		return err
+	}
+	bundlePath := filepath.Join(td, "bundle.sigstore.json")
+	if err := os.WriteFile(bundlePath, bundleBytes, 0600); err != nil {
+		return err
+	}
+
+	vc := c.VerifyBlobCmd
+	vc.BundlePath = bundlePath
+	vc.NewBundleFormat = true
+	if err := vc.Exec(ctx, payloadPath); err != nil {
+		return err
+	}
+
+	b := &sgbundle.Bundle{}
+	if err := b.UnmarshalJSON(bundleBytes); err != nil {
+		return err
+	}
+	content, err := b.VerificationContent()
+	if err != nil {
+		return err
+	}
+	if cert := content.Certificate(); cert != nil {
+		summary, err := certificate.SummarizeCertificate(cert)
+		if err != nil {
+			return err
+		}
+		signer.Identity = summary.SubjectAlternativeName
+		signer.Issuer = summary.Issuer
+	} else if c.KeyRef != "" {
+		keys, err := loadSSHKeys(ctx, c.KeyRef)
+		if err != nil {
+			return err
+		}
+		// With several keys, the bundle does not say which one signed.
+		if len(keys) == 1 {
+			signer.KeyFingerprint = ssh.FingerprintSHA256(keys[0])
+		}
+	}
+	return nil
+}
+
+// loadSSHKeys loads the public keys for keyRef in SSH form, so keys can be
+// compared with SSH signatures and reported by fingerprint.
+func loadSSHKeys(ctx context.Context, keyRef string) ([]ssh.PublicKey, error) {
+	verifier, err := sigs.PublicKeyFromKeyRef(ctx, keyRef)
+	if err != nil {
+		return nil, fmt.Errorf("loading public key: %w", err)
+	}
+	if closer, ok := verifier.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+	verifiers := []signature.Verifier{verifier}
+	if mkv, ok := verifier.(*cosign.MultiKeyVerifier); ok {
+		verifiers = mkv.Verifiers()
+	}
+	keys := make([]ssh.PublicKey, 0, len(verifiers))
+	for _, v := range verifiers {
+		pub, err := v.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		sshPub, err := ssh.NewPublicKey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("converting public key: %w", err)
+		}
+		keys = append(keys, sshPub)
+	}
+	return keys, nil
+}
+
+func printSigner(out io.Writer, format string, s *CommitSigner) error {
+	if format == "json" {
+		return json.NewEncoder(out).Encode(s)
+	}
+	signedBy := s.Identity
+	if s.Issuer != "" {
+		signedBy = fmt.Sprintf("%s (issuer %s)", s.Identity, s.Issuer)
+	}
+	if signedBy == "" {
+		signedBy = "key " + s.KeyFingerprint
+	}
+	_, err := fmt.Fprintf(out, "%s %s has a valid %s signature by %s\n", s.Type, s.Object, s.Format, signedBy)
+	return err
+}
@@ -0,0 +1,159 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitcli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/verify"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+)
+
+func passFunc(_ bool) ([]byte, error) {
+	return []byte("hello"), nil
+}
+
+func git(t *testing.T, dir string, stdin []byte, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(stdin)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %s: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writeSignedCommit stores a commit carrying the signature produced by sign
+// over its payload and returns its object name.
+func writeSignedCommit(t *testing.T, repo string, sign func(payload []byte) []byte) string {
+	t.Helper()
+	tree := git(t, repo, nil, "hash-object", "-t", "tree", "-w", "--stdin")
+	payload := "tree " + tree + "\n" +
+		"author A U Thor <author@example.com> 1700000000 +0000\n" +
+		"committer A U Thor <author@example.com> 1700000000 +0000\n" +
+		"\nsigned commit\n"
+	sig := strings.TrimSuffix(string(sign([]byte(payload))), "\n")
+	headers, message, _ := strings.Cut(payload, "\n\n")
+	raw := headers + "\ngpgsig " + strings.ReplaceAll(sig, "\n", "\n ") + "\n\n" + message
+	return git(t, repo, []byte(raw), "hash-object", "-t", "commit", "-w", "--stdin")
+}
+
+func TestVerifyCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	td := t.TempDir()
+	repo := filepath.Join(td, "repo")
+	git(t, td, nil, "init", "-q", repo)
+
+	keys, err := cosign.GenerateKeyPair(passFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPath := filepath.Join(td, "cosign.key")
+	pubPath := filepath.Join(td, "cosign.pub")
+	if err := os.WriteFile(privPath, keys.PrivateBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, keys.PublicBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	other, err := cosign.GenerateKeyPair(passFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPath := filepath.Join(td, "other.pub")
+	if err := os.WriteFile(otherPath, other.PublicBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sshCommit := writeSignedCommit(t, repo, func(payload []byte) []byte {
+		buf := filepath.Join(td, "buffer")
+		if err := os.WriteFile(buf, payload, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := SignSSHCmd(ctx, privPath, passFunc, "git", buf); err != nil {
+			t.Fatalf("SignSSHCmd() error = %v", err)
+		}
+		sig, err := os.ReadFile(buf + ".sig")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	})
+
+	x509Commit := writeSignedCommit(t, repo, func(payload []byte) []byte {
+		buf := filepath.Join(td, "payload")
+		if err := os.WriteFile(buf, payload, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		ro := &options.RootOptions{Timeout: time.Minute}
+		ko := options.KeyOpts{KeyRef: privPath, PassFunc: passFunc}
+		var out bytes.Buffer
+		if err := SignX509Cmd(ctx, ro, ko, buf, &out, -1, false); err != nil {
+			t.Fatalf("SignX509Cmd() error = %v", err)
+		}
+		return out.Bytes()
+	})
+
+	for _, tt := range []struct {
+		name   string
+		commit string
+		format string
+	}{
+		{"ssh", sshCommit, "ssh"},
+		{"x509", x509Commit, "x509"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &VerifyCommitCmd{
+				VerifyBlobCmd: verify.VerifyBlobCmd{
+					KeyOpts:    options.KeyOpts{KeyRef: pubPath},
+					IgnoreTlog: true,
+				},
+				Repository: repo,
+				Output:     "json",
+			}
+			var out bytes.Buffer
+			if err := c.exec(ctx, tt.commit, &out); err != nil {
+				t.Fatalf("exec() error = %v", err)
+			}
+			signer := &CommitSigner{}
+			if err := json.Unmarshal(out.Bytes(), signer); err != nil {
+				t.Fatal(err)
+			}
+			if signer.Object != tt.commit || signer.Type != "commit" || string(signer.Format) != tt.format || signer.KeyFingerprint == "" {
+				t.Errorf("unexpected signer %+v", signer)
+			}
+
+			c.KeyRef = otherPath
+			if err := c.exec(ctx, tt.commit, &out); err == nil {
+				t.Error("expected error verifying with a different key")
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/key"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/spf13/cobra"
+)
+
+func Key() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key",
+		Short: "Manage the lifecycle of signing keys",
+		Long:  "Tools for managing the lifecycle of signing keys",
+	}
+
+	cmd.AddCommand(keyRotate())
+	cmd.AddCommand(keyReencrypt())
+	cmd.AddCommand(keyInfo())
+
+	return cmd
+}
+
+func keyRotate() *cobra.Command {
+	o := &options.KeyRotateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace a signing key with a new one",
+		Long: `Replace a signing key with a new one.
+
+A new key pair is generated, or a new key is created in KMS, and a key transition
+statement naming the new key is signed by both the retired and the new key. The key
+set is updated so that the retired key stays valid until the end of the overlap and
+the new key is valid from now on.
+
+Publish the key set and the key transition alongside the new public key. Verifiers
+using "cosign verify --keyset" accept signatures made with the retired key only if
+their transparency log or signed timestamp time falls inside its validity window.`,
+		Example: `  cosign key rotate --key <key path>|<kms uri> [--kms <kms uri>] [--output-key-prefix <prefix>]
+
+  # retire cosign.key, writing the new key pair to cosign-2026.key and cosign-2026.pub
+  cosign key rotate --key cosign.key --output-key-prefix cosign-2026
+
+  # keep accepting signatures from the retired key for a week instead of 30 days
+  cosign key rotate --key cosign.key --output-key-prefix cosign-2026 --overlap 168h
+
+  # rotate to a new version of a Google Cloud KMS key
+  cosign key rotate --key gcpkms://projects/[PROJECT]/locations/global/keyRings/[KEYRING]/cryptoKeys/[KEY]/cryptoKeyVersions/1 \
+    --kms gcpkms://projects/[PROJECT]/locations/global/keyRings/[KEYRING]/cryptoKeys/[KEY]/cryptoKeyVersions/2
+
+CAVEATS:
+  This command interactively prompts for the password of the retired key and for a
+  password for the new key. You can use the COSIGN_PASSWORD environment variable
+  to provide one.`,
+		PersistentPreRun: options.BindViper,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rotateCmd := &key.RotateCmd{
+				KeyRef:           o.Key,
+				KMS:              o.KMS,
+				OutputKeyPrefix:  o.OutputKeyPrefix,
+				KeySetPath:       o.KeySet,
+				OutputTransition: o.OutputTransition,
+				Overlap:          o.Overlap,
+			}
+			return rotateCmd.Exec(cmd.Context())
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func keyReencrypt() *cobra.Command {
+	o := &options.KeyReencryptOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "reencrypt",
+		Short: "Change the password, KDF strength or format of a private key",
+		Long: `Change the password, KDF strength or format of a private key.
+
+The private key is decrypted and encrypted again, replacing the --key file unless
+--output-key is set. The key itself, and so its public key, does not change.
+
+Supported formats are:
+  cosign   ENCRYPTED SIGSTORE PRIVATE KEY, scrypt and nacl/secretbox, as written by
+           generate-key-pair.
+  pkcs8    ENCRYPTED PRIVATE KEY, PBKDF2-HMAC-SHA256 and AES-256-CBC, for OpenSSL
+           and other PKCS #8 tooling.
+  openssh  OPENSSH PRIVATE KEY, bcrypt with 16 rounds and AES-256-CTR, for ssh and
+           ssh-keygen.
+
+Keys in any of these formats, as well as unencrypted PKCS #8 and OpenSSH keys, are
+accepted as input. Keys in the cosign and openssh formats can be used with --key
+when signing.
+
+The --kdf-strength flag selects scrypt N=2^15, 2^16 or 2^17 for the cosign format,
+and 10,000, 100,000 or 600,000 PBKDF2 iterations for the pkcs8 format.`,
+		Example: `  cosign key reencrypt --key <key path> [--output-key <key path>] [--format cosign|pkcs8|openssh] [--kdf-strength legacy|standard|owasp] [--unencrypted]
+
+  # change the password of cosign.key
+  cosign key reencrypt --key cosign.key
+
+  # use the OWASP recommended scrypt parameters
+  cosign key reencrypt --key cosign.key --kdf-strength owasp
+
+  # export cosign.key for use with OpenSSL
+  cosign key reencrypt --key cosign.key --format pkcs8 --output-key cosign.p8
+
+  # export cosign.key without a password
+  cosign key reencrypt --key cosign.key --format pkcs8 --unencrypted --output-key cosign.p8
+
+  # import a key generated with ssh-keygen
+  cosign key reencrypt --key ~/.ssh/id_ed25519 --output-key cosign.key
+
+CAVEATS:
+  This command interactively prompts for the current and the new password. You can
+  use the COSIGN_PASSWORD and COSIGN_NEW_PASSWORD environment variables to provide
+  them. If the new password is neither set nor can be prompted for, the current
+  password is kept. An empty new password is refused.
+
+  The --unencrypted flag writes PKCS #8 and OpenSSH keys without a password. Keys in
+  the cosign format are always encrypted, and are encrypted with an empty password.`,
+		PersistentPreRun: options.BindViper,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			reencryptCmd := &key.ReencryptCmd{
+				KeyPath:     o.Key,
+				OutputPath:  o.OutputKey,
+				Format:      cosign.PrivateKeyFormat(o.Format),
+				KDFStrength: cosign.KDFStrength(o.KDFStrength),
+				Unencrypted: o.Unencrypted,
+			}
+			return reencryptCmd.Exec(cmd.Context())
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func keyInfo() *cobra.Command {
+	o := &options.KeyInfoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show the algorithm, encryption and fingerprint of a private key",
+		Long: `Show the algorithm, encryption and fingerprint of a private key.
+
+The key ID is the base64 encoded SHA-256 digest of the DER encoded public key, as
+used in key sets, revocation lists and the public key hint of Sigstore bundles. The
+SSH fingerprint matches the output of "ssh-keygen -l".`,
+		Example: `  cosign key info --key <key path>
+
+  cosign key info --key cosign.key
+
+CAVEATS:
+  This command interactively prompts for the password of the key. You can use the
+  COSIGN_PASSWORD environment variable to provide one.`,
+		PersistentPreRun: options.BindViper,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			infoCmd := &key.InfoCmd{
+				KeyPath: o.Key,
+				Out:     os.Stdout,
+			}
+			return infoCmd.Exec(cmd.Context())
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,77 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+)
+
+// InfoCmd describes the encoding and public key of a private key.
+type InfoCmd struct {
+	KeyPath string
+	// PassFunc returns the password. It defaults to generate.GetPass.
+	PassFunc cosign.PassFunc
+	Out      io.Writer
+}
+
+func (c *InfoCmd) Exec(_ context.Context) error {
+	kb, err := os.ReadFile(filepath.Clean(c.KeyPath))
+	if err != nil {
+		return err
+	}
+	pf := c.PassFunc
+	if pf == nil {
+		pf = generate.GetPass
+	}
+	pass, err := pf(false)
+	if err != nil {
+		return err
+	}
+	info, err := cosign.InspectPrivateKey(kb, pass)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", c.KeyPath, err)
+	}
+
+	out := c.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	fmt.Fprintf(out, "Format:          %s (%s)\n", info.Format, info.PEMType)
+	fmt.Fprintf(out, "Algorithm:       %s\n", info.Algorithm)
+	if info.KDF == "" {
+		fmt.Fprintln(out, "Encryption:      none")
+	} else {
+		kdf := info.KDF
+		if info.KDFParams != "" {
+			kdf += " " + info.KDFParams
+		}
+		if info.KDFStrength != "" {
+			kdf += fmt.Sprintf(" (%s)", info.KDFStrength)
+		}
+		fmt.Fprintf(out, "KDF:             %s\n", kdf)
+		fmt.Fprintf(out, "Cipher:          %s\n", info.Cipher)
+	}
+	fmt.Fprintf(out, "Key ID:          %s\n", info.KeyID)
+	fmt.Fprintf(out, "SSH fingerprint: %s\n", info.SSHFingerprint)
+	return nil
+}
@@ -0,0 +1,164 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/env"
+	"golang.org/x/term"
+)
+
+// ReencryptCmd decrypts a private key and encrypts it again, with a new
+// password, KDF strength or in a different format.
+type ReencryptCmd struct {
+	KeyPath     string
+	OutputPath  string
+	Format      cosign.PrivateKeyFormat
+	KDFStrength cosign.KDFStrength
+	// Unencrypted writes the key without a password instead of asking for
+	// a new one.
+	Unencrypted bool
+	// PassFunc returns the current password. It defaults to generate.GetPass.
+	PassFunc cosign.PassFunc
+	// NewPassFunc returns the new password. It defaults to reading
+	// COSIGN_NEW_PASSWORD, prompting for it on a terminal, and otherwise
+	// keeping the current password.
+	NewPassFunc cosign.PassFunc
+}
+
+func (c *ReencryptCmd) Exec(_ context.Context) error {
+	kb, err := os.ReadFile(filepath.Clean(c.KeyPath))
+	if err != nil {
+		return err
+	}
+	pf := c.PassFunc
+	if pf == nil {
+		pf = generate.GetPass
+	}
+	pass, err := pf(false)
+	if err != nil {
+		return err
+	}
+	pk, err := cosign.DecryptPrivateKey(kb, pass)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", c.KeyPath, err)
+	}
+
+	var b []byte
+	if c.Unencrypted {
+		if c.KDFStrength != "" {
+			return errors.New("--kdf-strength cannot be used with --unencrypted")
+		}
+		if b, err = cosign.MarshalUnencryptedPrivateKey(pk, c.Format); err != nil {
+			return err
+		}
+	} else {
+		npf := c.NewPassFunc
+		if npf == nil {
+			npf = newPassFunc(pass)
+		}
+		newPass, err := npf(true)
+		if err != nil {
+			return err
+		}
+		if len(newPass) == 0 {
+			return errEmptyNewPassword
+		}
+		if b, err = cosign.EncryptPrivateKey(pk, c.Format, c.KDFStrength, newPass); err != nil {
+			return err
+		}
+	}
+
+	out := c.OutputPath
+	if out == "" {
+		out = c.KeyPath
+	}
+	if err := writePrivateKey(out, b); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Private key written to", out)
+	return nil
+}
+
+var errEmptyNewPassword = errors.New("the new password is empty, pass --unencrypted to write the private key without a password")
+
+// newPassFunc reads the new password from COSIGN_NEW_PASSWORD or the
+// terminal. Without either the current password is kept, so that piped
+// passwords can still be used to change the format or KDF strength.
+func newPassFunc(current []byte) cosign.PassFunc {
+	return func(confirm bool) ([]byte, error) {
+		if pw, ok := env.LookupEnv(env.VariableNewPassword); ok {
+			return []byte(pw), nil
+		}
+		if !cosign.IsTerminal() {
+			return current, nil
+		}
+		fmt.Fprint(os.Stderr, "Enter new password for private key: ")
+		// nolint:unconvert
+		pw1, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		if len(pw1) == 0 {
+			return nil, errEmptyNewPassword
+		}
+		if !confirm {
+			return pw1, nil
+		}
+		fmt.Fprint(os.Stderr, "Enter new password for private key again: ")
+		// nolint:unconvert
+		pw2, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		if string(pw1) != string(pw2) {
+			return nil, errors.New("passwords do not match")
+		}
+		return pw1, nil
+	}
+}
+
+// writePrivateKey replaces path with b, so that an interrupted write does
+// not lose the only copy of a key being re-encrypted in place.
+func writePrivateKey(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,195 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
+	icos "github.com/sigstore/cosign/v3/internal/pkg/cosign"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	sigs "github.com/sigstore/cosign/v3/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+)
+
+// RotateCmd replaces a signing key with a new one. The previous key signs a
+// key transition statement naming its successor, and the key set is updated
+// so the previous key stays valid only until the end of the overlap.
+type RotateCmd struct {
+	KeyRef           string
+	KMS              string
+	OutputKeyPrefix  string
+	KeySetPath       string
+	OutputTransition string
+	Overlap          time.Duration
+	// Now returns the time of the rotation. It defaults to time.Now.
+	Now func() time.Time
+}
+
+func (c *RotateCmd) Exec(ctx context.Context) error {
+	if c.KMS == "" && c.OutputKeyPrefix == "" {
+		return errors.New("either --kms or --output-key-prefix is required for the new key")
+	}
+	if c.Overlap < 0 {
+		return fmt.Errorf("--overlap must not be negative, got %s", c.Overlap)
+	}
+
+	previous, err := sigs.SignerVerifierFromKeyRef(ctx, c.KeyRef, generate.GetPass, nil)
+	if err != nil {
+		return fmt.Errorf("loading key to retire: %w", err)
+	}
+	previousPEM, err := sigs.PublicKeyPem(previous)
+	if err != nil {
+		return err
+	}
+
+	ks, err := cosign.LoadKeySet(c.KeySetPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if ks, err = cosign.NewKeySet(previousPEM); err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("loading key set: %w", err)
+	}
+
+	next, keys, err := c.newKey(ctx)
+	if err != nil {
+		return err
+	}
+	nextPEM, err := sigs.PublicKeyPem(next)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(nextPEM, previousPEM) {
+		return errors.New("the new key is the key being retired")
+	}
+
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	rotated := now().UTC().Truncate(time.Second)
+	transition := &cosign.KeyTransition{
+		PreviousKey:         string(previousPEM),
+		NewKey:              string(nextPEM),
+		Time:                rotated,
+		PreviousKeyNotAfter: rotated.Add(c.Overlap),
+	}
+	envelope, err := cosign.SignKeyTransition(transition, previous, next)
+	if err != nil {
+		return fmt.Errorf("signing key transition: %w", err)
+	}
+	if _, err := ks.Apply(envelope); err != nil {
+		return fmt.Errorf("updating key set: %w", err)
+	}
+
+	if keys != nil {
+		if err := writeKeyFiles(ctx, c.OutputKeyPrefix, keys); err != nil {
+			return err
+		}
+	} else if c.OutputKeyPrefix != "" {
+		if err := os.WriteFile(c.OutputKeyPrefix+".pub", nextPEM, 0644); err != nil { //nolint: gosec
+			return err
+		} // #nosec G306
+		fmt.Fprintln(os.Stderr, "Public key written to", c.OutputKeyPrefix+".pub")
+	}
+
+	if err := os.WriteFile(c.OutputTransition, envelope, 0644); err != nil { //nolint: gosec
+		return err
+	} // #nosec G306
+	fmt.Fprintln(os.Stderr, "Key transition written to", c.OutputTransition)
+
+	b, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.KeySetPath, append(b, '\n'), 0644); err != nil { //nolint: gosec
+		return err
+	} // #nosec G306
+	fmt.Fprintf(os.Stderr, "Key set written to %s, the retired key is valid until %s\n",
+		c.KeySetPath, transition.PreviousKeyNotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// newKey creates the key replacing the retired one, either in KMS or as a
+// new encrypted key pair. The key pair is only written out once the
+// transition has been recorded.
+//
+// KMS providers return the existing key, not a new version of it, when the
+// key already exists, so --kms must name a key that does not exist yet.
+func (c *RotateCmd) newKey(ctx context.Context) (signature.Signer, *cosign.KeysBytes, error) {
+	if c.KMS != "" {
+		k, err := kms.Get(ctx, c.KMS, crypto.SHA256)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := k.PublicKey(); err == nil {
+			return nil, nil, fmt.Errorf("KMS key %s already exists, --kms must name a new key", c.KMS)
+		}
+		if _, err := k.CreateKey(ctx, k.DefaultAlgorithm()); err != nil {
+			return nil, nil, fmt.Errorf("creating key: %w", err)
+		}
+		return k, nil, nil
+	}
+
+	keys, err := cosign.GenerateKeyPair(generate.GetPass)
+	if err != nil {
+		return nil, nil, err
+	}
+	sv, err := cosign.LoadPrivateKey(keys.PrivateBytes, keys.Password(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sv, keys, nil
+}
+
+func writeKeyFiles(ctx context.Context, prefix string, keys *cosign.KeysBytes) error {
+	privateKeyFileName := prefix + ".key"
+	publicKeyFileName := prefix + ".pub"
+
+	fileExists, err := icos.FileExists(privateKeyFileName)
+	if err != nil {
+		return fmt.Errorf("failed checking if %s exists: %w", privateKeyFileName, err)
+	}
+	if fileExists {
+		ui.Warnf(ctx, "File %s already exists. Overwrite?", privateKeyFileName)
+		if err := ui.ConfirmContinue(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(privateKeyFileName, keys.PrivateBytes, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Private key written to", privateKeyFileName)
+
+	if err := os.WriteFile(publicKeyFileName, keys.PublicBytes, 0644); err != nil { //nolint: gosec
+		return err
+	} // #nosec G306
+	fmt.Fprintln(os.Stderr, "Public key written to", publicKeyFileName)
+	return nil
+}
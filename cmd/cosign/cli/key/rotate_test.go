@@ -0,0 +1,109 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/sigstore/pkg/signature/kms/fake"
+)
+
+func TestRotateCmd(t *testing.T) {
+	t.Setenv("COSIGN_PASSWORD", "")
+	ctx := context.Background()
+	td := t.TempDir()
+
+	keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := filepath.Join(td, "first")
+	if err := os.WriteFile(first+".key", keys.PrivateBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	keySetPath := filepath.Join(td, "keyset.json")
+	rotated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rotate := func(keyRef, prefix string) error {
+		return (&RotateCmd{
+			KeyRef:           keyRef,
+			OutputKeyPrefix:  prefix,
+			KeySetPath:       keySetPath,
+			OutputTransition: prefix + ".transition.json",
+			Overlap:          24 * time.Hour,
+			Now:              func() time.Time { return rotated },
+		}).Exec(ctx)
+	}
+
+	second := filepath.Join(td, "second")
+	if err := rotate(first+".key", second); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	envelope, err := os.ReadFile(second + ".transition.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transition, err := cosign.VerifyKeyTransition(envelope)
+	if err != nil {
+		t.Fatalf("VerifyKeyTransition() error = %v", err)
+	}
+	pub, err := os.ReadFile(second + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transition.NewKey != string(pub) || !transition.PreviousKeyNotAfter.Equal(rotated.Add(24*time.Hour)) {
+		t.Errorf("unexpected transition %+v", transition)
+	}
+
+	// Rotating again chains onto the key set.
+	rotated = rotated.Add(48 * time.Hour)
+	if err := rotate(second+".key", filepath.Join(td, "third")); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	ks, err := cosign.LoadKeySet(keySetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ks.Keys) != 3 {
+		t.Fatalf("got %d keys, want 3", len(ks.Keys))
+	}
+	if !ks.Keys[1].ValidAt(rotated.Add(-time.Hour)) || ks.Keys[1].ValidAt(rotated.Add(25*time.Hour)) {
+		t.Errorf("unexpected validity of the second key %+v", ks.Keys[1])
+	}
+
+	// The first key was retired and cannot be rotated again.
+	if err := rotate(first+".key", filepath.Join(td, "fourth")); err == nil {
+		t.Error("expected error rotating a retired key")
+	}
+	if _, err := os.Stat(filepath.Join(td, "fourth.key")); !os.IsNotExist(err) {
+		t.Errorf("new key written despite failed rotation: %v", err)
+	}
+
+	if err := (&RotateCmd{KeyRef: first + ".key", KeySetPath: keySetPath}).Exec(ctx); err == nil {
+		t.Error("expected error without --kms or --output-key-prefix")
+	}
+
+	// An existing KMS key is not created again, and would not be new.
+	err = (&RotateCmd{KeyRef: filepath.Join(td, "third.key"), KMS: fake.ReferenceScheme, KeySetPath: keySetPath}).Exec(ctx)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Exec() with an existing KMS key error = %v", err)
+	}
+}
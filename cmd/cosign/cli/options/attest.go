@@ -43,13 +43,15 @@ type AttestOptions struct {
 	UseSigningConfig        bool
 	SigningConfigPath       string
 	TrustedRootPath         string
-
-	Rekor       RekorOptions
-	Fulcio      FulcioOptions
-	OIDC        OIDCOptions
-	SecurityKey SecurityKeyOptions
-	Predicate   PredicateLocalOptions
-	Registry    RegistryOptions
+	AttestationBundle       string
+
+	Rekor         RekorOptions
+	Fulcio        FulcioOptions
+	OIDC          OIDCOptions
+	SecurityKey   SecurityKeyOptions
+	PasswordShare PasswordShareOptions
+	Predicate     PredicateLocalOptions
+	Registry      RegistryOptions
 }
 
 var _ Interface = (*AttestOptions)(nil)
@@ -57,7 +59,15 @@ var _ Interface = (*AttestOptions)(nil)
 // AddFlags implements Interface
 func (o *AttestOptions) AddFlags(cmd *cobra.Command) {
 	o.SecurityKey.AddFlags(cmd)
+	o.PasswordShare.AddFlags(cmd)
 	o.Predicate.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.AttestationBundle, "attestation-bundle", "",
+		"path to an in-toto attestation bundle (JSON Lines of DSSE envelopes or in-toto statements) to attach "+
+			"instead of a single predicate. Statements are signed; envelopes, whose signatures are not checked, are attached as is "+
+			"to the legacy attestation image and cannot be used with --new-bundle-format. Every attestation must have the image as a subject")
+	_ = cmd.MarkFlagFilename("attestation-bundle", "jsonl", "json")
+	cmd.MarkFlagsOneRequired("predicate", "statement", "attestation-bundle")
+	cmd.MarkFlagsMutuallyExclusive("predicate", "attestation-bundle")
 	o.Fulcio.AddFlags(cmd)
 	o.OIDC.AddFlags(cmd)
 	o.Rekor.AddFlags(cmd)
@@ -36,8 +36,10 @@ type AttestBlobOptions struct {
 	TSAServerURL         string
 	RFC3161TimestampPath string
 
-	Hash      string
-	Predicate PredicateLocalOptions
+	Hash             string
+	Subjects         []string
+	SubjectChecksums string
+	Predicate        PredicateLocalOptions
 
 	OutputSignature   string
 	OutputAttestation string
@@ -47,10 +49,11 @@ type AttestBlobOptions struct {
 
 	RekorEntryType string
 
-	Rekor       RekorOptions
-	Fulcio      FulcioOptions
-	OIDC        OIDCOptions
-	SecurityKey SecurityKeyOptions
+	Rekor         RekorOptions
+	Fulcio        FulcioOptions
+	OIDC          OIDCOptions
+	SecurityKey   SecurityKeyOptions
+	PasswordShare PasswordShareOptions
 
 	UseSigningConfig  bool
 	SigningConfigPath string
@@ -62,10 +65,12 @@ var _ Interface = (*AttestOptions)(nil)
 // AddFlags implements Interface
 func (o *AttestBlobOptions) AddFlags(cmd *cobra.Command) {
 	o.Predicate.AddFlags(cmd)
+	cmd.MarkFlagsOneRequired("predicate", "statement")
 	o.Rekor.AddFlags(cmd)
 	o.Fulcio.AddFlags(cmd)
 	o.OIDC.AddFlags(cmd)
 	o.SecurityKey.AddFlags(cmd)
+	o.PasswordShare.AddFlags(cmd)
 
 	cmd.Flags().StringVar(&o.Key, "key", "",
 		"path to the private key file, KMS URI or Kubernetes Secret")
@@ -121,6 +126,15 @@ func (o *AttestBlobOptions) AddFlags(cmd *cobra.Command) {
 		"hash of blob in hexadecimal (base16). Used if you want to sign an artifact stored elsewhere and have the hash")
 	_ = cmd.RegisterFlagCompletionFunc("hash", cobra.NoFileCompletions)
 
+	cmd.Flags().StringArrayVar(&o.Subjects, "subject", nil,
+		"additional subject of the attestation: a file, hashed with SHA-256, or NAME@ALGORITHM:HEX such as a platform manifest digest. "+
+			"May be repeated to attest several artifacts, such as all files of a release, in one statement. Not allowed with --statement")
+
+	cmd.Flags().StringVar(&o.SubjectChecksums, "subject-checksums", "",
+		"path to a checksums file, as written by sha256sum, sha512sum or 'shasum --tag', whose entries are added as subjects of the attestation")
+	cmd.MarkFlagsMutuallyExclusive("statement", "subject")
+	cmd.MarkFlagsMutuallyExclusive("statement", "subject-checksums")
+
 	cmd.Flags().BoolVarP(&o.SkipConfirmation, "yes", "y", false,
 		"skip confirmation prompts for non-destructive operations")
 
@@ -0,0 +1,50 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+	"github.com/spf13/cobra"
+)
+
+// AttestationQueryOptions is the wrapper for selecting attestations by their
+// content and printing selected fields of them.
+type AttestationQueryOptions struct {
+	Filters  []string
+	JSONPath string
+}
+
+var _ Interface = (*AttestationQueryOptions)(nil)
+
+// AddFlags implements Interface
+func (o *AttestationQueryOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&o.Filters, "filter", nil,
+		"only consider attestations whose in-toto statement matches this JSONPath filter expression, "+
+			"e.g. '@.predicate.runDetails.builder.id==\"https://github.com/actions/runner\"'. "+
+			"May be repeated; all filters must match")
+
+	cmd.Flags().StringVar(&o.JSONPath, "jsonpath", "",
+		"print this JSONPath template evaluated over each in-toto statement instead of the attestation, "+
+			"e.g. '{.predicate.metadata.scanFinishedOn}'. Use '{@}' for the whole decoded statement")
+}
+
+// Query returns the query for the flags, or nil if none are set.
+func (o *AttestationQueryOptions) Query() (*attestation.Query, error) {
+	if len(o.Filters) == 0 && o.JSONPath == "" {
+		return nil, nil
+	}
+	return attestation.NewQuery(o.Filters, o.JSONPath)
+}
@@ -15,7 +15,10 @@
 
 package options
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+	"github.com/spf13/cobra"
+)
 
 // DownloadOptions is the struct for control
 type SBOMDownloadOptions struct {
@@ -25,6 +28,9 @@ type SBOMDownloadOptions struct {
 type AttestationDownloadOptions struct {
 	PredicateType string // Predicate type of attestation to retrieve
 	Platform      string // Platform to download attestations
+	Output        string // Path to write an in-toto attestation bundle to
+	Convert       string // Format to convert SBOM attestations to
+	Query         AttestationQueryOptions
 }
 
 var _ Interface = (*SBOMDownloadOptions)(nil)
@@ -41,6 +47,19 @@ func (o *SBOMDownloadOptions) AddFlags(cmd *cobra.Command) {
 func (o *AttestationDownloadOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.PredicateType, "predicate-type", "",
 		"download attestation with matching predicateType")
+	cmd.Flags().StringVar(&o.PredicateType, "type", "",
+		"alias of --predicate-type")
+	cmd.MarkFlagsMutuallyExclusive("predicate-type", "type")
 	cmd.Flags().StringVar(&o.Platform, "platform", "",
 		"download attestation for a specific platform image")
+	cmd.Flags().StringVar(&o.Output, "output", "",
+		"write the attestations to this file as an in-toto attestation bundle, one DSSE envelope per line, "+
+			"which can be attached again with 'cosign attest --attestation-bundle'")
+	_ = cmd.MarkFlagFilename("output", "jsonl")
+	cmd.Flags().StringVar(&o.Convert, "convert", "",
+		"convert SBOM attestations, whether SPDX, CycloneDX or Syft, and print one document per line: "+
+			"cyclonedx for CycloneDX 1.6 JSON, or components for the normalized component list")
+	_ = cmd.RegisterFlagCompletionFunc("convert", cobra.FixedCompletions(attestation.SBOMConversions(), cobra.ShellCompDirectiveNoFileComp))
+	o.Query.AddFlags(cmd)
+	cmd.MarkFlagsMutuallyExclusive("output", "jsonpath", "convert")
 }
@@ -24,6 +24,16 @@ type GenerateKeyPairOptions struct {
 	// KMS Key Management Service
 	KMS             string
 	OutputKeyPrefix string
+
+	// Kubernetes output, only used with k8s:// references
+	DryRun              bool
+	SealedSecretsCert   string
+	ExternalSecretStore string
+	ExternalSecretRef   string
+
+	// Shamir split of the key password, only used for key files
+	PasswordShares    int
+	PasswordThreshold int
 }
 
 var _ Interface = (*GenerateKeyPairOptions)(nil)
@@ -34,4 +44,19 @@ func (o *GenerateKeyPairOptions) AddFlags(cmd *cobra.Command) {
 		"create key pair in KMS service to use for signing")
 	cmd.Flags().StringVar(&o.OutputKeyPrefix, "output-key-prefix", "cosign",
 		"name used for generated .pub and .key files (defaults to `cosign`)")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false,
+		"print the Kubernetes Secret manifest instead of creating the Secret (k8s:// only)")
+	cmd.Flags().StringVar(&o.SealedSecretsCert, "sealed-secrets-cert", "",
+		"path or URL of a sealed-secrets controller certificate, prints a SealedSecret manifest instead of creating the Secret (k8s:// only)")
+	cmd.Flags().StringVar(&o.ExternalSecretStore, "external-secret-store", "",
+		"[SecretStore/|ClusterSecretStore/]NAME of an External Secrets Operator store, prints an ExternalSecret manifest instead of creating the Secret (k8s:// only)")
+	cmd.Flags().StringVar(&o.ExternalSecretRef, "external-secret-ref", "",
+		"vault://, awssm:// or gcpsm:// secret the key pair is written to for --external-secret-store")
+	cmd.Flags().IntVar(&o.PasswordShares, "password-shares", 0,
+		"encrypt the private key with a random password and write it as this many Shamir shares instead of prompting for a password")
+	cmd.Flags().IntVar(&o.PasswordThreshold, "password-threshold", 0,
+		"number of --password-shares needed to reconstruct the password")
+	cmd.MarkFlagsRequiredTogether("password-shares", "password-threshold")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "sealed-secrets-cert", "external-secret-store")
+	cmd.MarkFlagsRequiredTogether("external-secret-store", "external-secret-ref")
 }
@@ -0,0 +1,161 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/spf13/cobra"
+)
+
+// GitSignOptions is the top level wrapper for the git-sign command. Besides
+// cosign's own flags it accepts the arguments git passes to gpg.x509.program
+// and gpg.ssh.program.
+type GitSignOptions struct {
+	Key               string
+	SecurityKey       SecurityKeyOptions
+	Fulcio            FulcioOptions
+	Rekor             RekorOptions
+	OIDC              OIDCOptions
+	TlogUpload        bool
+	TSAClientCACert   string
+	TSAClientCert     string
+	TSAClientKey      string
+	TSAServerName     string
+	IssueCertificate  bool
+	SigningAlgorithm  string
+	UseSigningConfig  bool
+	SigningConfigPath string
+	TrustedRootPath   string
+
+	// Arguments passed by git when invoking a gpg-compatible program.
+	StatusFD   int
+	DetachSign bool
+	Sign       bool
+	Armor      bool
+	LocalUser  string
+
+	// Arguments passed by git when invoking an ssh-keygen-compatible program.
+	SSHOperation string
+	SSHNamespace string
+	SSHKeyFile   string
+	SSHAgent     bool
+}
+
+var _ Interface = (*GitSignOptions)(nil)
+
+// AddFlags implements Interface
+func (o *GitSignOptions) AddFlags(cmd *cobra.Command) {
+	o.SecurityKey.AddFlags(cmd)
+	o.Fulcio.AddFlags(cmd)
+	o.Rekor.AddFlags(cmd)
+	o.OIDC.AddFlags(cmd)
+
+	cmd.Flags().StringVar(&o.Key, "key", "",
+		"path to the private key file, KMS URI or Kubernetes Secret. Overrides the key git passes with -f in SSH mode")
+	_ = cmd.MarkFlagFilename("key", privateKeyExts...)
+
+	cmd.Flags().BoolVar(&o.TlogUpload, "tlog-upload", true,
+		"whether or not to upload x509 signatures to the tlog")
+
+	cmd.Flags().StringVar(&o.TSAClientCACert, "timestamp-client-cacert", "",
+		"path to the X.509 CA certificate file in PEM format to be used for the connection to the TSA Server")
+	_ = cmd.MarkFlagFilename("timestamp-client-cacert", certificateExts...)
+
+	cmd.Flags().StringVar(&o.TSAClientCert, "timestamp-client-cert", "",
+		"path to the X.509 certificate file in PEM format to be used for the connection to the TSA Server")
+	_ = cmd.MarkFlagFilename("timestamp-client-cert", certificateExts...)
+
+	cmd.Flags().StringVar(&o.TSAClientKey, "timestamp-client-key", "",
+		"path to the X.509 private key file in PEM format to be used, together with the 'timestamp-client-cert' value, for the connection to the TSA Server")
+	_ = cmd.MarkFlagFilename("timestamp-client-key", privateKeyExts...)
+
+	cmd.Flags().StringVar(&o.TSAServerName, "timestamp-server-name", "",
+		"SAN name to use as the 'ServerName' tls.Config field to verify the mTLS connection to the TSA Server")
+	_ = cmd.RegisterFlagCompletionFunc("timestamp-server-name", cobra.NoFileCompletions)
+
+	cmd.Flags().BoolVar(&o.IssueCertificate, "issue-certificate", false,
+		"issue a code signing certificate from Fulcio, even if a key is provided")
+
+	cmd.Flags().BoolVar(&o.UseSigningConfig, "use-signing-config", true,
+		"whether to use a TUF-provided signing config for the service URLs")
+
+	cmd.Flags().StringVar(&o.SigningConfigPath, "signing-config", "",
+		"path to a signing config file")
+	cmd.MarkFlagsMutuallyExclusive("use-signing-config", "signing-config")
+
+	cmd.Flags().StringVar(&o.TrustedRootPath, "trusted-root", "",
+		"optional path to a TrustedRoot JSON file to verify a signature after signing")
+
+	keyAlgorithmTypes := cosign.GetSupportedAlgorithms()
+	keyAlgorithmHelp := fmt.Sprintf("signing algorithm to use for signing/hashing (allowed %s)", strings.Join(keyAlgorithmTypes, ", "))
+	defaultKeyFlag, _ := signature.FormatSignatureAlgorithmFlag(v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256)
+	cmd.Flags().StringVar(&o.SigningAlgorithm, "signing-algorithm", defaultKeyFlag, keyAlgorithmHelp)
+
+	cmd.Flags().IntVar(&o.StatusFD, "status-fd", -1,
+		"file descriptor to write gpg status lines to (set by git)")
+	cmd.Flags().BoolVarP(&o.DetachSign, "detach-sign", "b", false,
+		"make a detached signature (set by git)")
+	cmd.Flags().BoolVarP(&o.Sign, "sign", "s", false,
+		"sign the payload read from stdin (set by git)")
+	cmd.Flags().BoolVarP(&o.Armor, "armor", "a", false,
+		"armor the signature (set by git)")
+	cmd.Flags().StringVarP(&o.LocalUser, "local-user", "u", "",
+		"user.signingkey; ignored for x509 signatures, use --key instead (set by git)")
+
+	cmd.Flags().StringVarP(&o.SSHOperation, "ssh-operation", "Y", "",
+		"ssh-keygen operation, only sign is supported (set by git)")
+	cmd.Flags().StringVarP(&o.SSHNamespace, "ssh-namespace", "n", "",
+		"SSH signature namespace (set by git)")
+	cmd.Flags().StringVarP(&o.SSHKeyFile, "ssh-key-file", "f", "",
+		"user.signingkey, interpreted as a cosign key reference (set by git)")
+	cmd.Flags().BoolVarP(&o.SSHAgent, "ssh-agent", "U", false,
+		"the key is held by ssh-agent, which is not supported (set by git)")
+}
+
+// VerifyCommitOptions is the top level wrapper for the verify-commit command.
+type VerifyCommitOptions struct {
+	Key        string
+	Repository string
+	Output     string
+
+	CertVerify          CertVerifyOptions
+	CommonVerifyOptions CommonVerifyOptions
+}
+
+var _ Interface = (*VerifyCommitOptions)(nil)
+
+// AddFlags implements Interface
+func (o *VerifyCommitOptions) AddFlags(cmd *cobra.Command) {
+	o.CertVerify.AddFlags(cmd)
+	o.CommonVerifyOptions.AddFlags(cmd)
+
+	cmd.Flags().StringVar(&o.Key, "key", "",
+		"path to the public key file, KMS URI or Kubernetes Secret")
+	_ = cmd.MarkFlagFilename("key", publicKeyExts...)
+
+	cmd.Flags().StringVar(&o.Repository, "repository", ".",
+		"path to the local git repository")
+	_ = cmd.MarkFlagDirname("repository")
+
+	cmd.Flags().StringVarP(&o.Output, "output", "o", verifyOutputTypes[0],
+		"output format for the signer information ("+strings.Join(verifyOutputTypes, "|")+")")
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(verifyOutputTypes, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,42 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// HardwareAttestationOptions is the wrapper for hardware key attestation
+// verification options.
+type HardwareAttestationOptions struct {
+	RequireHardwareKey       bool
+	HardwareAttestationRoots string
+}
+
+var _ Interface = (*HardwareAttestationOptions)(nil)
+
+// AddFlags implements Interface
+func (o *HardwareAttestationOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.RequireHardwareKey, "require-hardware-key", false,
+		"require signatures to carry a hardware attestation proving that the signing key was generated on, and never left, a PIV or PKCS#11 token")
+
+	cmd.Flags().StringVar(&o.HardwareAttestationRoots, "hardware-attestation-roots", "",
+		"path to a list of vendor root CA X.509 certificates in PEM format that hardware attestations must chain up to, "+
+			"e.g. the Yubico PIV attestation root")
+	_ = cmd.MarkFlagFilename("hardware-attestation-roots", certificateExts...)
+
+	cmd.MarkFlagsRequiredTogether("require-hardware-key", "hardware-attestation-roots")
+}
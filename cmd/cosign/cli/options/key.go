@@ -45,6 +45,9 @@ type KeyOpts struct {
 	TSAServerURL         string
 	RFC3161TimestampPath string
 	TSACertChainPath     string
+	// HardwareAttestationPath is the path to the PEM encoded hardware
+	// attestation chain of the signing key.
+	HardwareAttestationPath string
 	// IssueCertificate controls whether to issue a certificate when a key is
 	// provided.
 	IssueCertificateForExistingKey bool
@@ -0,0 +1,72 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"strings"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/spf13/cobra"
+)
+
+// KeyReencryptOptions is the top level wrapper for the key reencrypt command.
+type KeyReencryptOptions struct {
+	Key         string
+	OutputKey   string
+	Format      string
+	KDFStrength string
+	Unencrypted bool
+}
+
+var _ Interface = (*KeyReencryptOptions)(nil)
+
+// AddFlags implements Interface
+func (o *KeyReencryptOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Key, "key", "",
+		"path to the encrypted private key file")
+	_ = cmd.MarkFlagFilename("key", privateKeyExts...)
+	_ = cmd.MarkFlagRequired("key")
+
+	cmd.Flags().StringVar(&o.OutputKey, "output-key", "",
+		"path to write the re-encrypted private key to, defaults to replacing --key")
+	_ = cmd.MarkFlagFilename("output-key", privateKeyExts...)
+
+	cmd.Flags().StringVar(&o.Format, "format", string(cosign.PrivateKeyFormatCosign),
+		"format of the re-encrypted private key, one of "+strings.Join(cosign.PrivateKeyFormats(), ", "))
+
+	cmd.Flags().StringVar(&o.KDFStrength, "kdf-strength", "",
+		"cost of deriving the encryption key from the password, one of "+strings.Join(cosign.KDFStrengths(), ", ")+
+			" (defaults to standard, cannot be set for openssh)")
+
+	cmd.Flags().BoolVar(&o.Unencrypted, "unencrypted", false,
+		"write the private key without a password instead of asking for a new one")
+	cmd.MarkFlagsMutuallyExclusive("unencrypted", "kdf-strength")
+}
+
+// KeyInfoOptions is the top level wrapper for the key info command.
+type KeyInfoOptions struct {
+	Key string
+}
+
+var _ Interface = (*KeyInfoOptions)(nil)
+
+// AddFlags implements Interface
+func (o *KeyInfoOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Key, "key", "",
+		"path to the private key file")
+	_ = cmd.MarkFlagFilename("key", privateKeyExts...)
+	_ = cmd.MarkFlagRequired("key")
+}
@@ -0,0 +1,59 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// KeyRotateOptions is the top level wrapper for the key rotate command.
+type KeyRotateOptions struct {
+	Key              string
+	KMS              string
+	OutputKeyPrefix  string
+	KeySet           string
+	OutputTransition string
+	Overlap          time.Duration
+}
+
+var _ Interface = (*KeyRotateOptions)(nil)
+
+// AddFlags implements Interface
+func (o *KeyRotateOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Key, "key", "",
+		"path to the private key file, KMS URI or Kubernetes Secret of the key being retired")
+	_ = cmd.MarkFlagFilename("key", privateKeyExts...)
+	_ = cmd.MarkFlagRequired("key")
+
+	cmd.Flags().StringVar(&o.KMS, "kms", "",
+		"create the new key in this KMS service; the key must not exist yet, as KMS providers return an existing key instead of a new version")
+
+	cmd.Flags().StringVar(&o.OutputKeyPrefix, "output-key-prefix", "",
+		"name used for the new .pub and .key files")
+
+	cmd.Flags().StringVar(&o.KeySet, "keyset", "cosign.keyset.json",
+		"path to the key set recording the validity window of each key, created from --key if it does not exist")
+	_ = cmd.MarkFlagFilename("keyset", "json")
+
+	cmd.Flags().StringVar(&o.OutputTransition, "output-transition", "key-transition.json",
+		"path to write the key transition statement signed by the retired and the new key")
+	_ = cmd.MarkFlagFilename("output-transition", "json")
+
+	cmd.Flags().DurationVar(&o.Overlap, "overlap", 30*24*time.Hour,
+		"how long the retired key remains valid after the new key becomes valid")
+}
@@ -61,6 +61,14 @@ var rekorEntryTypes = []string{
 	"intoto",
 }
 
+var pkcs11KeyAlgorithms = []string{
+	"ecdsa-p256", // first one is the default
+	"ecdsa-p384",
+	"rsa-2048",
+	"rsa-3072",
+	"rsa-4096",
+}
+
 var securityKeySlots = []string{
 	"authentication",
 	"signature",
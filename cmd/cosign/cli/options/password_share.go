@@ -0,0 +1,37 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// PasswordShareOptions is the wrapper for reconstructing a private key
+// password from the Shamir shares written by generate-key-pair.
+type PasswordShareOptions struct {
+	Files  []string
+	Prompt bool
+}
+
+var _ Interface = (*PasswordShareOptions)(nil)
+
+// AddFlags implements Interface
+func (o *PasswordShareOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&o.Files, "password-share", nil,
+		"path to a share of the private key password created with generate-key-pair --password-shares. May be repeated")
+	cmd.Flags().BoolVar(&o.Prompt, "password-share-prompt", false,
+		"prompt for the password shares not given with --password-share")
+}
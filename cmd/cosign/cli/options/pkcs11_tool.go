@@ -16,6 +16,9 @@
 package options
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/sigstore/cosign/v3/pkg/cosign/env"
 	"github.com/spf13/cobra"
 )
@@ -55,3 +58,104 @@ func (o *PKCS11ToolListKeysUrisOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.Pin, "pin", "",
 		"pin of the PKCS11 slot, uses environment variable COSIGN_PKCS11_PIN if empty")
 }
+
+// PKCS11ToolGenerateKeyOptions is the wrapper for `pkcs11-tool generate-key` related options.
+type PKCS11ToolGenerateKeyOptions struct {
+	ModulePath string
+	SlotID     uint
+	Pin        string
+	KeyLabel   string
+	KeyID      string
+	Algorithm  string
+}
+
+var _ Interface = (*PKCS11ToolGenerateKeyOptions)(nil)
+
+// AddFlags implements Interface
+func (o *PKCS11ToolGenerateKeyOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.ModulePath, "module-path", env.Getenv(env.VariablePKCS11ModulePath),
+		"absolute path to the PKCS11 module")
+	_ = cmd.MarkFlagFilename("module-path", moduleExts...)
+
+	cmd.Flags().UintVar(&o.SlotID, "slot-id", 0,
+		"id of the PKCS11 slot, uses 0 if empty")
+
+	cmd.Flags().StringVar(&o.Pin, "pin", "",
+		"pin of the PKCS11 slot, uses environment variable COSIGN_PKCS11_PIN if empty")
+
+	cmd.Flags().StringVar(&o.KeyLabel, "key-label", "",
+		"label (object) of the generated key")
+
+	cmd.Flags().StringVar(&o.KeyID, "key-id", "",
+		"hex encoded id of the generated key, uses a random id if empty")
+
+	cmd.Flags().StringVar(&o.Algorithm, "algorithm", pkcs11KeyAlgorithms[0],
+		fmt.Sprintf("algorithm of the generated key (%s)", strings.Join(pkcs11KeyAlgorithms, "|")))
+	_ = cmd.RegisterFlagCompletionFunc("algorithm", cobra.FixedCompletions(pkcs11KeyAlgorithms, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// PKCS11ToolImportCertificateOptions is the wrapper for `pkcs11-tool import-certificate` related options.
+type PKCS11ToolImportCertificateOptions struct {
+	ModulePath string
+	SlotID     uint
+	Pin        string
+	KeyLabel   string
+	KeyID      string
+	Cert       string
+}
+
+var _ Interface = (*PKCS11ToolImportCertificateOptions)(nil)
+
+// AddFlags implements Interface
+func (o *PKCS11ToolImportCertificateOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.ModulePath, "module-path", env.Getenv(env.VariablePKCS11ModulePath),
+		"absolute path to the PKCS11 module")
+	_ = cmd.MarkFlagFilename("module-path", moduleExts...)
+
+	cmd.Flags().UintVar(&o.SlotID, "slot-id", 0,
+		"id of the PKCS11 slot, uses 0 if empty")
+
+	cmd.Flags().StringVar(&o.Pin, "pin", "",
+		"pin of the PKCS11 slot, uses environment variable COSIGN_PKCS11_PIN if empty")
+
+	cmd.Flags().StringVar(&o.KeyLabel, "key-label", "",
+		"label (object) of the key the certificate belongs to")
+
+	cmd.Flags().StringVar(&o.KeyID, "key-id", "",
+		"hex encoded id of the key the certificate belongs to")
+
+	cmd.Flags().StringVar(&o.Cert, "certificate", "",
+		"path to the PEM encoded certificate to import")
+	_ = cmd.MarkFlagFilename("certificate", certificateExts...)
+	_ = cmd.MarkFlagRequired("certificate")
+}
+
+// PKCS11ToolDeleteKeyOptions is the wrapper for `pkcs11-tool delete-key` related options.
+type PKCS11ToolDeleteKeyOptions struct {
+	ModulePath string
+	SlotID     uint
+	Pin        string
+	KeyLabel   string
+	KeyID      string
+}
+
+var _ Interface = (*PKCS11ToolDeleteKeyOptions)(nil)
+
+// AddFlags implements Interface
+func (o *PKCS11ToolDeleteKeyOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.ModulePath, "module-path", env.Getenv(env.VariablePKCS11ModulePath),
+		"absolute path to the PKCS11 module")
+	_ = cmd.MarkFlagFilename("module-path", moduleExts...)
+
+	cmd.Flags().UintVar(&o.SlotID, "slot-id", 0,
+		"id of the PKCS11 slot, uses 0 if empty")
+
+	cmd.Flags().StringVar(&o.Pin, "pin", "",
+		"pin of the PKCS11 slot, uses environment variable COSIGN_PKCS11_PIN if empty")
+
+	cmd.Flags().StringVar(&o.KeyLabel, "key-label", "",
+		"label (object) of the key to delete")
+
+	cmd.Flags().StringVar(&o.KeyID, "key-id", "",
+		"hex encoded id of the key to delete")
+}
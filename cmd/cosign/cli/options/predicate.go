@@ -17,7 +17,7 @@ package options
 
 import (
 	"fmt"
-	"net/url"
+	"strings"
 
 	"github.com/in-toto/in-toto-golang/in_toto"
 	slsa02 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
@@ -27,35 +27,47 @@ import (
 )
 
 const (
-	PredicateCustom    = "custom"
-	PredicateSLSA      = "slsaprovenance"
-	PredicateSLSA02    = "slsaprovenance02"
-	PredicateSLSA1     = "slsaprovenance1"
-	PredicateSPDX      = "spdx"
-	PredicateSPDXJSON  = "spdxjson"
-	PredicateCycloneDX = "cyclonedx"
-	PredicateLink      = "link"
-	PredicateVuln      = "vuln"
-	PredicateOpenVEX   = "openvex"
+	PredicateCustom     = "custom"
+	PredicateSLSA       = "slsaprovenance"
+	PredicateSLSA02     = "slsaprovenance02"
+	PredicateSLSA1      = "slsaprovenance1"
+	PredicateSLSA11     = "slsaprovenance11"
+	PredicateSPDX       = "spdx"
+	PredicateSPDXJSON   = "spdxjson"
+	PredicateCycloneDX  = "cyclonedx"
+	PredicateLink       = "link"
+	PredicateVuln       = "vuln"
+	PredicateOpenVEX    = "openvex"
+	PredicateVSA        = "vsa"
+	PredicateTestResult = "testresult"
+	PredicateRelease    = "release"
 )
 
 // PredicateTypeMap is the mapping between the predicate `type` option to predicate URI.
+//
+// Deprecated: use attestation.Lookup, which also resolves predicate types
+// registered with --predicate-schema.
 var PredicateTypeMap = map[string]string{
-	PredicateCustom:    attestation.CosignCustomProvenanceV01,
-	PredicateSLSA:      slsa02.PredicateSLSAProvenance,
-	PredicateSLSA02:    slsa02.PredicateSLSAProvenance,
-	PredicateSLSA1:     slsa1.PredicateSLSAProvenance,
-	PredicateSPDX:      in_toto.PredicateSPDX,
-	PredicateSPDXJSON:  in_toto.PredicateSPDX,
-	PredicateCycloneDX: in_toto.PredicateCycloneDX,
-	PredicateLink:      in_toto.PredicateLinkV1,
-	PredicateVuln:      attestation.CosignVulnProvenanceV01,
-	PredicateOpenVEX:   attestation.OpenVexNamespace,
+	PredicateCustom:     attestation.CosignCustomProvenanceV01,
+	PredicateSLSA:       slsa02.PredicateSLSAProvenance,
+	PredicateSLSA02:     slsa02.PredicateSLSAProvenance,
+	PredicateSLSA1:      slsa1.PredicateSLSAProvenance,
+	PredicateSLSA11:     slsa1.PredicateSLSAProvenance,
+	PredicateSPDX:       in_toto.PredicateSPDX,
+	PredicateSPDXJSON:   in_toto.PredicateSPDX,
+	PredicateCycloneDX:  in_toto.PredicateCycloneDX,
+	PredicateLink:       in_toto.PredicateLinkV1,
+	PredicateVuln:       attestation.CosignVulnProvenanceV01,
+	PredicateOpenVEX:    attestation.OpenVexNamespace,
+	PredicateVSA:        attestation.SLSAVerificationSummaryV1,
+	PredicateTestResult: attestation.TestResultV01,
+	PredicateRelease:    attestation.ReleaseV02,
 }
 
 // PredicateOptions is the wrapper for predicate related options.
 type PredicateOptions struct {
-	Type string
+	Type    string
+	Schemas []string
 }
 
 var _ Interface = (*PredicateOptions)(nil)
@@ -63,19 +75,31 @@ var _ Interface = (*PredicateOptions)(nil)
 // AddFlags implements Interface
 func (o *PredicateOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.Type, "type", "custom",
-		"specify a predicate type (slsaprovenance|slsaprovenance02|slsaprovenance1|link|spdx|spdxjson|cyclonedx|vuln|openvex|custom) or an URI")
+		"specify a predicate type ("+strings.Join(attestation.PredicateTypeNames(), "|")+"), a name registered with --predicate-schema, or an URI")
+
+	cmd.Flags().StringArrayVar(&o.Schemas, "predicate-schema", nil,
+		"register a predicate type as name=path, where path is a JSON Schema whose $id is the predicate type URI. "+
+			"Predicates of that type are validated against the schema. May be repeated")
 }
 
-// ParsePredicateType parses the predicate `type` flag passed into a predicate URI, or validates `type` is a valid URI.
-func ParsePredicateType(t string) (string, error) {
-	uri, ok := PredicateTypeMap[t]
-	if !ok {
-		if _, err := url.ParseRequestURI(t); err != nil {
-			return "", fmt.Errorf("invalid predicate type: %s", t)
+// RegisterSchemas registers the predicate types given with --predicate-schema,
+// so they can be used with --type.
+func (o *PredicateOptions) RegisterSchemas() error {
+	for _, s := range o.Schemas {
+		name, path, ok := strings.Cut(s, "=")
+		if !ok || name == "" || path == "" {
+			return fmt.Errorf("invalid --predicate-schema %q, expected name=path", s)
+		}
+		if err := attestation.RegisterSchemaFile(name, path); err != nil {
+			return err
 		}
-		uri = t
 	}
-	return uri, nil
+	return nil
+}
+
+// ParsePredicateType parses the predicate `type` flag passed into a predicate URI, or validates `type` is a valid URI.
+func ParsePredicateType(t string) (string, error) {
+	return attestation.ResolvePredicateType(t)
 }
 
 // PredicateLocalOptions is the wrapper for predicate related options.
@@ -96,8 +120,6 @@ func (o *PredicateLocalOptions) AddFlags(cmd *cobra.Command) {
 
 	cmd.Flags().StringVar(&o.Statement, "statement", "",
 		"path to the statement file.")
-
-	cmd.MarkFlagsOneRequired("predicate", "statement")
 }
 
 // PredicateRemoteOptions is the wrapper for remote predicate related options.
@@ -0,0 +1,90 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RevocationOptions is the wrapper for revocation list related options.
+type RevocationOptions struct {
+	RevocationList    string
+	RevocationListKey string
+}
+
+var _ Interface = (*RevocationOptions)(nil)
+
+// AddFlags implements Interface
+func (o *RevocationOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.RevocationList, "revocation-list", "",
+		"path to a revocation list written by 'cosign revoke'. Signatures are rejected if their key, certificate or identity was revoked at their trusted time")
+	_ = cmd.MarkFlagFilename("revocation-list", "json")
+
+	cmd.Flags().StringVar(&o.RevocationListKey, "revocation-list-key", "",
+		"path to the public key file, KMS URI or Kubernetes Secret the revocation list is signed with")
+	_ = cmd.MarkFlagFilename("revocation-list-key", publicKeyExts...)
+
+	cmd.MarkFlagsRequiredTogether("revocation-list", "revocation-list-key")
+}
+
+// RevokeOptions is the top level wrapper for the revoke command.
+type RevokeOptions struct {
+	Key                string
+	RevocationList     string
+	PublicKeys         []string
+	KeyIDs             []string
+	CertificateSerials []string
+	Identities         []string
+	OIDCIssuer         string
+	EffectiveTime      string
+	Reason             string
+}
+
+var _ Interface = (*RevokeOptions)(nil)
+
+// AddFlags implements Interface
+func (o *RevokeOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Key, "key", "",
+		"path to the private key file, KMS URI or Kubernetes Secret the revocation list is signed with")
+	_ = cmd.MarkFlagFilename("key", privateKeyExts...)
+	_ = cmd.MarkFlagRequired("key")
+
+	cmd.Flags().StringVar(&o.RevocationList, "revocation-list", "revocations.json",
+		"path to the revocation list to update, created if it does not exist")
+	_ = cmd.MarkFlagFilename("revocation-list", "json")
+
+	cmd.Flags().StringArrayVar(&o.PublicKeys, "public-key", nil,
+		"path to a public key file, KMS URI or Kubernetes Secret to revoke. May be repeated")
+	_ = cmd.MarkFlagFilename("public-key", publicKeyExts...)
+
+	cmd.Flags().StringArrayVar(&o.KeyIDs, "key-id", nil,
+		"base64 encoded SHA-256 digest of a DER encoded public key to revoke, as found in the public key hint of a bundle. May be repeated")
+
+	cmd.Flags().StringArrayVar(&o.CertificateSerials, "certificate-serial", nil,
+		"hex encoded serial number of a certificate to revoke. May be repeated")
+
+	cmd.Flags().StringArrayVar(&o.Identities, "certificate-identity", nil,
+		"certificate identity, such as an email address or workflow URI, to revoke. May be repeated")
+
+	cmd.Flags().StringVar(&o.OIDCIssuer, "certificate-oidc-issuer", "",
+		"only revoke the identities for certificates issued for this OIDC issuer")
+
+	cmd.Flags().StringVar(&o.EffectiveTime, "effective-time", "",
+		"RFC3339 time from which signatures are rejected, defaults to now. Signatures with an earlier trusted time remain valid")
+
+	cmd.Flags().StringVar(&o.Reason, "reason", "",
+		"reason for the revocation, reported when a signature is rejected")
+}
@@ -22,6 +22,8 @@ import (
 // SignOptions is the top level wrapper for the sign command.
 type SignOptions struct {
 	Key                     string
+	Keys                    []string // further signing keys, from repeated --key flags
+	Keyless                 bool
 	Cert                    string
 	CertChain               string
 	Upload                  bool
@@ -47,11 +49,13 @@ type SignOptions struct {
 	UseSigningConfig        bool
 	SigningConfigPath       string
 	TrustedRootPath         string
+	HardwareAttestation     string
 
-	Rekor       RekorOptions
-	Fulcio      FulcioOptions
-	OIDC        OIDCOptions
-	SecurityKey SecurityKeyOptions
+	Rekor         RekorOptions
+	Fulcio        FulcioOptions
+	OIDC          OIDCOptions
+	SecurityKey   SecurityKeyOptions
+	PasswordShare PasswordShareOptions
 	AnnotationOptions
 	Registry             RegistryOptions
 	RegistryExperimental RegistryExperimentalOptions
@@ -59,20 +63,32 @@ type SignOptions struct {
 
 var _ Interface = (*SignOptions)(nil)
 
+// KeyRefs returns Key, when set, followed by Keys.
+func (o *SignOptions) KeyRefs() []string {
+	if o.Key == "" {
+		return o.Keys
+	}
+	return append([]string{o.Key}, o.Keys...)
+}
+
 // AddFlags implements Interface
 func (o *SignOptions) AddFlags(cmd *cobra.Command) {
 	o.Rekor.AddFlags(cmd)
 	o.Fulcio.AddFlags(cmd)
 	o.OIDC.AddFlags(cmd)
 	o.SecurityKey.AddFlags(cmd)
+	o.PasswordShare.AddFlags(cmd)
 	o.AnnotationOptions.AddFlags(cmd)
 	o.Registry.AddFlags(cmd)
 	o.RegistryExperimental.AddFlags(cmd)
 
-	cmd.Flags().StringVar(&o.Key, "key", "",
-		"path to the private key file, KMS URI or Kubernetes Secret")
+	cmd.Flags().StringArrayVar(&o.Keys, "key", nil,
+		"path to the private key file, KMS URI or Kubernetes Secret. May be repeated to sign with several keys at once")
 	_ = cmd.MarkFlagFilename("key", privateKeyExts...)
 
+	cmd.Flags().BoolVar(&o.Keyless, "keyless", false,
+		"also sign with a Fulcio certificate for the OIDC identity when --key is given")
+
 	cmd.Flags().StringVar(&o.Cert, "certificate", "",
 		"path to the X.509 certificate in PEM format to include in the OCI Signature")
 	_ = cmd.MarkFlagFilename("certificate", certificateExts...)
@@ -147,6 +163,11 @@ func (o *SignOptions) AddFlags(cmd *cobra.Command) {
 
 	_ = cmd.MarkFlagFilename("certificate", certificateExts...)
 
+	cmd.Flags().StringVar(&o.HardwareAttestation, "hardware-attestation", "",
+		"path to the PEM encoded hardware attestation chain of the signing key, starting with the certificate attesting the key, to attach to the signature. "+
+			"Fetched from the token when signing with --sk")
+	_ = cmd.MarkFlagFilename("hardware-attestation", certificateExts...)
+
 	cmd.Flags().BoolVar(&o.IssueCertificate, "issue-certificate", false,
 		"issue a code signing certificate from Fulcio, even if a key is provided")
 	_ = cmd.Flags().MarkDeprecated("issue-certificate", "support for this flag will be removed in the future")
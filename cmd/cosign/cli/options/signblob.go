@@ -36,6 +36,7 @@ type SignBlobOptions struct {
 	OutputSignature      string // TODO: this should be the root output file arg.
 	OutputCertificate    string
 	SecurityKey          SecurityKeyOptions
+	PasswordShare        PasswordShareOptions
 	Fulcio               FulcioOptions
 	Rekor                RekorOptions
 	OIDC                 OIDCOptions
@@ -52,6 +53,9 @@ type SignBlobOptions struct {
 	RFC3161TimestampPath string
 	IssueCertificate     bool
 	SigningAlgorithm     string
+	Tree                 bool
+	TreeManifest         string
+	Digest               string
 
 	UseSigningConfig  bool
 	SigningConfigPath string
@@ -63,6 +67,7 @@ var _ Interface = (*SignBlobOptions)(nil)
 // AddFlags implements Interface
 func (o *SignBlobOptions) AddFlags(cmd *cobra.Command) {
 	o.SecurityKey.AddFlags(cmd)
+	o.PasswordShare.AddFlags(cmd)
 	o.Fulcio.AddFlags(cmd)
 	o.Rekor.AddFlags(cmd)
 	o.OIDC.AddFlags(cmd)
@@ -158,6 +163,19 @@ func (o *SignBlobOptions) AddFlags(cmd *cobra.Command) {
 		"issue a code signing certificate from Fulcio, even if a key is provided")
 	_ = cmd.Flags().MarkDeprecated("issue-certificate", "support for this flag will be removed in the future")
 
+	cmd.Flags().BoolVar(&o.Tree, "tree", false,
+		"treat the argument as a directory and sign a manifest of the path, size and sha256 digest of every file below it")
+
+	cmd.Flags().StringVar(&o.TreeManifest, "tree-manifest", "",
+		"write the tree manifest to FILE. Required with --tree")
+	_ = cmd.MarkFlagFilename("tree-manifest", "json")
+
+	cmd.Flags().StringVar(&o.Digest, "digest", "",
+		"sign a precomputed artifact digest of the form <algorithm>:<hex> instead of a file. "+
+			"The algorithm must match the hash used by --signing-algorithm")
+	_ = cmd.RegisterFlagCompletionFunc("digest", cobra.NoFileCompletions)
+	cmd.MarkFlagsMutuallyExclusive("digest", "tree")
+
 	keyAlgorithmTypes := cosign.GetSupportedAlgorithms()
 	keyAlgorithmHelp := fmt.Sprintf("signing algorithm to use for signing/hashing (allowed %s)", strings.Join(keyAlgorithmTypes, ", "))
 	defaultKeyFlag, _ := signature.FormatSignatureAlgorithmFlag(v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256)
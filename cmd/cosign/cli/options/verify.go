@@ -21,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/sigstore/cosign/v3/internal/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
 )
 
 type CommonVerifyOptions struct {
@@ -82,9 +83,18 @@ func (o *CommonVerifyOptions) AddFlags(cmd *cobra.Command) {
 
 var verifyOutputTypes = []string{"json", "text"} // First one is the default
 
+// addKeySetFlag adds the --keyset flag, an alternative to --key.
+func addKeySetFlag(cmd *cobra.Command, keySet *string) {
+	cmd.Flags().StringVar(keySet, "keyset", "",
+		"path to a key set written by 'cosign key rotate'. Signatures are accepted from any of its keys whose validity window contains the transparency log or signed timestamp time")
+	_ = cmd.MarkFlagFilename("keyset", "json")
+	cmd.MarkFlagsMutuallyExclusive("key", "keyset")
+}
+
 // VerifyOptions is the top level wrapper for the `verify` command.
 type VerifyOptions struct {
 	Key          string
+	KeySet       string
 	CheckClaims  bool
 	Attachment   string
 	Output       string
@@ -93,11 +103,14 @@ type VerifyOptions struct {
 	LocalImage   bool
 
 	CommonVerifyOptions CommonVerifyOptions
+	Revocation          RevocationOptions
+	HardwareAttestation HardwareAttestationOptions
 	SecurityKey         SecurityKeyOptions
 	CertVerify          CertVerifyOptions
 	Rekor               RekorOptions
 	Registry            RegistryOptions
 	SignatureDigest     SignatureDigestOptions
+	VSA                 VSAOptions
 
 	AnnotationOptions
 }
@@ -113,6 +126,9 @@ func (o *VerifyOptions) AddFlags(cmd *cobra.Command) {
 	o.SignatureDigest.AddFlags(cmd)
 	o.AnnotationOptions.AddFlags(cmd)
 	o.CommonVerifyOptions.AddFlags(cmd)
+	o.Revocation.AddFlags(cmd)
+	o.HardwareAttestation.AddFlags(cmd)
+	o.VSA.AddFlags(cmd)
 
 	_ = cmd.Flags().MarkDeprecated("rekor-url", "please use --bundle, which includes the Rekor inclusion proof")
 
@@ -120,6 +136,8 @@ func (o *VerifyOptions) AddFlags(cmd *cobra.Command) {
 		"path to the public key file, KMS URI or Kubernetes Secret")
 	_ = cmd.MarkFlagFilename("key", publicKeyExts...)
 
+	addKeySetFlag(cmd, &o.KeySet)
+
 	cmd.Flags().BoolVar(&o.CheckClaims, "check-claims", true,
 		"whether to check the claims found")
 
@@ -149,18 +167,27 @@ func (o *VerifyOptions) AddFlags(cmd *cobra.Command) {
 // VerifyAttestationOptions is the top level wrapper for the `verify attestation` command.
 type VerifyAttestationOptions struct {
 	Key         string
+	KeySet      string
 	CheckClaims bool
 	Output      string
 
 	CommonVerifyOptions CommonVerifyOptions
+	Revocation          RevocationOptions
 	SecurityKey         SecurityKeyOptions
 	Rekor               RekorOptions
 	CertVerify          CertVerifyOptions
 	Registry            RegistryOptions
 	Predicate           PredicateRemoteOptions
 	SignatureDigest     SignatureDigestOptions
+	VSA                 VSAOptions
+	Query               AttestationQueryOptions
+	Vuln                VulnPolicyOptions
 	Policies            []string
+	Convert             string
 	LocalImage          bool
+
+	MergeAttestationFormats bool
+	RequirePredicateTypes   []string
 }
 
 var _ Interface = (*VerifyAttestationOptions)(nil)
@@ -173,7 +200,11 @@ func (o *VerifyAttestationOptions) AddFlags(cmd *cobra.Command) {
 	o.Registry.AddFlags(cmd)
 	o.Predicate.AddFlags(cmd)
 	o.CommonVerifyOptions.AddFlags(cmd)
+	o.Revocation.AddFlags(cmd)
 	o.SignatureDigest.AddFlags(cmd)
+	o.VSA.AddFlags(cmd)
+	o.Query.AddFlags(cmd)
+	o.Vuln.AddFlags(cmd)
 
 	_ = cmd.Flags().MarkDeprecated("rekor-url", "please use --bundle, which includes the Rekor inclusion proof")
 
@@ -181,6 +212,8 @@ func (o *VerifyAttestationOptions) AddFlags(cmd *cobra.Command) {
 		"path to the public key file, KMS URI or Kubernetes Secret")
 	_ = cmd.MarkFlagFilename("key", publicKeyExts...)
 
+	addKeySetFlag(cmd, &o.KeySet)
+
 	cmd.Flags().BoolVar(&o.CheckClaims, "check-claims", true,
 		"whether to check the claims found")
 
@@ -188,17 +221,32 @@ func (o *VerifyAttestationOptions) AddFlags(cmd *cobra.Command) {
 		"specify CUE or Rego files with policies to be used for validation")
 	_ = cmd.MarkFlagFilename("policy", "cue", "rego")
 
+	cmd.Flags().StringVar(&o.Convert, "convert", "",
+		"evaluate --policy against SBOM attestations, whether SPDX, CycloneDX or Syft, with the predicate converted: "+
+			"cyclonedx for CycloneDX 1.6 JSON, or components for the normalized component list; "+
+			"attestations that are not SBOMs are skipped")
+	_ = cmd.RegisterFlagCompletionFunc("convert", cobra.FixedCompletions(attestation.SBOMConversions(), cobra.ShellCompDirectiveNoFileComp))
+
 	cmd.Flags().StringVarP(&o.Output, "output", "o", verifyOutputTypes[0],
 		"output format for the signing image information ("+strings.Join(verifyOutputTypes, "|")+")")
 	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(verifyOutputTypes, cobra.ShellCompDirectiveNoFileComp))
 
 	cmd.Flags().BoolVar(&o.LocalImage, "local-image", false,
 		"whether the specified image is a path to an image saved locally via 'cosign save'")
+
+	cmd.Flags().BoolVar(&o.MergeAttestationFormats, "merge-attestation-formats", false,
+		"verify the attestations attached as Sigstore bundles and those of the legacy .att tag in one pass, "+
+			"dropping identical statements, instead of only the bundles when there are any")
+
+	cmd.Flags().StringSliceVar(&o.RequirePredicateTypes, "require-predicate-type", nil,
+		"fail unless the verified attestations, of any format, include one of each of these predicate types; "+
+			"accepts the same names and URIs as --type")
 }
 
 // VerifyBlobOptions is the top level wrapper for the `verify blob` command.
 type VerifyBlobOptions struct {
 	Key        string
+	KeySet     string
 	Signature  string
 	BundlePath string
 
@@ -206,9 +254,14 @@ type VerifyBlobOptions struct {
 	CertVerify          CertVerifyOptions
 	Rekor               RekorOptions
 	CommonVerifyOptions CommonVerifyOptions
+	Revocation          RevocationOptions
 	SignatureDigest     SignatureDigestOptions
 
 	RFC3161TimestampPath string
+
+	Tree         bool
+	TreeManifest string
+	Digest       string
 }
 
 var _ Interface = (*VerifyBlobOptions)(nil)
@@ -219,6 +272,7 @@ func (o *VerifyBlobOptions) AddFlags(cmd *cobra.Command) {
 	o.Rekor.AddFlags(cmd)
 	o.CertVerify.AddFlags(cmd)
 	o.CommonVerifyOptions.AddFlags(cmd)
+	o.Revocation.AddFlags(cmd)
 	o.SignatureDigest.AddFlags(cmd)
 
 	_ = cmd.Flags().MarkDeprecated("rekor-url", "please use --bundle, which includes the Rekor inclusion proof")
@@ -227,6 +281,8 @@ func (o *VerifyBlobOptions) AddFlags(cmd *cobra.Command) {
 		"path to the public key file, KMS URI or Kubernetes Secret")
 	_ = cmd.MarkFlagFilename("key", publicKeyExts...)
 
+	addKeySetFlag(cmd, &o.KeySet)
+
 	cmd.Flags().StringVar(&o.Signature, "signature", "",
 		"signature content or path or remote URL")
 	_ = cmd.MarkFlagFilename("signature", signatureExts...)
@@ -240,6 +296,19 @@ func (o *VerifyBlobOptions) AddFlags(cmd *cobra.Command) {
 		"path to RFC3161 timestamp FILE")
 	// _ = cmd.MarkFlagFilename("rfc3161-timestamp") // no typical extensions
 	_ = cmd.Flags().MarkDeprecated("rfc3161-timestamp", "please use --bundle to provide the output bundle location, which will include the signed timestamp")
+
+	cmd.Flags().BoolVar(&o.Tree, "tree", false,
+		"treat the argument as a directory and verify it against the signed manifest given by --tree-manifest")
+
+	cmd.Flags().StringVar(&o.TreeManifest, "tree-manifest", "",
+		"path to the tree manifest FILE produced by sign-blob --tree. Required with --tree")
+	_ = cmd.MarkFlagFilename("tree-manifest", "json")
+
+	cmd.Flags().StringVar(&o.Digest, "digest", "",
+		"verify against a precomputed artifact digest of the form <algorithm>:<hex> instead of a file. "+
+			"Only supported with --bundle in the new bundle format")
+	_ = cmd.RegisterFlagCompletionFunc("digest", cobra.NoFileCompletions)
+	cmd.MarkFlagsMutuallyExclusive("digest", "tree")
 }
 
 // VerifyDockerfileOptions is the top level wrapper for the `dockerfile verify` command.
@@ -261,6 +330,7 @@ func (o *VerifyDockerfileOptions) AddFlags(cmd *cobra.Command) {
 // VerifyBlobAttestationOptions is the top level wrapper for the `verify-blob-attestation` command.
 type VerifyBlobAttestationOptions struct {
 	Key           string
+	KeySet        string
 	SignaturePath string
 	BundlePath    string
 
@@ -271,6 +341,7 @@ type VerifyBlobAttestationOptions struct {
 	CertVerify          CertVerifyOptions
 	Rekor               RekorOptions
 	CommonVerifyOptions CommonVerifyOptions
+	Revocation          RevocationOptions
 	SignatureDigest     SignatureDigestOptions
 
 	RFC3161TimestampPath string
@@ -288,6 +359,7 @@ func (o *VerifyBlobAttestationOptions) AddFlags(cmd *cobra.Command) {
 	o.Rekor.AddFlags(cmd)
 	o.CertVerify.AddFlags(cmd)
 	o.CommonVerifyOptions.AddFlags(cmd)
+	o.Revocation.AddFlags(cmd)
 	o.SignatureDigest.AddFlags(cmd)
 
 	_ = cmd.Flags().MarkDeprecated("rekor-url", "please use --bundle, which includes the Rekor inclusion proof")
@@ -296,6 +368,8 @@ func (o *VerifyBlobAttestationOptions) AddFlags(cmd *cobra.Command) {
 		"path to the public key file, KMS URI or Kubernetes Secret")
 	_ = cmd.MarkFlagFilename("key", publicKeyExts...)
 
+	addKeySetFlag(cmd, &o.KeySet)
+
 	cmd.Flags().StringVar(&o.SignaturePath, "signature", "",
 		"path to base64-encoded signature over attestation in DSSE format")
 	_ = cmd.MarkFlagFilename("signature", signatureExts...)
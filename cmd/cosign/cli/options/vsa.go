@@ -0,0 +1,69 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// DefaultVSAVerifierID identifies cosign as the verifier in Verification
+// Summary Attestations unless --vsa-verifier-id is set.
+const DefaultVSAVerifierID = "https://github.com/sigstore/cosign"
+
+// VSAOptions is the wrapper for the Verification Summary Attestation written
+// after a successful verification.
+type VSAOptions struct {
+	Path           string
+	VerifierID     string
+	PolicyURI      string
+	VerifiedLevels []string
+	Key            string
+	Attach         bool
+	TlogUpload     bool
+}
+
+var _ Interface = (*VSAOptions)(nil)
+
+// AddFlags implements Interface
+func (o *VSAOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Path, "emit-vsa", "",
+		"write a SLSA Verification Summary Attestation to this path after successful verification. "+
+			"The in-toto statement is written as is, or as a Sigstore bundle if --vsa-key is set. Requires verifying a single image")
+
+	cmd.Flags().StringVar(&o.VerifierID, "vsa-verifier-id", DefaultVSAVerifierID,
+		"URI identifying the verifier in the VSA")
+
+	cmd.Flags().StringVar(&o.PolicyURI, "vsa-policy-uri", "",
+		"URI of the policy recorded in the VSA. Defaults to the --policy file when there is exactly one")
+
+	cmd.Flags().StringSliceVar(&o.VerifiedLevels, "vsa-verified-levels", []string{"SLSA_BUILD_LEVEL_0"},
+		"levels recorded as verified in the VSA")
+
+	cmd.Flags().StringVar(&o.Key, "vsa-key", "",
+		"path to the private key file, KMS URI or Kubernetes Secret used to sign the VSA")
+	_ = cmd.MarkFlagFilename("vsa-key", privateKeyExts...)
+
+	cmd.Flags().BoolVar(&o.Attach, "vsa-attach", false,
+		"attach the signed VSA to the verified image as an attestation; requires --vsa-key")
+
+	cmd.Flags().BoolVar(&o.TlogUpload, "vsa-tlog-upload", true,
+		"whether or not to upload the signed VSA to the tlog")
+}
+
+// Enabled reports whether a VSA should be produced.
+func (o *VSAOptions) Enabled() bool {
+	return o.Path != "" || o.Attach
+}
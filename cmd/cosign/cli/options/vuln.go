@@ -0,0 +1,60 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// VulnPolicyOptions is the wrapper for the built-in checks of vulnerability
+// scan attestations.
+type VulnPolicyOptions struct {
+	MaxAge               string
+	MaxSeverity          string
+	AllowUnknownSeverity bool
+	VEX                  []string
+	Residual             bool
+}
+
+var _ Interface = (*VulnPolicyOptions)(nil)
+
+// AddFlags implements Interface
+func (o *VulnPolicyOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.MaxAge, "max-age", "",
+		"with --type vuln, fail if the latest vulnerability scan finished longer ago than this, e.g. 7d or 12h")
+
+	cmd.Flags().StringVar(&o.MaxSeverity, "max-severity", "",
+		"with --type vuln, fail if the vulnerability scans have findings more severe than this "+
+			"(negligible|low|medium|high|critical) that no OpenVEX statement marks as not_affected or fixed")
+	_ = cmd.RegisterFlagCompletionFunc("max-severity", cobra.FixedCompletions([]string{"negligible", "low", "medium", "high", "critical"}, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.Flags().BoolVar(&o.AllowUnknownSeverity, "allow-unknown-severity", false,
+		"with --max-severity, allow findings the scanner reports with an unknown severity, which otherwise fail the check")
+
+	cmd.Flags().StringSliceVar(&o.VEX, "vex", nil,
+		"paths to OpenVEX documents applied to vulnerability scan findings, in addition to verified OpenVEX attestations on the image. "+
+			"Statements apply when they name the image digest among their products, or name no products; the latest statement about a vulnerability wins")
+	_ = cmd.MarkFlagFilename("vex", "json")
+
+	cmd.Flags().BoolVar(&o.Residual, "residual", false,
+		"with --type vuln, merge the latest scan of each scanner with the OpenVEX statements and print the residual vulnerabilities "+
+			"as JSON instead of the attestations; --policy is evaluated against this report")
+}
+
+// Enabled reports whether vulnerability scans should be checked.
+func (o *VulnPolicyOptions) Enabled() bool {
+	return o.MaxAge != "" || o.MaxSeverity != "" || len(o.VEX) > 0 || o.Residual
+}
@@ -34,10 +34,12 @@ func PKCS11Tool() *cobra.Command {
 	cmd.AddCommand(
 		pkcs11ToolListTokens(),
 		PKCS11ToolListKeysUrisOptions(),
+		pkcs11ToolGenerateKey(),
+		pkcs11ToolImportCertificate(),
+		pkcs11ToolDeleteKey(),
 	)
 
 	// TODO: drop -f in favor of --no-input only
-	// TODO: use the force flag.
 	cmd.PersistentFlags().BoolVarP(&pkcs11ToolForce, "no-input", "f", false,
 		"skip warnings and confirmations")
 
@@ -81,3 +83,71 @@ func PKCS11ToolListKeysUrisOptions() *cobra.Command {
 
 	return cmd
 }
+
+func pkcs11ToolGenerateKey() *cobra.Command {
+	o := &options.PKCS11ToolGenerateKeyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "generate-key",
+		Short: "Generate a signing key pair in a PKCS11 token",
+		Long: `Generate a signing key pair in a PKCS11 token.
+
+The public key is printed to stdout, the key id and URI to stderr. Ed25519 keys
+are not supported, the PKCS11 signer only handles RSA and ECDSA keys.`,
+		Example: `  # generate an ECDSA P-256 key pair in a specific PKCS11 token slot
+  cosign pkcs11-tool generate-key --module-path /usr/lib/softhsm/libsofthsm2.so --slot-id 0 --key-label release > release.pub
+
+  # generate an RSA 4096 key pair with a specific key id
+  cosign pkcs11-tool generate-key --module-path /usr/lib/softhsm/libsofthsm2.so --key-label release --key-id 0102 --algorithm rsa-4096`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pkcs11cli.GenerateKeyCmd(cmd.Context(), o.ModulePath, o.SlotID, o.Pin, o.KeyLabel, o.KeyID, o.Algorithm)
+		},
+	}
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func pkcs11ToolImportCertificate() *cobra.Command {
+	o := &options.PKCS11ToolImportCertificateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import-certificate",
+		Short: "Import the certificate of a key pair into a PKCS11 token",
+		Long: `Import the certificate of a key pair into a PKCS11 token.
+
+The certificate is stored with the id and label of the key pair, replacing any
+previous certificate, so that signing with the key URI uses it.`,
+		Example: `  # import the certificate of the key labeled release
+  cosign pkcs11-tool import-certificate --module-path /usr/lib/softhsm/libsofthsm2.so --key-label release --certificate release.crt`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pkcs11cli.ImportCertificateCmd(cmd.Context(), o.ModulePath, o.SlotID, o.Pin, o.KeyLabel, o.KeyID, o.Cert)
+		},
+	}
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func pkcs11ToolDeleteKey() *cobra.Command {
+	o := &options.PKCS11ToolDeleteKeyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete-key",
+		Short: "Delete a key pair and its certificate from a PKCS11 token",
+		Example: `  # delete the key pair with id 0102 without confirmation
+  cosign pkcs11-tool delete-key --module-path /usr/lib/softhsm/libsofthsm2.so --key-id 0102 --no-input`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pkcs11cli.DeleteKeyCmd(cmd.Context(), o.ModulePath, o.SlotID, o.Pin, o.KeyLabel, o.KeyID, pkcs11ToolForce)
+		},
+	}
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
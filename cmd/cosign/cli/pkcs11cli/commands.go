@@ -112,24 +112,9 @@ func GetKeysInfo(_ context.Context, modulePath string, slotID uint, pin string)
 		return nil, fmt.Errorf("get token info: %w", err)
 	}
 
-	// If pin was not given, check COSIGN_PKCS11_PIN environment variable.
-	if pin == "" {
-		pin = env.Getenv(env.VariablePKCS11Pin)
-
-		// If COSIGN_PKCS11_PIN was not set, check if CKF_LOGIN_REQUIRED is set in Token Info.
-		// If it is, ask the user for the PIN, otherwise, do not.
-		if pin == "" {
-			if tokenInfo.Flags&pkcs11.CKF_LOGIN_REQUIRED == pkcs11.CKF_LOGIN_REQUIRED {
-				fmt.Fprintf(os.Stderr, "Enter PIN for PKCS11 token '%s': ", tokenInfo.Label)
-				// Unnecessary convert of syscall.Stdin on *nix, but Windows is a uintptr
-				// nolint:unconvert
-				b, err := term.ReadPassword(int(syscall.Stdin))
-				if err != nil {
-					return nil, fmt.Errorf("get pin: %w", err)
-				}
-				pin = string(b)
-			}
-		}
+	pin, err = tokenPin(pin, tokenInfo)
+	if err != nil {
+		return nil, err
 	}
 
 	// Open a new session to the token.
@@ -211,6 +196,29 @@ func GetKeysInfo(_ context.Context, modulePath string, slotID uint, pin string)
 	return keysInfo, nil
 }
 
+// tokenPin returns pin if it is set. Otherwise, it checks the
+// COSIGN_PKCS11_PIN environment variable, then asks the user for the PIN if
+// CKF_LOGIN_REQUIRED is set in Token Info.
+func tokenPin(pin string, tokenInfo pkcs11.TokenInfo) (string, error) {
+	if pin != "" {
+		return pin, nil
+	}
+	if pin = env.Getenv(env.VariablePKCS11Pin); pin != "" {
+		return pin, nil
+	}
+	if tokenInfo.Flags&pkcs11.CKF_LOGIN_REQUIRED != pkcs11.CKF_LOGIN_REQUIRED {
+		return "", nil
+	}
+	fmt.Fprintf(os.Stderr, "Enter PIN for PKCS11 token '%s': ", tokenInfo.Label)
+	// Unnecessary convert of syscall.Stdin on *nix, but Windows is a uintptr
+	// nolint:unconvert
+	b, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return "", fmt.Errorf("get pin: %w", err)
+	}
+	return string(b), nil
+}
+
 func ListTokensCmd(ctx context.Context, modulePath string) error {
 	if modulePath == "" {
 		return fmt.Errorf("please specify --module-path or set COSIGN_PKCS11_MODULE_PATH")
@@ -0,0 +1,322 @@
+//go:build pkcs11key
+// +build pkcs11key
+
+// Copyright 2026 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs11cli
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/miekg/pkcs11"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign/pkcs11key"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// token is a crypto11 context logged in to the token of a slot.
+type token struct {
+	*crypto11.Context
+	modulePath string
+	slotID     uint
+	info       pkcs11.TokenInfo
+}
+
+// openToken logs in to the token in slotID. The PIN is resolved as in
+// GetKeysInfo.
+func openToken(modulePath string, slotID uint, pin string) (*token, error) {
+	if modulePath == "" || !filepath.IsAbs(modulePath) {
+		return nil, flag.ErrHelp
+	}
+
+	p := pkcs11.New(modulePath)
+	if p == nil {
+		return nil, errors.New("failed to load PKCS11 module")
+	}
+	info, err := func() (pkcs11.TokenInfo, error) {
+		if err := p.Initialize(); err != nil {
+			return pkcs11.TokenInfo{}, fmt.Errorf("initialize PKCS11 module: %w", err)
+		}
+		defer p.Destroy()
+		defer p.Finalize()
+		// YKCS11 (Yubico's pkcs#11 library) requires this to initialize correctly
+		// See https://github.com/Yubico/yubico-piv-tool/issues/571
+		if _, err := p.GetSlotList(true); err != nil {
+			return pkcs11.TokenInfo{}, fmt.Errorf("error getting slot list %w", err)
+		}
+		info, err := p.GetTokenInfo(slotID)
+		if err != nil {
+			return pkcs11.TokenInfo{}, fmt.Errorf("get token info: %w", err)
+		}
+		return info, nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	pin, err = tokenPin(pin, info)
+	if err != nil {
+		return nil, err
+	}
+	slot := int(slotID)
+	ctx, err := crypto11.Configure(&crypto11.Config{Path: modulePath, SlotNumber: &slot, Pin: pin})
+	if err != nil {
+		return nil, fmt.Errorf("login: %w", err)
+	}
+	return &token{Context: ctx, modulePath: modulePath, slotID: slotID, info: info}, nil
+}
+
+// uri returns the PKCS11 URI of a key of the token, without the PIN.
+func (t *token) uri(keyLabel, keyID []byte) (string, error) {
+	slot := int(t.slotID)
+	return pkcs11key.NewPkcs11UriConfigFromInput(t.modulePath, &slot, t.info.Label, keyLabel, keyID, "").Construct()
+}
+
+// findKeyPair returns the key pair with the given label or id, along with
+// its label and id read from the token when not given.
+func (t *token) findKeyPair(keyLabel, keyID []byte) (crypto11.Signer, []byte, []byte, error) {
+	if len(keyLabel) == 0 && len(keyID) == 0 {
+		return nil, nil, nil, errors.New("one of --key-label and --key-id must be set")
+	}
+	signer, err := t.FindKeyPair(nilIfEmpty(keyID), nilIfEmpty(keyLabel))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("find key pair: %w", err)
+	}
+	if signer == nil {
+		return nil, nil, nil, fmt.Errorf("no key pair found for id=%q label=%q in slot %d", hex.EncodeToString(keyID), keyLabel, t.slotID)
+	}
+	attrs, err := t.GetAttributes(signer, []crypto11.AttributeType{crypto11.CkaLabel, crypto11.CkaId})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get key attributes: %w", err)
+	}
+	if a := attrs[crypto11.CkaLabel]; len(keyLabel) == 0 && a != nil {
+		keyLabel = a.Value
+	}
+	if a := attrs[crypto11.CkaId]; len(keyID) == 0 && a != nil {
+		keyID = a.Value
+	}
+	return signer, keyLabel, keyID, nil
+}
+
+// keyAttributes returns the attributes setting the id and label of new
+// objects, leaving out empty ones.
+func keyAttributes(keyLabel, keyID []byte) (crypto11.AttributeSet, error) {
+	attrs := crypto11.NewAttributeSet()
+	for attr, v := range map[crypto11.AttributeType][]byte{crypto11.CkaId: keyID, crypto11.CkaLabel: keyLabel} {
+		if len(v) == 0 {
+			continue
+		}
+		if err := attrs.Set(attr, v); err != nil {
+			return nil, err
+		}
+	}
+	return attrs, nil
+}
+
+func nilIfEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+// GenerateKeyCmd generates a key pair in the token and prints its public
+// key. Ed25519 is not offered, the PKCS11 signer only handles RSA and ECDSA
+// keys.
+func GenerateKeyCmd(_ context.Context, modulePath string, slotID uint, pin string, keyLabel string, keyIDHex string, algorithm string) error {
+	keyID, err := hex.DecodeString(keyIDHex)
+	if err != nil {
+		return fmt.Errorf("parse key id: %w", err)
+	}
+	if len(keyID) == 0 {
+		keyID = make([]byte, 16)
+		if _, err := rand.Read(keyID); err != nil {
+			return err
+		}
+	}
+	generate, ok := map[string]func(t *token, attrs crypto11.AttributeSet) (crypto11.Signer, error){
+		"ecdsa-p256": ecdsaGenerator(elliptic.P256()),
+		"ecdsa-p384": ecdsaGenerator(elliptic.P384()),
+		"rsa-2048":   rsaGenerator(2048),
+		"rsa-3072":   rsaGenerator(3072),
+		"rsa-4096":   rsaGenerator(4096),
+	}[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+
+	t, err := openToken(modulePath, slotID, pin)
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	// Signing looks keys up by id or by label, both must be unique.
+	existing := [][2][]byte{{keyID, nil}}
+	if keyLabel != "" {
+		existing = append(existing, [2][]byte{nil, []byte(keyLabel)})
+	}
+	for _, e := range existing {
+		signer, err := t.FindKeyPair(e[0], e[1])
+		if err != nil {
+			return fmt.Errorf("find key pair: %w", err)
+		}
+		if signer != nil {
+			return fmt.Errorf("a key pair with id=%q or label=%q already exists in slot %d", hex.EncodeToString(keyID), keyLabel, slotID)
+		}
+	}
+
+	attrs, err := keyAttributes([]byte(keyLabel), keyID)
+	if err != nil {
+		return err
+	}
+	signer, err := generate(t, attrs)
+	if err != nil {
+		return fmt.Errorf("generate key pair: %w", err)
+	}
+	pemBytes, err := cryptoutils.MarshalPublicKeyToPEM(signer.Public())
+	if err != nil {
+		return err
+	}
+	uri, err := t.uri([]byte(keyLabel), keyID)
+	if err != nil {
+		return fmt.Errorf("construct pkcs11 uri: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Generated %s key pair in slot %d\n", algorithm, slotID)
+	fmt.Fprintf(os.Stderr, "\tID: %s\n", hex.EncodeToString(keyID))
+	fmt.Fprintf(os.Stderr, "\tURI: %s\n", uri)
+	fmt.Fprint(os.Stdout, string(pemBytes))
+	return nil
+}
+
+func ecdsaGenerator(curve elliptic.Curve) func(*token, crypto11.AttributeSet) (crypto11.Signer, error) {
+	return func(t *token, attrs crypto11.AttributeSet) (crypto11.Signer, error) {
+		return t.GenerateECDSAKeyPairWithAttributes(attrs, attrs.Copy(), curve)
+	}
+}
+
+func rsaGenerator(bits int) func(*token, crypto11.AttributeSet) (crypto11.Signer, error) {
+	return func(t *token, attrs crypto11.AttributeSet) (crypto11.Signer, error) {
+		return t.GenerateRSAKeyPairWithAttributes(attrs, attrs.Copy(), bits)
+	}
+}
+
+// ImportCertificateCmd imports the certificate of a key pair of the token,
+// with the id and label of the key so that signing picks it up.
+func ImportCertificateCmd(ctx context.Context, modulePath string, slotID uint, pin string, keyLabel string, keyIDHex string, certPath string) error {
+	keyID, err := hex.DecodeString(keyIDHex)
+	if err != nil {
+		return fmt.Errorf("parse key id: %w", err)
+	}
+	certPEM, err := os.ReadFile(filepath.Clean(certPath))
+	if err != nil {
+		return err
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(certPEM)
+	if err != nil {
+		return fmt.Errorf("parse certificate: %w", err)
+	}
+	if len(certs) != 1 {
+		return fmt.Errorf("expected a single certificate in %s, found %d", certPath, len(certs))
+	}
+	cert := certs[0]
+
+	t, err := openToken(modulePath, slotID, pin)
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	signer, label, keyID, err := t.findKeyPair([]byte(keyLabel), keyID)
+	if err != nil {
+		return err
+	}
+	if err := cryptoutils.EqualKeys(signer.Public(), cert.PublicKey); err != nil {
+		return fmt.Errorf("certificate does not match the key pair: %w", err)
+	}
+
+	if err := replaceCertificate(ctx, t, label, keyID, cert); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Imported certificate for %s into slot %d\n", cert.Subject, slotID)
+	return nil
+}
+
+func replaceCertificate(ctx context.Context, t *token, keyLabel, keyID []byte, cert *x509.Certificate) error {
+	existing, err := t.FindCertificate(nilIfEmpty(keyID), nilIfEmpty(keyLabel), nil)
+	if err != nil {
+		return fmt.Errorf("find certificate: %w", err)
+	}
+	if existing != nil {
+		ui.Warnf(ctx, "Replacing the certificate for %s of the key pair", existing.Subject)
+		if err := t.DeleteCertificate(nilIfEmpty(keyID), nilIfEmpty(keyLabel), nil); err != nil {
+			return fmt.Errorf("delete certificate: %w", err)
+		}
+	}
+	attrs, err := keyAttributes(keyLabel, keyID)
+	if err != nil {
+		return err
+	}
+	if err := t.ImportCertificateWithAttributes(attrs, cert); err != nil {
+		return fmt.Errorf("import certificate: %w", err)
+	}
+	return nil
+}
+
+// DeleteKeyCmd deletes a key pair of the token and its certificate, if any.
+// Unless force is set, it asks for confirmation first.
+func DeleteKeyCmd(ctx context.Context, modulePath string, slotID uint, pin string, keyLabel string, keyIDHex string, force bool) error {
+	keyID, err := hex.DecodeString(keyIDHex)
+	if err != nil {
+		return fmt.Errorf("parse key id: %w", err)
+	}
+
+	t, err := openToken(modulePath, slotID, pin)
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	signer, label, keyID, err := t.findKeyPair([]byte(keyLabel), keyID)
+	if err != nil {
+		return err
+	}
+	if !force {
+		ui.Warnf(ctx, "Deleting key pair id=%q label=%q from slot %d. This cannot be undone. Continue?", hex.EncodeToString(keyID), label, slotID)
+		if err := ui.ConfirmContinue(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := t.DeleteCertificate(nilIfEmpty(keyID), nilIfEmpty(label), nil); err != nil {
+		return fmt.Errorf("delete certificate: %w", err)
+	}
+	if err := signer.Delete(); err != nil {
+		return fmt.Errorf("delete key pair: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Deleted key pair from slot %d\n", slotID)
+	return nil
+}
@@ -50,6 +50,9 @@ func PublicKey() *cobra.Command {
   # extract public key from Hashicorp Vault KMS
   cosign public-key --key hashivault://[KEY]
 
+  # extract public key from a signer plugin (runs cosign-signer-[NAME] from $PATH)
+  cosign public-key --key plugin://[NAME]/[KEY]
+
   # extract public key from GitLab with project name
   cosign public-key --key gitlab://[OWNER]/[PROJECT_NAME] <IMAGE>
 
@@ -0,0 +1,79 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/revoke"
+	"github.com/spf13/cobra"
+)
+
+func Revoke() *cobra.Command {
+	o := &options.RevokeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke signing keys, certificates or identities",
+		Long: `Add keys, certificates or identities to a signed revocation list.
+
+The revocation list is signed with --key. Verification commands given the list with
+--revocation-list and the matching public key with --revocation-list-key reject
+signatures whose trusted transparency log or timestamp time is at or after the
+effective time of a matching revocation. Signatures made before a key was revoked
+remain valid. Revoking an entry again may move its effective time earlier, never
+later, and replaces its reason.`,
+		Example: `  cosign revoke --key <key path>|<kms uri> [--public-key <key>] [--key-id <id>] [--certificate-serial <serial>] [--certificate-identity <identity>]
+
+  # revoke a leaked key from now on
+  cosign revoke --key revocation.key --public-key ci.pub --reason "leaked in build logs"
+
+  # revoke a key from the time it is known to have leaked
+  cosign revoke --key revocation.key --public-key ci.pub --effective-time 2025-06-01T00:00:00Z
+
+  # revoke a certificate by serial number
+  cosign revoke --key revocation.key --certificate-serial 4a:2b:1c:...
+
+  # revoke an identity issued by GitHub Actions
+  cosign revoke --key revocation.key --certificate-identity https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main \
+    --certificate-oidc-issuer https://token.actions.githubusercontent.com
+
+  # reject signatures made by revoked signers
+  cosign verify --key ci.pub --revocation-list revocations.json --revocation-list-key revocation.pub <IMAGE>
+
+CAVEATS:
+  This command interactively prompts for the password of the revocation list key.
+  You can use the COSIGN_PASSWORD environment variable to provide one.`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: options.BindViper,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			revokeCmd := &revoke.RevokeCmd{
+				KeyRef:             o.Key,
+				RevocationListPath: o.RevocationList,
+				PublicKeys:         o.PublicKeys,
+				KeyIDs:             o.KeyIDs,
+				CertificateSerials: o.CertificateSerials,
+				Identities:         o.Identities,
+				OIDCIssuer:         o.OIDCIssuer,
+				EffectiveTime:      o.EffectiveTime,
+				Reason:             o.Reason,
+			}
+			return revokeCmd.Exec(cmd.Context())
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,157 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	sigs "github.com/sigstore/cosign/v3/pkg/signature"
+)
+
+// RevokeCmd adds revocations to a signed revocation list.
+type RevokeCmd struct {
+	KeyRef             string
+	RevocationListPath string
+	PublicKeys         []string
+	KeyIDs             []string
+	CertificateSerials []string
+	Identities         []string
+	OIDCIssuer         string
+	EffectiveTime      string
+	Reason             string
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+}
+
+func (c *RevokeCmd) Exec(ctx context.Context) error {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	effective := now().UTC().Truncate(time.Second)
+	if c.EffectiveTime != "" {
+		t, err := time.Parse(time.RFC3339, c.EffectiveTime)
+		if err != nil {
+			return fmt.Errorf("parsing --effective-time: %w", err)
+		}
+		effective = t.UTC()
+	}
+	if c.OIDCIssuer != "" && len(c.Identities) == 0 {
+		return errors.New("--certificate-oidc-issuer requires --certificate-identity")
+	}
+
+	revocations, err := c.revocations(ctx, effective)
+	if err != nil {
+		return err
+	}
+	if len(revocations) == 0 {
+		return errors.New("nothing to revoke, provide --public-key, --key-id, --certificate-serial or --certificate-identity")
+	}
+
+	signer, err := sigs.SignerVerifierFromKeyRef(ctx, c.KeyRef, generate.GetPass, nil)
+	if err != nil {
+		return fmt.Errorf("loading revocation list key: %w", err)
+	}
+	rl, err := cosign.LoadRevocationList(c.RevocationListPath, signer)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		rl = &cosign.RevocationList{}
+	case err != nil:
+		return err
+	}
+
+	for _, r := range revocations {
+		rl.Revocations, r = merge(rl.Revocations, r)
+		fmt.Fprintf(os.Stderr, "Revoked %s, effective %s\n", &r, r.EffectiveTime.Format(time.RFC3339))
+	}
+	rl.Updated = now().UTC().Truncate(time.Second)
+
+	envelope, err := cosign.SignRevocationList(rl, signer)
+	if err != nil {
+		return fmt.Errorf("signing revocation list: %w", err)
+	}
+	if err := os.WriteFile(c.RevocationListPath, envelope, 0644); err != nil { //nolint: gosec
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Revocation list written to", c.RevocationListPath)
+	return nil
+}
+
+func (c *RevokeCmd) revocations(ctx context.Context, effective time.Time) ([]cosign.Revocation, error) {
+	var revocations []cosign.Revocation
+	add := func(r cosign.Revocation) {
+		r.EffectiveTime = effective
+		r.Reason = c.Reason
+		revocations = append(revocations, r)
+	}
+	for _, ref := range c.PublicKeys {
+		verifier, err := sigs.PublicKeyFromKeyRef(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("loading public key %s: %w", ref, err)
+		}
+		pub, err := verifier.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		id, err := cosign.PublicKeyID(pub)
+		if err != nil {
+			return nil, err
+		}
+		add(cosign.Revocation{KeyID: id})
+	}
+	for _, id := range c.KeyIDs {
+		add(cosign.Revocation{KeyID: id})
+	}
+	for _, serial := range c.CertificateSerials {
+		s, err := cosign.NormalizeCertificateSerial(serial)
+		if err != nil {
+			return nil, err
+		}
+		add(cosign.Revocation{CertificateSerial: s})
+	}
+	for _, identity := range c.Identities {
+		add(cosign.Revocation{Identity: identity, OIDCIssuer: c.OIDCIssuer})
+	}
+	return revocations, nil
+}
+
+// merge adds r to revocations and returns the revocation recorded. Revoking
+// a key, certificate or identity again may only move its effective time
+// earlier, as signatures made in between must stay rejected, and updates the
+// reason if one is given.
+func merge(revocations []cosign.Revocation, r cosign.Revocation) ([]cosign.Revocation, cosign.Revocation) {
+	for i, existing := range revocations {
+		if existing.KeyID == r.KeyID && existing.CertificateSerial == r.CertificateSerial &&
+			existing.Identity == r.Identity && existing.OIDCIssuer == r.OIDCIssuer {
+			if r.EffectiveTime.Before(existing.EffectiveTime) {
+				existing.EffectiveTime = r.EffectiveTime
+			}
+			if r.Reason != "" {
+				existing.Reason = r.Reason
+			}
+			revocations[i] = existing
+			return revocations, existing
+		}
+	}
+	return append(revocations, r), r
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+func TestRevokeCmd(t *testing.T) {
+	t.Setenv("COSIGN_PASSWORD", "")
+	ctx := context.Background()
+	td := t.TempDir()
+
+	writeKeyPair := func(name string) (string, string) {
+		keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return nil, nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		priv, pub := filepath.Join(td, name+".key"), filepath.Join(td, name+".pub")
+		if err := os.WriteFile(priv, keys.PrivateBytes, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(pub, keys.PublicBytes, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return priv, pub
+	}
+	authorityKey, authorityPub := writeKeyPair("authority")
+	_, leakedPub := writeKeyPair("leaked")
+
+	listPath := filepath.Join(td, "revocations.json")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	revoke := func(c RevokeCmd) error {
+		c.KeyRef = authorityKey
+		c.RevocationListPath = listPath
+		c.Now = func() time.Time { return now }
+		return c.Exec(ctx)
+	}
+	load := func() *cosign.RevocationList {
+		t.Helper()
+		b, err := os.ReadFile(authorityPub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifier, err := signature.LoadDefaultVerifier(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rl, err := cosign.LoadRevocationList(listPath, verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rl
+	}
+
+	if err := revoke(RevokeCmd{PublicKeys: []string{leakedPub}, Reason: "leaked"}); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if err := revoke(RevokeCmd{
+		CertificateSerials: []string{"0A:FF"},
+		Identities:         []string{"ci@example.com"},
+		OIDCIssuer:         "https://accounts.google.com",
+		EffectiveTime:      "2025-06-01T00:00:00Z",
+	}); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	rl := load()
+	if len(rl.Revocations) != 3 || !rl.Updated.Equal(now) {
+		t.Fatalf("unexpected revocation list %+v", rl)
+	}
+	if r := rl.Revocations[0]; r.KeyID == "" || r.Reason != "leaked" || !r.EffectiveTime.Equal(now) {
+		t.Errorf("unexpected key revocation %+v", r)
+	}
+	if r := rl.Revocations[1]; r.CertificateSerial != "aff" {
+		t.Errorf("unexpected certificate revocation %+v", r)
+	}
+
+	// Revoking the same key again may move its effective time earlier,
+	// keeping the reason unless a new one is given.
+	if err := revoke(RevokeCmd{PublicKeys: []string{leakedPub}, EffectiveTime: "2025-01-01T00:00:00Z"}); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	rl = load()
+	if len(rl.Revocations) != 3 || rl.Revocations[0].EffectiveTime.Year() != 2025 || rl.Revocations[0].Reason != "leaked" {
+		t.Errorf("unexpected revocation list %+v", rl)
+	}
+
+	// But not later, which would accept signatures made in between.
+	if err := revoke(RevokeCmd{PublicKeys: []string{leakedPub}, Reason: "compromised"}); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	rl = load()
+	if r := rl.Revocations[0]; len(rl.Revocations) != 3 || r.EffectiveTime.Year() != 2025 || r.Reason != "compromised" {
+		t.Errorf("unexpected key revocation %+v", r)
+	}
+
+	// The list must be signed by the same key.
+	otherKey, _ := writeKeyPair("other")
+	if err := (&RevokeCmd{KeyRef: otherKey, RevocationListPath: listPath, KeyIDs: []string{"abc"}}).Exec(ctx); err == nil {
+		t.Error("expected error updating the list with another key")
+	}
+	if err := revoke(RevokeCmd{}); err == nil {
+		t.Error("expected error without anything to revoke")
+	}
+	if err := revoke(RevokeCmd{KeyIDs: []string{"abc"}, OIDCIssuer: "https://accounts.google.com"}); err == nil {
+		t.Error("expected error for an OIDC issuer without identities")
+	}
+}
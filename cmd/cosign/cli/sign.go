@@ -52,9 +52,19 @@ race conditions or (worse) malicious tampering.
   # sign a container image and add annotations
   cosign sign --key cosign.key -a key1=value1 -a key2=value2 <IMAGE DIGEST>
 
+  # sign a container image with a key whose password is split into shares, prompting for the shares not given as files
+  cosign sign --key root.key --password-share root.share-1 --password-share-prompt <IMAGE DIGEST>
+
   # sign a container image with a key stored in an environment variable
   cosign sign --key env://[ENV_VAR] <IMAGE DIGEST>
 
+  # sign a container image with an OpenSSH private key file
+  cosign sign --key ~/.ssh/id_ed25519 <IMAGE DIGEST>
+
+  # sign a container image with an ECDSA, RSA or Ed25519 key held by ssh-agent, selected by its fingerprint or comment
+  # (Ed25519 keys only without uploading to the transparency log)
+  cosign sign --key ssh-agent://[FINGERPRINT] <IMAGE DIGEST>
+
   # sign a container image with a key pair stored in Azure Key Vault
   cosign sign --key azurekms://[VAULT_NAME][VAULT_URI]/[KEY] <IMAGE DIGEST>
 
@@ -70,6 +80,21 @@ race conditions or (worse) malicious tampering.
   # sign a container image with a key pair stored in a Kubernetes secret
   cosign sign --key k8s://[NAMESPACE]/[KEY] <IMAGE DIGEST>
 
+  # sign a container image with an encrypted key pair stored in a Hashicorp Vault KV secret
+  cosign sign --key vault://[MOUNT]/[PATH] <IMAGE DIGEST>
+
+  # sign a container image with a signer plugin (runs cosign-signer-[NAME] from $PATH)
+  cosign sign --key plugin://[NAME]/[KEY] <IMAGE DIGEST>
+
+  # sign a container image with two keys and a keyless identity, publishing all signatures at once
+  cosign sign --key cosign.key --key awskms://[ENDPOINT]/[ID/ALIAS/ARN] --keyless <IMAGE DIGEST>
+
+  # sign a container image with a YubiKey, attaching the attestation of the key fetched from the token
+  cosign sign --sk <IMAGE DIGEST>
+
+  # sign a container image with a PKCS#11 key, attaching its hardware attestation chain
+  cosign sign --key "pkcs11:token=[TOKEN];object=[KEY]" --hardware-attestation attestation.pem <IMAGE DIGEST>
+
   # sign a container image with a key, attaching a certificate and certificate chain
   cosign sign --key cosign.key --cert cosign.crt --cert-chain chain.crt <IMAGE DIGEST>
 
@@ -91,7 +116,7 @@ race conditions or (worse) malicious tampering.
 			if o.NewBundleFormat && !o.Upload && o.BundlePath == "" {
 				return fmt.Errorf("must enable upload to the OCI registry or specify a local --bundle path with --new-bundle-format")
 			}
-			return nil
+			return checkSigners(o)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			switch o.Attachment {
@@ -108,8 +133,7 @@ race conditions or (worse) malicious tampering.
 			}
 
 			ko := options.KeyOpts{
-				KeyRef:                         o.Key,
-				PassFunc:                       generate.GetPass,
+				PassFunc:                       generate.PassFunc(o.PasswordShare.Files, o.PasswordShare.Prompt),
 				Sk:                             o.SecurityKey.Use,
 				Slot:                           o.SecurityKey.Slot,
 				FulcioURL:                      o.Fulcio.URL,
@@ -131,14 +155,19 @@ race conditions or (worse) malicious tampering.
 				TSAServerURL:                   o.TSAServerURL,
 				IssueCertificateForExistingKey: o.IssueCertificate,
 				NewBundleFormat:                o.NewBundleFormat,
+				HardwareAttestationPath:        o.HardwareAttestation,
 			}
 			if err := signcommon.LoadTrustedMaterialAndSigningConfig(cmd.Context(), &ko, o.UseSigningConfig, o.SigningConfigPath,
 				o.Rekor.URL, o.Fulcio.URL, o.OIDC.Issuer, o.TSAServerURL, o.TrustedRootPath, o.TlogUpload,
-				o.NewBundleFormat, "", o.Key, o.IssueCertificate, o.Output, "", o.OutputCertificate, o.OutputPayload, o.OutputSignature, ""); err != nil {
+				o.NewBundleFormat, "", trustedRootKeyRef(o.KeyRefs(), o.Keyless), o.IssueCertificate, o.Output, "", o.OutputCertificate, o.OutputPayload, o.OutputSignature, ""); err != nil {
 				return err
 			}
 
-			if err := sign.SignCmd(cmd.Context(), ro, ko, *o, args); err != nil {
+			kos, err := signerKeyOpts(ko, o.KeyRefs(), o.Keyless)
+			if err != nil {
+				return err
+			}
+			if err := sign.SignWithKeysCmd(cmd.Context(), ro, kos, *o, args); err != nil {
 				if o.Attachment == "" {
 					return fmt.Errorf("signing %v: %w", args, err)
 				}
@@ -150,3 +179,61 @@ race conditions or (worse) malicious tampering.
 	o.AddFlags(cmd)
 	return cmd
 }
+
+// signerKeyOpts returns one KeyOpts per --key and one for --sk, plus a
+// keyless signer when --keyless is set or neither was given.
+func signerKeyOpts(ko options.KeyOpts, keys []string, keyless bool) ([]options.KeyOpts, error) {
+	// Only the --sk signer uses the security key.
+	keyOpts := ko
+	keyOpts.Sk = false
+	keyOpts.Slot = ""
+
+	seen := map[string]bool{}
+	kos := make([]options.KeyOpts, 0, len(keys)+2)
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("--key must not be empty")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("--key %s given more than once", key)
+		}
+		seen[key] = true
+		k := keyOpts
+		k.KeyRef = key
+		kos = append(kos, k)
+	}
+	if ko.Sk {
+		kos = append(kos, ko)
+	}
+	if len(kos) == 0 || keyless {
+		kos = append(kos, keyOpts)
+	}
+	return kos, nil
+}
+
+// checkSigners rejects flags that only work with a single signer when
+// signing with several.
+func checkSigners(o *options.SignOptions) error {
+	keySigners := len(o.KeyRefs())
+	if o.SecurityKey.Use {
+		keySigners++
+	}
+	if o.HardwareAttestation != "" && (keySigners != 1 || o.Keyless) {
+		return fmt.Errorf("--hardware-attestation requires signing with a single --key or --sk")
+	}
+	// A bundle file holds a single bundle, so several signers cannot write
+	// theirs to one.
+	if o.BundlePath != "" && (keySigners > 1 || keySigners == 1 && o.Keyless) {
+		return fmt.Errorf("--bundle requires signing with a single --key, --sk or --keyless")
+	}
+	return nil
+}
+
+// trustedRootKeyRef returns the key reference used to decide whether a
+// trusted root is needed, which is the case whenever one signer is keyless.
+func trustedRootKeyRef(keys []string, keyless bool) string {
+	if len(keys) == 0 || keyless {
+		return ""
+	}
+	return keys[0]
+}
@@ -66,8 +66,32 @@ func GetAttachedImageRef(ref name.Reference, attachment string, opts ...ociremot
 
 // nolint
 func SignCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, signOpts options.SignOptions, imgs []string) error {
-	if options.NOf(ko.KeyRef, ko.Sk) > 1 {
-		return &options.KeyParseError{}
+	return SignWithKeysCmd(ctx, ro, []options.KeyOpts{ko}, signOpts, imgs)
+}
+
+// SignWithKeysCmd signs each image once for every entry in kos, so several
+// keys and keyless identities sign the same payload in one invocation. With
+// the legacy signature format all of the signatures are attached to the
+// signature manifest in a single write, so signers listed together never
+// race to update it.
+// nolint
+func SignWithKeysCmd(ctx context.Context, ro *options.RootOptions, kos []options.KeyOpts, signOpts options.SignOptions, imgs []string) error {
+	if len(kos) == 0 {
+		return fmt.Errorf("no signers specified")
+	}
+	keySigners := 0
+	for _, ko := range kos {
+		if options.NOf(ko.KeyRef, ko.Sk) > 1 {
+			return &options.KeyParseError{}
+		}
+		if ko.KeyRef != "" {
+			keySigners++
+		}
+	}
+	// The certificate and chain belong to one key, so they cannot be
+	// attached to the signatures of several.
+	if (signOpts.Cert != "" || signOpts.CertChain != "") && keySigners > 1 {
+		return fmt.Errorf("--certificate and --certificate-chain can only be used when signing with a single --key")
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, ro.Timeout)
@@ -116,9 +140,9 @@ func SignCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, s
 				return fmt.Errorf("accessing image: %w", err)
 			}
 			if signOpts.NewBundleFormat {
-				err = signDigestBundle(ctx, digest, ko, signOpts, annotations)
+				err = signDigestBundle(ctx, digest, kos, signOpts, annotations)
 			} else {
-				err = signDigest(ctx, digest, staticPayload, ko, signOpts, annotations, se)
+				err = signDigest(ctx, digest, staticPayload, kos, signOpts, annotations, se)
 			}
 			if err != nil {
 				return fmt.Errorf("signing digest: %w", err)
@@ -139,9 +163,9 @@ func SignCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, s
 			}
 			digest := ref.Context().Digest(d.String())
 			if signOpts.NewBundleFormat {
-				err = signDigestBundle(ctx, digest, ko, signOpts, annotations)
+				err = signDigestBundle(ctx, digest, kos, signOpts, annotations)
 			} else {
-				err = signDigest(ctx, digest, staticPayload, ko, signOpts, annotations, se)
+				err = signDigest(ctx, digest, staticPayload, kos, signOpts, annotations, se)
 			}
 			if err != nil {
 				return fmt.Errorf("signing digest: %w", err)
@@ -155,7 +179,10 @@ func SignCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, s
 	return nil
 }
 
-func signDigestBundle(ctx context.Context, digest name.Digest, ko options.KeyOpts, signOpts options.SignOptions, annotations map[string]any) error {
+func signDigestBundle(ctx context.Context, digest name.Digest, kos []options.KeyOpts, signOpts options.SignOptions, annotations map[string]any) error {
+	if signOpts.BundlePath != "" && len(kos) > 1 {
+		return fmt.Errorf("a bundle file holds a single bundle, but %d signers were given", len(kos))
+	}
 	digestParts := strings.Split(digest.DigestStr(), ":")
 	if len(digestParts) != 2 {
 		return fmt.Errorf("unable to parse digest %s", digest.DigestStr())
@@ -174,11 +201,6 @@ func signDigestBundle(ctx context.Context, digest name.Digest, ko options.KeyOpt
 		Predicate:     &structpb.Struct{},
 	}
 
-	payload, err := protojson.Marshal(statement)
-	if err != nil {
-		return err
-	}
-
 	regOpts := signOpts.Registry
 	ociremoteOpts, err := regOpts.ClientOpts(ctx)
 	if err != nil {
@@ -189,7 +211,6 @@ func signDigestBundle(ctx context.Context, digest name.Digest, ko options.KeyOpt
 	}
 
 	bundleOpts := signcommon.CommonBundleOpts{
-		Payload:       payload,
 		Digest:        digest,
 		PredicateType: types.CosignSignPredicateType,
 		BundlePath:    signOpts.BundlePath,
@@ -197,27 +218,41 @@ func signDigestBundle(ctx context.Context, digest name.Digest, ko options.KeyOpt
 		OCIRemoteOpts: ociremoteOpts,
 	}
 
-	if ko.SigningConfig == nil {
-		ko.SigningConfig, err = signcommon.NewSigningConfigFromKeyOpts(ko)
+	bundles := make([][]byte, 0, len(kos))
+	for _, ko := range kos {
+		if err := setSigningConfig(ctx, &ko, digest, signOpts); err != nil {
+			return err
+		}
+		// The hardware attestation of the signing key goes in the signed
+		// predicate, so the statement differs from one signer to the next.
+		hwAttestation, err := signcommon.HardwareAttestation(ctx, ko)
 		if err != nil {
-			return fmt.Errorf("creating signing config: %w", err)
+			return err
+		}
+		statement.Predicate = &structpb.Struct{}
+		if hwAttestation != nil {
+			statement.Predicate.Fields = map[string]*structpb.Value{
+				cosign.HardwareAttestationPredicateField: structpb.NewStringValue(string(hwAttestation)),
+			}
 		}
-		shouldUpload, err := signcommon.ShouldUploadToTlog(ctx, ko, digest, signOpts.TlogUpload)
+		bundleOpts.Payload, err = protojson.Marshal(statement)
 		if err != nil {
-			return fmt.Errorf("should upload to tlog: %w", err)
+			return err
 		}
-		if !shouldUpload {
-			ko.SigningConfig = ko.SigningConfig.WithRekorLogURLs()
+		bundleBytes, pubKey, _, err := signcommon.NewAttestationBundle(ctx, ko, signOpts.Cert, signOpts.CertChain, bundleOpts, ko.SigningConfig, ko.TrustedMaterial)
+		if err != nil {
+			return err
 		}
-	}
-
-	bundleBytes, _, _, err := signcommon.NewAttestationBundle(ctx, ko, signOpts.Cert, signOpts.CertChain, bundleOpts, ko.SigningConfig, ko.TrustedMaterial)
-	if err != nil {
-		return err
+		if hwAttestation != nil {
+			if err := signcommon.CheckHardwareAttestation(hwAttestation, pubKey); err != nil {
+				return err
+			}
+		}
+		bundles = append(bundles, bundleBytes)
 	}
 
 	if signOpts.BundlePath != "" {
-		if err := os.WriteFile(signOpts.BundlePath, bundleBytes, 0600); err != nil {
+		if err := os.WriteFile(signOpts.BundlePath, bundles[0], 0600); err != nil {
 			return fmt.Errorf("create bundle file: %w", err)
 		}
 		ui.Infof(ctx, "Wrote bundle to file %s", signOpts.BundlePath)
@@ -225,7 +260,9 @@ func signDigestBundle(ctx context.Context, digest name.Digest, ko options.KeyOpt
 
 	if signOpts.Upload {
 		ui.Infof(ctx, "Pushing signature to: %s", digest.Repository)
-		if err := ociremote.WriteAttestationNewBundleFormat(digest, bundleBytes, bundleOpts.PredicateType, bundleOpts.OCIRemoteOpts...); err != nil {
+		// All of the bundles go in one referrer manifest, so that a failed
+		// upload never leaves only some of the signers' bundles behind.
+		if err := ociremote.WriteAttestationsNewBundleFormat(digest, bundles, bundleOpts.PredicateType, bundleOpts.OCIRemoteOpts...); err != nil {
 			return err
 		}
 	}
@@ -233,7 +270,28 @@ func signDigestBundle(ctx context.Context, digest name.Digest, ko options.KeyOpt
 	return nil
 }
 
-func signDigest(ctx context.Context, digest name.Digest, payload []byte, ko options.KeyOpts, signOpts options.SignOptions,
+// setSigningConfig derives a signing config from the service URLs in ko when
+// none was loaded, dropping the transparency log if uploading is declined.
+func setSigningConfig(ctx context.Context, ko *options.KeyOpts, digest name.Digest, signOpts options.SignOptions) error {
+	if ko.SigningConfig != nil {
+		return nil
+	}
+	var err error
+	ko.SigningConfig, err = signcommon.NewSigningConfigFromKeyOpts(*ko)
+	if err != nil {
+		return fmt.Errorf("creating signing config: %w", err)
+	}
+	shouldUpload, err := signcommon.ShouldUploadToTlog(ctx, *ko, digest, signOpts.TlogUpload)
+	if err != nil {
+		return fmt.Errorf("should upload to tlog: %w", err)
+	}
+	if !shouldUpload {
+		ko.SigningConfig = ko.SigningConfig.WithRekorLogURLs()
+	}
+	return nil
+}
+
+func signDigest(ctx context.Context, digest name.Digest, payload []byte, kos []options.KeyOpts, signOpts options.SignOptions,
 	annotations map[string]interface{}, se oci.SignedEntity) error {
 	var err error
 	var payloads [][]byte
@@ -258,100 +316,23 @@ func signDigest(ctx context.Context, digest name.Digest, payload []byte, ko opti
 		payloads = append(payloads, payload)
 	}
 
-	if ko.SigningConfig == nil {
-		ko.SigningConfig, err = signcommon.NewSigningConfigFromKeyOpts(ko)
-		if err != nil {
-			return fmt.Errorf("creating signing config: %w", err)
-		}
-		shouldUpload, err := signcommon.ShouldUploadToTlog(ctx, ko, digest, signOpts.TlogUpload)
-		if err != nil {
-			return fmt.Errorf("should upload to tlog: %w", err)
-		}
-		if !shouldUpload {
-			ko.SigningConfig = ko.SigningConfig.WithRekorLogURLs()
-		}
-	}
-
-	keypair, certBytes, chainBytes, idToken, err := signcommon.GetKeypairAndToken(ctx, ko, signOpts.Cert, signOpts.CertChain)
-	if err != nil {
-		return fmt.Errorf("getting keypair and token: %w", err)
-	}
-	if closer, ok := keypair.(interface{ Close() }); ok {
-		defer closer.Close()
-	}
-
-	var tsaClientTransport http.RoundTripper
-	if ko.TSAClientCACert != "" || (ko.TSAClientCert != "" && ko.TSAClientKey != "") {
-		tsaClientTransport, err = client.GetHTTPTransport(ko.TSAClientCACert, ko.TSAClientCert, ko.TSAClientKey, ko.TSAServerName, 30*time.Second)
-		if err != nil {
-			return fmt.Errorf("getting TSA client transport: %w", err)
+	var signed []*signedPayloads
+	for _, ko := range kos {
+		if err := setSigningConfig(ctx, &ko, digest, signOpts); err != nil {
+			return err
 		}
-	}
-	var certProvider sign.CertificateProvider
-	if idToken != "" {
-		certProvider, err = cbundle.NewCachingFulcioProvider(ko.SigningConfig)
+		sp, err := signPayloads(ctx, payloads, ko, signOpts)
 		if err != nil {
-			return fmt.Errorf("creating caching Fulcio provider: %w", err)
+			return err
 		}
+		signed = append(signed, sp)
 	}
 
-	cbundleOpts := cbundle.SignOptions{
-		TSAClientTransport:  tsaClientTransport,
-		CertificateProvider: certProvider,
-	}
-
-	ociSigs := make([]oci.Signature, len(payloads))
-	b64sigs := make([]string, len(payloads))
-
-	var leafCertPem []byte
-
-	for i, payload := range payloads {
-		content := &sign.PlainData{
-			Data: payload,
-		}
-
-		bundleBytes, err := cbundle.SignData(ctx, content, keypair, idToken, certBytes, chainBytes, ko.SigningConfig, ko.TrustedMaterial, cbundleOpts)
-		if err != nil {
-			return fmt.Errorf("signing bundle: %w", err)
-		}
-
-		var pb protobundle.Bundle
-		if err := protojson.Unmarshal(bundleBytes, &pb); err != nil {
-			return fmt.Errorf("unmarshalling bundle: %w", err)
-		}
-
-		bundleComponents, err := signcommon.ExtractComponentsFromProtoBundle(&pb)
-		if err != nil {
-			return fmt.Errorf("extracting components from bundle: %w", err)
-		}
-
-		certPem, chainPem := signcommon.EncodeCertificatesToPEM(bundleComponents.Certificates)
-		if i == 0 {
-			leafCertPem = certPem
-		}
-
-		b64sig := base64.StdEncoding.EncodeToString(bundleComponents.Signature)
-		b64sigs[i] = b64sig
-
-		var opts []static.Option
-		if certPem != nil {
-			opts = append(opts, static.WithCertChain(certPem, chainPem))
-		}
-
-		if len(bundleComponents.RFC3161Timestamps) > 0 {
-			opts = append(opts, static.WithRFC3161Timestamp(cbundle.TimestampToRFC3161Timestamp(bundleComponents.RFC3161Timestamps[0].GetSignedTimestamp())))
-		}
-
-		if len(bundleComponents.RekorEntries) > 0 {
-			opts = append(opts, static.WithBundle(signcommon.RekorBundleFromProtoTlogEntry(bundleComponents.RekorEntries[0])))
-		}
-
-		ociSig, err := static.NewSignature(payload, b64sig, opts...)
-		if err != nil {
-			return fmt.Errorf("creating signature: %w", err)
-		}
-
-		ociSigs[i] = ociSig
+	var ociSigs []oci.Signature
+	var b64sigs []string
+	for _, sp := range signed {
+		ociSigs = append(ociSigs, sp.ociSigs...)
+		b64sigs = append(b64sigs, sp.b64sigs...)
 	}
 
 	outputSignature := signOpts.OutputSignature
@@ -376,17 +357,11 @@ func signDigest(ctx context.Context, digest name.Digest, payload []byte, ko opti
 	}
 
 	if signOpts.OutputCertificate != "" {
+		// Each signer's certificate or public key is written, in signer order.
 		var outBytes []byte
-		if len(leafCertPem) > 0 {
-			outBytes = leafCertPem
-		} else {
-			pubPem, err := keypair.GetPublicKeyPem()
-			if err != nil {
-				return fmt.Errorf("getting public key pem: %w", err)
-			}
-			outBytes = []byte(pubPem)
+		for _, sp := range signed {
+			outBytes = append(outBytes, sp.certPem...)
 		}
-
 		if err := os.WriteFile(signOpts.OutputCertificate, outBytes, 0600); err != nil {
 			return fmt.Errorf("create certificate file: %w", err)
 		}
@@ -394,7 +369,7 @@ func signDigest(ctx context.Context, digest name.Digest, payload []byte, ko opti
 		ui.Infof(ctx, "Certificate wrote in the file %s", signOpts.OutputCertificate)
 	}
 
-	if ko.BundlePath != "" {
+	if bundlePath := kos[0].BundlePath; bundlePath != "" {
 		var contents [][]byte
 		for _, ociSig := range ociSigs {
 			signedPayload, err := fetchLocalSignedPayload(ociSig)
@@ -408,31 +383,27 @@ func signDigest(ctx context.Context, digest name.Digest, payload []byte, ko opti
 			}
 			contents = append(contents, content)
 		}
-		if err := os.WriteFile(ko.BundlePath, bytes.Join(contents, []byte("\n")), 0600); err != nil {
+		if err := os.WriteFile(bundlePath, bytes.Join(contents, []byte("\n")), 0600); err != nil {
 			return fmt.Errorf("create bundle file: %w", err)
 		}
-		ui.Infof(ctx, "Wrote bundle to file %s", ko.BundlePath)
+		ui.Infof(ctx, "Wrote bundle to file %s", bundlePath)
 	}
 
 	if !signOpts.Upload {
 		return nil
 	}
 
-	hashAlgo := signcommon.ProtoHashAlgoToHash(keypair.GetHashAlgorithm())
-	ddVerifier, err := signature.LoadVerifier(keypair.GetPublicKey(), hashAlgo)
-	if err != nil {
-		return fmt.Errorf("loading verifier: %w", err)
-	}
-	dd := cremote.NewDupeDetector(ddVerifier)
-
-	// Attach the signature to the entity.
-	var newSE oci.SignedEntity
-	for _, ociSig := range ociSigs {
-		newSE, err = mutate.AttachSignatureToEntity(se, ociSig, mutate.WithDupeDetector(dd), mutate.WithRecordCreationTimestamp(signOpts.RecordCreationTimestamp))
-		if err != nil {
-			return err
+	// Attach every signature to the entity, so that they are all published
+	// by a single write of the signature manifest.
+	newSE := se
+	for _, sp := range signed {
+		dd := cremote.NewDupeDetector(sp.verifier)
+		for _, ociSig := range sp.ociSigs {
+			newSE, err = mutate.AttachSignatureToEntity(newSE, ociSig, mutate.WithDupeDetector(dd), mutate.WithRecordCreationTimestamp(signOpts.RecordCreationTimestamp))
+			if err != nil {
+				return err
+			}
 		}
-		se = newSE
 	}
 
 	// Publish the signatures associated with this entity
@@ -458,6 +429,129 @@ func signDigest(ctx context.Context, digest name.Digest, payload []byte, ko opti
 	return ociremote.WriteSignatures(digest.Repository, newSE, walkOpts...)
 }
 
+// signedPayloads holds the signatures one signer produced over the payloads.
+type signedPayloads struct {
+	ociSigs []oci.Signature
+	b64sigs []string
+	// certPem is the leaf certificate of the first signature, or the
+	// public key when no certificate was issued.
+	certPem  []byte
+	verifier signature.Verifier
+}
+
+func signPayloads(ctx context.Context, payloads [][]byte, ko options.KeyOpts, signOpts options.SignOptions) (*signedPayloads, error) {
+	hwAttestation, err := signcommon.HardwareAttestation(ctx, ko)
+	if err != nil {
+		return nil, err
+	}
+	keypair, certBytes, chainBytes, idToken, err := signcommon.GetKeypairAndToken(ctx, ko, signOpts.Cert, signOpts.CertChain)
+	if err != nil {
+		return nil, fmt.Errorf("getting keypair and token: %w", err)
+	}
+	if closer, ok := keypair.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+	if hwAttestation != nil {
+		if err := signcommon.CheckHardwareAttestation(hwAttestation, keypair.GetPublicKey()); err != nil {
+			return nil, err
+		}
+	}
+
+	var tsaClientTransport http.RoundTripper
+	if ko.TSAClientCACert != "" || (ko.TSAClientCert != "" && ko.TSAClientKey != "") {
+		tsaClientTransport, err = client.GetHTTPTransport(ko.TSAClientCACert, ko.TSAClientCert, ko.TSAClientKey, ko.TSAServerName, 30*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("getting TSA client transport: %w", err)
+		}
+	}
+	var certProvider sign.CertificateProvider
+	if idToken != "" {
+		certProvider, err = cbundle.NewCachingFulcioProvider(ko.SigningConfig)
+		if err != nil {
+			return nil, fmt.Errorf("creating caching Fulcio provider: %w", err)
+		}
+	}
+
+	cbundleOpts := cbundle.SignOptions{
+		TSAClientTransport:  tsaClientTransport,
+		CertificateProvider: certProvider,
+	}
+
+	sp := &signedPayloads{
+		ociSigs: make([]oci.Signature, len(payloads)),
+		b64sigs: make([]string, len(payloads)),
+	}
+
+	for i, payload := range payloads {
+		content := &sign.PlainData{
+			Data: payload,
+		}
+
+		bundleBytes, err := cbundle.SignData(ctx, content, keypair, idToken, certBytes, chainBytes, ko.SigningConfig, ko.TrustedMaterial, cbundleOpts)
+		if err != nil {
+			return nil, fmt.Errorf("signing bundle: %w", err)
+		}
+
+		var pb protobundle.Bundle
+		if err := protojson.Unmarshal(bundleBytes, &pb); err != nil {
+			return nil, fmt.Errorf("unmarshalling bundle: %w", err)
+		}
+
+		bundleComponents, err := signcommon.ExtractComponentsFromProtoBundle(&pb)
+		if err != nil {
+			return nil, fmt.Errorf("extracting components from bundle: %w", err)
+		}
+
+		certPem, chainPem := signcommon.EncodeCertificatesToPEM(bundleComponents.Certificates)
+		if i == 0 {
+			sp.certPem = certPem
+		}
+
+		b64sig := base64.StdEncoding.EncodeToString(bundleComponents.Signature)
+		sp.b64sigs[i] = b64sig
+
+		var opts []static.Option
+		if hwAttestation != nil {
+			opts = append(opts, static.WithAnnotations(map[string]string{
+				cosign.HardwareAttestationAnnotationKey: string(hwAttestation),
+			}))
+		}
+		if certPem != nil {
+			opts = append(opts, static.WithCertChain(certPem, chainPem))
+		}
+
+		if len(bundleComponents.RFC3161Timestamps) > 0 {
+			opts = append(opts, static.WithRFC3161Timestamp(cbundle.TimestampToRFC3161Timestamp(bundleComponents.RFC3161Timestamps[0].GetSignedTimestamp())))
+		}
+
+		if len(bundleComponents.RekorEntries) > 0 {
+			opts = append(opts, static.WithBundle(signcommon.RekorBundleFromProtoTlogEntry(bundleComponents.RekorEntries[0])))
+		}
+
+		ociSig, err := static.NewSignature(payload, b64sig, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating signature: %w", err)
+		}
+
+		sp.ociSigs[i] = ociSig
+	}
+
+	if len(sp.certPem) == 0 {
+		pubPem, err := keypair.GetPublicKeyPem()
+		if err != nil {
+			return nil, fmt.Errorf("getting public key pem: %w", err)
+		}
+		sp.certPem = []byte(pubPem)
+	}
+
+	hashAlgo := signcommon.ProtoHashAlgoToHash(keypair.GetHashAlgorithm())
+	sp.verifier, err = signature.LoadVerifier(keypair.GetPublicKey(), hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("loading verifier: %w", err)
+	}
+	return sp, nil
+}
+
 func fetchLocalSignedPayload(sig oci.Signature) (*cosign.LocalSignedPayload, error) {
 	signedPayload := &cosign.LocalSignedPayload{}
 	var err error
@@ -19,11 +19,13 @@ import (
 	"context"
 	"crypto"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"net/http"
@@ -33,8 +35,10 @@ import (
 	internal "github.com/sigstore/cosign/v3/internal/pkg/cosign"
 	"github.com/sigstore/cosign/v3/internal/pkg/cosign/tsa/client"
 	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/blob"
 	cbundle "github.com/sigstore/cosign/v3/pkg/cosign/bundle"
 	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	"github.com/sigstore/sigstore-go/pkg/sign"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -52,10 +56,93 @@ func getPayload(ctx context.Context, payloadPath string, hashFunction crypto.Has
 	return internal.NewHashReader(f, hashFunction), f.Close, nil
 }
 
+// getContent returns the content to sign for payloadPath. The payload is
+// streamed through the keypair's hash so only its digest is held in memory,
+// except for pure Ed25519 and keys that have to be given the message itself,
+// like ssh-agent keys.
+func getContent(ctx context.Context, payloadPath string, keypair sign.Keypair) (sign.Content, error) {
+	if keypair.GetSigningAlgorithm() == protocommon.PublicKeyDetails_PKIX_ED25519 || !signsDigest(keypair) {
+		payload, closePayload, err := getPayload(ctx, payloadPath, crypto.Hash(0))
+		if err != nil {
+			return nil, fmt.Errorf("getting payload: %w", err)
+		}
+		defer closePayload()
+		data, err := io.ReadAll(&payload)
+		if err != nil {
+			return nil, fmt.Errorf("reading payload: %w", err)
+		}
+		return &sign.PlainData{Data: data}, nil
+	}
+
+	payload, closePayload, err := getPayload(ctx, payloadPath, signcommon.ProtoHashAlgoToHash(keypair.GetHashAlgorithm()))
+	if err != nil {
+		return nil, fmt.Errorf("getting payload: %w", err)
+	}
+	defer closePayload()
+	if _, err := io.Copy(io.Discard, &payload); err != nil {
+		return nil, fmt.Errorf("reading payload: %w", err)
+	}
+	return &cbundle.PrehashedData{
+		Digest:        payload.Sum(nil),
+		HashAlgorithm: keypair.GetHashAlgorithm(),
+	}, nil
+}
+
+// signsDigest reports whether keypair can sign a precomputed digest.
+func signsDigest(keypair sign.Keypair) bool {
+	d, ok := keypair.(interface{ SignsDigest() bool })
+	return !ok || d.SignsDigest()
+}
+
+// getDigestContent parses a digest of the form <algorithm>:<hex> computed
+// elsewhere, checking that it matches the keypair's hash algorithm.
+func getDigestContent(digestRef string, keypair sign.Keypair) (sign.Content, error) {
+	alg, hexDigest, ok := strings.Cut(digestRef, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid digest %q, expected <algorithm>:<hex>", digestRef)
+	}
+	hashAlgorithm, ok := map[string]protocommon.HashAlgorithm{
+		"sha256": protocommon.HashAlgorithm_SHA2_256,
+		"sha384": protocommon.HashAlgorithm_SHA2_384,
+		"sha512": protocommon.HashAlgorithm_SHA2_512,
+	}[strings.ToLower(alg)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported digest algorithm %q", alg)
+	}
+	if keypair.GetSigningAlgorithm() == protocommon.PublicKeyDetails_PKIX_ED25519 {
+		return nil, fmt.Errorf("ed25519 keys sign the artifact itself and cannot sign a digest, use --signing-algorithm=ed25519-ph")
+	}
+	if !signsDigest(keypair) {
+		return nil, fmt.Errorf("the signing key has to be given the artifact itself and cannot sign a digest")
+	}
+	if hashAlgorithm != keypair.GetHashAlgorithm() {
+		return nil, fmt.Errorf("digest algorithm %s does not match the signing algorithm, which uses %s", alg, keypair.GetHashAlgorithm())
+	}
+	digest, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return nil, fmt.Errorf("decoding digest: %w", err)
+	}
+	if len(digest) != signcommon.ProtoHashAlgoToHash(hashAlgorithm).Size() {
+		return nil, fmt.Errorf("digest has length %d, expected %d for %s", len(digest), signcommon.ProtoHashAlgoToHash(hashAlgorithm).Size(), alg)
+	}
+	return &cbundle.PrehashedData{Digest: digest, HashAlgorithm: hashAlgorithm}, nil
+}
+
 // nolint
 func SignBlobCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, payloadPath, certPath, certChainPath string, b64 bool, outputSignature string, outputCertificate string, tlogUpload bool) ([]byte, error) {
-	var payload internal.HashReader
+	return signBlob(ctx, ro, ko, payloadPath, "", certPath, certChainPath, b64, outputSignature, outputCertificate, tlogUpload)
+}
+
+// SignBlobDigestCmd signs an artifact that was hashed elsewhere, given its
+// digest as <algorithm>:<hex>. The algorithm must match the hash used by the
+// signing algorithm. Outputs are the same as SignBlobCmd.
+// nolint
+func SignBlobDigestCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, digest, certPath, certChainPath string, b64 bool, outputSignature string, outputCertificate string, tlogUpload bool) ([]byte, error) {
+	return signBlob(ctx, ro, ko, "", digest, certPath, certChainPath, b64, outputSignature, outputCertificate, tlogUpload)
+}
 
+// nolint
+func signBlob(ctx context.Context, ro *options.RootOptions, ko options.KeyOpts, payloadPath, digest, certPath, certChainPath string, b64 bool, outputSignature string, outputCertificate string, tlogUpload bool) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(ctx, ro.Timeout)
 	defer cancel()
 
@@ -92,12 +179,6 @@ func SignBlobCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpt
 	}
 
 	hashFunction := signcommon.ProtoHashAlgoToHash(keypair.GetHashAlgorithm())
-	payload, closePayload, err := getPayload(ctx, payloadPath, hashFunction)
-	if err != nil {
-		return nil, fmt.Errorf("getting payload: %w", err)
-	}
-	defer closePayload()
-
 	if hashFunction != crypto.SHA256 && !ko.NewBundleFormat && (shouldUpload || (!ko.Sk && ko.KeyRef == "")) {
 		ui.Infof(ctx, "Non SHA256 hash function is not supported for old bundle format. Use --new-bundle-format to use the new bundle format or use different signing key/algorithm.")
 		if !ko.SkipConfirmation {
@@ -108,12 +189,14 @@ func SignBlobCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpt
 		ui.Infof(ctx, "Continuing with non SHA256 hash function and old bundle format")
 	}
 
-	data, err := io.ReadAll(&payload)
-	if err != nil {
-		return nil, fmt.Errorf("reading payload: %w", err)
+	var content sign.Content
+	if digest != "" {
+		content, err = getDigestContent(digest, keypair)
+	} else {
+		content, err = getContent(ctx, payloadPath, keypair)
 	}
-	content := &sign.PlainData{
-		Data: data,
+	if err != nil {
+		return nil, err
 	}
 
 	var tsaClientTransport http.RoundTripper
@@ -211,3 +294,22 @@ func SignBlobCmd(ctx context.Context, ro *options.RootOptions, ko options.KeyOpt
 	}
 	return bundleComponents.Signature, nil
 }
+
+// WriteTreeManifest hashes every file below dir and writes the resulting
+// manifest to manifestPath, so that it can be signed with SignBlobCmd. The
+// manifest itself is not listed when manifestPath is inside dir.
+func WriteTreeManifest(ctx context.Context, dir, manifestPath string) error {
+	m, err := blob.HashTree(dir, manifestPath)
+	if err != nil {
+		return err
+	}
+	b, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling tree manifest: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, b, 0600); err != nil {
+		return fmt.Errorf("create tree manifest file: %w", err)
+	}
+	ui.Infof(ctx, "Wrote manifest of %d files in %s to %s", len(m.Files), dir, manifestPath)
+	return nil
+}
@@ -15,13 +15,19 @@
 package sign
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -30,7 +36,11 @@ import (
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/v3/internal/test"
 	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/sshagent"
+	sgbundle "github.com/sigstore/sigstore-go/pkg/bundle"
 	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"golang.org/x/crypto/ssh/agent"
 )
 
 func TestSignBlobCmd(t *testing.T) {
@@ -202,3 +212,162 @@ func TestSignBlobCmd_LegacyBundleNoCert(t *testing.T) {
 		t.Fatal("expected non-empty Base64Signature in legacy bundle")
 	}
 }
+
+func TestSignBlobDigestCmd(t *testing.T) {
+	td := t.TempDir()
+	bundlePath := filepath.Join(td, "bundle.sigstore.json")
+
+	keys, _ := cosign.GenerateKeyPair(nil)
+	keyRef := writeFile(t, td, string(keys.PrivateBytes), "key.pem")
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(keys.PublicBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := []byte("artifact built on another host")
+	digest := sha256.Sum256(blob)
+	digestRef := "sha256:" + hex.EncodeToString(digest[:])
+
+	rootOpts := &options.RootOptions{}
+	keyOpts := options.KeyOpts{KeyRef: keyRef, BundlePath: bundlePath, NewBundleFormat: true}
+	// An empty trusted root exercises verification of the signed digest
+	// against the signing key.
+	keyOpts.TrustedMaterial = &root.TrustedRoot{}
+
+	if _, err := SignBlobDigestCmd(t.Context(), rootOpts, keyOpts, digestRef, "", "", false, "", "", false); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	b, err := sgbundle.LoadJSONFromPath(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := b.GetMessageSignature()
+	if !bytes.Equal(msg.GetMessageDigest().GetDigest(), digest[:]) {
+		t.Errorf("bundle digest = %x, want %x", msg.GetMessageDigest().GetDigest(), digest)
+	}
+	if !ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), digest[:], msg.GetSignature()) {
+		t.Error("signature does not verify against digest")
+	}
+
+	// Signing the file itself must produce a bundle over the same digest.
+	blobPath := writeFile(t, td, string(blob), "blob")
+	if _, err := SignBlobCmd(t.Context(), rootOpts, keyOpts, blobPath, "", "", false, "", "", false); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	b, err = sgbundle.LoadJSONFromPath(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b.GetMessageSignature().GetMessageDigest().GetDigest(), digest[:]) {
+		t.Error("streamed blob digest does not match")
+	}
+
+	for _, bad := range []string{
+		"sha512:" + hex.EncodeToString(digest[:]),
+		"sha256:" + hex.EncodeToString(digest[:16]),
+		"md5:00",
+		"sha256:zz",
+		hex.EncodeToString(digest[:]),
+	} {
+		if _, err := SignBlobDigestCmd(t.Context(), rootOpts, keyOpts, bad, "", "", false, "", "", false); err == nil {
+			t.Errorf("expected error signing digest %q", bad)
+		}
+	}
+}
+
+// serveSSHAgent serves an ssh-agent holding keys, keyed by their comment, on
+// a unix socket and returns its path.
+func serveSSHAgent(t *testing.T, keys map[string]crypto.Signer) string {
+	t.Helper()
+	keyring := agent.NewKeyring()
+	for comment, k := range keys {
+		if err := keyring.Add(agent.AddedKey{PrivateKey: k, Comment: comment}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Unix socket paths are limited to about 100 bytes, which t.TempDir()
+	// can exceed.
+	dir, err := os.MkdirTemp("", "ssh-agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	sock := filepath.Join(dir, "agent.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+	return sock
+}
+
+func TestSignBlobCmdSSHAgent(t *testing.T) {
+	td := t.TempDir()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SSH_AUTH_SOCK", serveSSHAgent(t, map[string]crypto.Signer{"ec@host": ecKey, "ed@host": edKey}))
+
+	blob := []byte("signed by an ssh-agent key")
+	blobPath := writeFile(t, td, string(blob), "blob")
+	digest := sha256.Sum256(blob)
+	rootOpts := &options.RootOptions{}
+
+	for name, verify := range map[string]func(sig []byte) bool{
+		"ec@host": func(sig []byte) bool { return ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], sig) },
+		"ed@host": func(sig []byte) bool { return ed25519.Verify(edPub, blob, sig) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			bundlePath := filepath.Join(td, name+".sigstore.json")
+			keyOpts := options.KeyOpts{
+				KeyRef:          sshagent.ReferenceScheme + name,
+				BundlePath:      bundlePath,
+				NewBundleFormat: true,
+			}
+			if name == "ec@host" {
+				// An empty trusted root exercises verification of the
+				// signature against the signing key, which sigstore-go
+				// cannot do for pure Ed25519 message signatures.
+				keyOpts.TrustedMaterial = &root.TrustedRoot{}
+			}
+			if _, err := SignBlobCmd(t.Context(), rootOpts, keyOpts, blobPath, "", "", false, "", "", false); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			b, err := sgbundle.LoadJSONFromPath(bundlePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !verify(b.GetMessageSignature().GetSignature()) {
+				t.Error("signature does not verify against the agent key")
+			}
+		})
+	}
+
+	keyOpts := options.KeyOpts{
+		KeyRef:          sshagent.ReferenceScheme + "ec@host",
+		BundlePath:      filepath.Join(td, "digest.sigstore.json"),
+		NewBundleFormat: true,
+		TrustedMaterial: &root.TrustedRoot{},
+	}
+	digestRef := "sha256:" + hex.EncodeToString(digest[:])
+	if _, err := SignBlobDigestCmd(t.Context(), rootOpts, keyOpts, digestRef, "", "", false, "", "", false); err == nil {
+		t.Error("expected error signing a digest with an ssh-agent key")
+	}
+}
@@ -16,13 +16,26 @@
 package sign
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	intotov1 "github.com/in-toto/attestation/go/v1"
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	ociremote "github.com/sigstore/cosign/v3/pkg/oci/remote"
 	"github.com/sigstore/cosign/v3/pkg/types"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -49,6 +62,158 @@ func TestSignCmdLocalKeyAndSk(t *testing.T) {
 	}
 }
 
+// TestSignWithKeysCmd verifies that signing with several keys publishes all
+// of the signatures with a single write of the signature manifest.
+func TestSignWithKeysCmd(t *testing.T) {
+	reg := registry.New()
+	var sigManifestPuts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") && strings.HasSuffix(r.URL.Path, ".sig") {
+			sigManifestPuts.Add(1)
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ref, err := name.ParseReference(strings.TrimPrefix(srv.URL, "http://")+"/test/image:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := ref.Context().Digest(d.String())
+
+	td := t.TempDir()
+	var kos []options.KeyOpts
+	for _, n := range []string{"a.key", "b.key"} {
+		keys, err := cosign.GenerateKeyPair(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kos = append(kos, options.KeyOpts{KeyRef: writeFile(t, td, string(keys.PrivateBytes), n)})
+	}
+
+	ro := &options.RootOptions{Timeout: options.DefaultTimeout}
+	so := options.SignOptions{
+		Upload:   true,
+		Registry: options.RegistryOptions{AllowHTTPRegistry: true},
+	}
+	if err := SignWithKeysCmd(t.Context(), ro, kos, so, []string{digest.String()}); err != nil {
+		t.Fatalf("SignWithKeysCmd() error = %v", err)
+	}
+	if got := sigManifestPuts.Load(); got != 1 {
+		t.Errorf("signature manifest written %d times, want 1", got)
+	}
+
+	se, err := ociremote.SignedEntity(digest, ociremote.WithRemoteOptions(remote.WithContext(t.Context())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigs, err := se.Signatures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := sigs.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("got %d signatures, want 2", len(got))
+	}
+
+	// Every signer's public key is written to --output-certificate.
+	so.Upload = false
+	so.OutputCertificate = filepath.Join(td, "out.pem")
+	if err := SignWithKeysCmd(t.Context(), ro, kos, so, []string{digest.String()}); err != nil {
+		t.Fatalf("SignWithKeysCmd() error = %v", err)
+	}
+	out, err := os.ReadFile(so.OutputCertificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(out, []byte("-----BEGIN PUBLIC KEY-----")); n != 2 {
+		t.Errorf("--output-certificate holds %d keys, want 2", n)
+	}
+
+	// A certificate belongs to a single key.
+	so.Cert = writeFile(t, td, "", "cert.pem")
+	if err := SignWithKeysCmd(t.Context(), ro, kos, so, []string{digest.String()}); err == nil || !strings.Contains(err.Error(), "single --key") {
+		t.Errorf("SignWithKeysCmd() with --certificate and two keys error = %v", err)
+	}
+}
+
+// TestSignWithKeysCmdNewBundleFormat verifies that the bundles of several
+// signers are published together in a single referrer manifest.
+func TestSignWithKeysCmdNewBundleFormat(t *testing.T) {
+	reg := registry.New(registry.WithReferrersSupport(true))
+	var manifestPuts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") {
+			manifestPuts.Add(1)
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ref, err := name.ParseReference(strings.TrimPrefix(srv.URL, "http://")+"/test/image:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := ref.Context().Digest(d.String())
+	manifestPuts.Store(0)
+
+	td := t.TempDir()
+	var kos []options.KeyOpts
+	for _, n := range []string{"a.key", "b.key"} {
+		keys, err := cosign.GenerateKeyPair(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kos = append(kos, options.KeyOpts{KeyRef: writeFile(t, td, string(keys.PrivateBytes), n), NewBundleFormat: true})
+	}
+
+	ro := &options.RootOptions{Timeout: options.DefaultTimeout}
+	so := options.SignOptions{
+		Upload:          true,
+		NewBundleFormat: true,
+		Registry:        options.RegistryOptions{AllowHTTPRegistry: true},
+	}
+	if err := SignWithKeysCmd(t.Context(), ro, kos, so, []string{digest.String()}); err != nil {
+		t.Fatalf("SignWithKeysCmd() error = %v", err)
+	}
+	if got := manifestPuts.Load(); got != 1 {
+		t.Errorf("referrer manifests written %d times, want 1", got)
+	}
+
+	bundles, _, err := cosign.GetBundles(t.Context(), digest, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundles) != 2 {
+		t.Errorf("got %d bundles, want 2", len(bundles))
+	}
+}
+
 func TestInTotoStatementHasPredicate(t *testing.T) {
 	annoStruct, _ := structpb.NewStruct(map[string]any{})
 	subject := intotov1.ResourceDescriptor{
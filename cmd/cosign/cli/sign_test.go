@@ -0,0 +1,103 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+)
+
+func TestSignerKeyOpts(t *testing.T) {
+	tests := []struct {
+		name    string
+		sk      bool
+		keys    []string
+		keyless bool
+		// want describes each signer: its key reference, "sk" or "keyless".
+		want    []string
+		wantErr bool
+	}{
+		{name: "keyless by default", want: []string{"keyless"}},
+		{name: "keys", keys: []string{"a.key", "b.key"}, want: []string{"a.key", "b.key"}},
+		{name: "keys and keyless", keys: []string{"a.key"}, keyless: true, want: []string{"a.key", "keyless"}},
+		{name: "sk", sk: true, want: []string{"sk"}},
+		{name: "sk and keyless", sk: true, keyless: true, want: []string{"sk", "keyless"}},
+		{name: "key and sk", sk: true, keys: []string{"a.key"}, want: []string{"a.key", "sk"}},
+		{name: "repeated key", keys: []string{"a.key", "a.key"}, wantErr: true},
+		{name: "empty key", keys: []string{""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kos, err := signerKeyOpts(options.KeyOpts{Sk: tt.sk, Slot: "signature"}, tt.keys, tt.keyless)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("signerKeyOpts() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("signerKeyOpts() error = %v", err)
+			}
+			var got []string
+			for _, ko := range kos {
+				switch {
+				case ko.KeyRef != "" && !ko.Sk:
+					got = append(got, ko.KeyRef)
+				case ko.Sk && ko.KeyRef == "":
+					got = append(got, "sk")
+				case !ko.Sk && ko.KeyRef == "" && ko.Slot == "":
+					got = append(got, "keyless")
+				default:
+					t.Fatalf("signer %+v mixes signing methods", ko)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("signerKeyOpts() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("signerKeyOpts() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckSigners(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       options.SignOptions
+		wantErr bool
+	}{
+		{name: "bundle with a key", o: options.SignOptions{Key: "a.key", BundlePath: "out.json"}},
+		{name: "bundle keyless", o: options.SignOptions{Keyless: true, BundlePath: "out.json"}},
+		{name: "bundle keyless by default", o: options.SignOptions{BundlePath: "out.json"}},
+		{name: "bundle with two keys", o: options.SignOptions{Key: "a.key", Keys: []string{"b.key"}, BundlePath: "out.json"}, wantErr: true},
+		{name: "bundle with a key and keyless", o: options.SignOptions{Key: "a.key", Keyless: true, BundlePath: "out.json"}, wantErr: true},
+		{name: "bundle with a key and sk", o: options.SignOptions{Key: "a.key", SecurityKey: options.SecurityKeyOptions{Use: true}, BundlePath: "out.json"}, wantErr: true},
+		{name: "two keys without bundle", o: options.SignOptions{Key: "a.key", Keys: []string{"b.key"}, Keyless: true}},
+		{name: "hardware attestation with a key", o: options.SignOptions{Key: "a.key", HardwareAttestation: "att.pem"}},
+		{name: "hardware attestation keyless", o: options.SignOptions{HardwareAttestation: "att.pem"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkSigners(&tt.o); (err != nil) != tt.wantErr {
+				t.Errorf("checkSigners() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
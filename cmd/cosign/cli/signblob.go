@@ -41,9 +41,15 @@ func SignBlob() *cobra.Command {
   # sign a blob with a local key pair file
   cosign sign-blob --key cosign.key <FILE>
 
+  # sign a blob with a key whose password is split into shares, 2 of which are needed
+  cosign sign-blob --key root.key --password-share root.share-1 --password-share root.share-3 <FILE>
+
   # sign a blob with a key stored in an environment variable
   cosign sign-blob --key env://[ENV_VAR] <FILE>
 
+  # sign a blob with a key held by ssh-agent, selected by its fingerprint or comment
+  cosign sign-blob --key ssh-agent://[FINGERPRINT] --bundle artifact.sigstore.json <FILE>
+
   # sign a blob with a key pair stored in Azure Key Vault
   cosign sign-blob --key azurekms://[VAULT_NAME][VAULT_URI]/[KEY] <FILE>
 
@@ -54,10 +60,19 @@ func SignBlob() *cobra.Command {
   cosign sign-blob --key gcpkms://projects/[PROJECT]/locations/global/keyRings/[KEYRING]/cryptoKeys/[KEY] <FILE>
 
   # sign a blob with a key pair stored in Hashicorp Vault
-  cosign sign-blob --key hashivault://[KEY] <FILE>`,
-		Args:             cobra.MinimumNArgs(1),
+  cosign sign-blob --key hashivault://[KEY] <FILE>
+
+  # sign a large artifact streamed from stdin
+  cat <FILE> | cosign sign-blob --key cosign.key --bundle artifact.sigstore.json -
+
+  # sign the digest of an artifact built on another host
+  cosign sign-blob --key cosign.key --bundle artifact.sigstore.json --digest sha256:[HEX DIGEST]
+
+  # sign every file in a directory by signing a manifest of their digests
+  cosign sign-blob --key cosign.key --tree --tree-manifest manifest.json --bundle manifest.sigstore.json <DIRECTORY>`,
+		Args:             cobra.ArbitraryArgs,
 		PersistentPreRun: options.BindViper,
-		PreRunE: func(_ *cobra.Command, _ []string) error {
+		PreRunE: func(_ *cobra.Command, args []string) error {
 			if options.NOf(o.Key, o.SecurityKey.Use) > 1 {
 				return &options.KeyParseError{}
 			}
@@ -66,6 +81,17 @@ func SignBlob() *cobra.Command {
 				return fmt.Errorf("must specify --bundle with --new-bundle-format")
 			}
 
+			if o.Digest != "" && len(args) > 0 {
+				return fmt.Errorf("--digest cannot be used with a blob argument")
+			}
+			if o.Digest == "" && len(args) == 0 {
+				return fmt.Errorf("requires at least 1 blob argument or --digest")
+			}
+
+			if o.Tree && o.TreeManifest == "" {
+				return fmt.Errorf("must specify --tree-manifest with --tree")
+			}
+
 			// Check if the algorithm is in the list of supported algorithms
 			supportedAlgorithms := cosign.GetSupportedAlgorithms()
 			isValid := false
@@ -90,7 +116,7 @@ func SignBlob() *cobra.Command {
 
 			ko := options.KeyOpts{
 				KeyRef:                         o.Key,
-				PassFunc:                       generate.GetPass,
+				PassFunc:                       generate.PassFunc(o.PasswordShare.Files, o.PasswordShare.Prompt),
 				Sk:                             o.SecurityKey.Use,
 				Slot:                           o.SecurityKey.Slot,
 				FulcioURL:                      o.Fulcio.URL,
@@ -122,7 +148,25 @@ func SignBlob() *cobra.Command {
 				return err
 			}
 
-			for _, blob := range args {
+			if o.Digest != "" {
+				if _, err := sign.SignBlobDigestCmd(cmd.Context(), ro, ko, o.Digest, o.Cert, o.CertChain, o.Base64Output, o.OutputSignature, o.OutputCertificate, o.TlogUpload); err != nil {
+					return fmt.Errorf("signing %s: %w", o.Digest, err)
+				}
+				return nil
+			}
+
+			blobs := args
+			if o.Tree {
+				if len(args) != 1 {
+					return fmt.Errorf("--tree accepts exactly one directory")
+				}
+				if err := sign.WriteTreeManifest(cmd.Context(), args[0], o.TreeManifest); err != nil {
+					return err
+				}
+				blobs = []string{o.TreeManifest}
+			}
+
+			for _, blob := range blobs {
 				// TODO: remove when the output flag has been deprecated
 				if o.Output != "" {
 					fmt.Fprintln(os.Stderr, "WARNING: the '--output' flag is deprecated and will be removed in the future. Use '--output-signature'")
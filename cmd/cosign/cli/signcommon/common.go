@@ -73,6 +73,14 @@ func (c *SignerVerifier) Close() {
 	}
 }
 
+// SignsDigest reports whether the signer can sign a precomputed digest. It
+// cannot if the wrapped signer, like an ssh-agent key, has to be given the
+// message itself.
+func (c *SignerVerifier) SignsDigest() bool {
+	d, ok := c.SignerVerifier.(interface{ SignsDigest() bool })
+	return !ok || d.SignsDigest()
+}
+
 // GetKeypairAndToken creates a keypair object from provided key or cert flags or generates an ephemeral key.
 // For an ephemeral key, it also uses the key to fetch an OIDC token, the pair of which are later used to get a Fulcio cert.
 //
@@ -407,6 +415,16 @@ type CommonBundleOpts struct {
 
 // NewAttestationBundle uses signing config and trusted root to sign an attestation and create a bundle.
 func NewAttestationBundle(ctx context.Context, ko options.KeyOpts, cert, certChain string, bundleOpts CommonBundleOpts, signingConfig *root.SigningConfig, trustedMaterial root.TrustedMaterial) ([]byte, crypto.PublicKey, pb_go_v1.HashAlgorithm, error) {
+	bundles, pubKey, hashAlg, err := NewAttestationBundles(ctx, ko, cert, certChain, [][]byte{bundleOpts.Payload}, signingConfig, trustedMaterial)
+	if err != nil {
+		return nil, nil, pb_go_v1.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED, err
+	}
+	return bundles[0], pubKey, hashAlg, nil
+}
+
+// NewAttestationBundles signs several attestations with the same key, and
+// the same certificate when signing keyless, and creates a bundle for each.
+func NewAttestationBundles(ctx context.Context, ko options.KeyOpts, cert, certChain string, payloads [][]byte, signingConfig *root.SigningConfig, trustedMaterial root.TrustedMaterial) ([][]byte, crypto.PublicKey, pb_go_v1.HashAlgorithm, error) {
 	keypair, certBytes, chainBytes, idToken, err := GetKeypairAndToken(ctx, ko, cert, certChain)
 	if err != nil {
 		return nil, nil, pb_go_v1.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED, fmt.Errorf("getting keypair and token: %w", err)
@@ -415,11 +433,6 @@ func NewAttestationBundle(ctx context.Context, ko options.KeyOpts, cert, certCha
 		defer closer.Close()
 	}
 
-	content := &sign.DSSEData{
-		Data:        bundleOpts.Payload,
-		PayloadType: "application/vnd.in-toto+json",
-	}
-
 	var tsaClientTransport http.RoundTripper
 	if ko.TSAClientCACert != "" || (ko.TSAClientCert != "" && ko.TSAClientKey != "") {
 		tsaClientTransport, err = client.GetHTTPTransport(ko.TSAClientCACert, ko.TSAClientCert, ko.TSAClientKey, ko.TSAServerName, 30*time.Second)
@@ -428,13 +441,27 @@ func NewAttestationBundle(ctx context.Context, ko options.KeyOpts, cert, certCha
 		}
 	}
 	signOpts := cbundle.SignOptions{TSAClientTransport: tsaClientTransport}
+	if idToken != "" && len(payloads) > 1 {
+		signOpts.CertificateProvider, err = cbundle.NewCachingFulcioProvider(signingConfig)
+		if err != nil {
+			return nil, nil, pb_go_v1.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED, fmt.Errorf("creating caching Fulcio provider: %w", err)
+		}
+	}
 
-	bundle, err := cbundle.SignData(ctx, content, keypair, idToken, certBytes, chainBytes, signingConfig, trustedMaterial, signOpts)
-	if err != nil {
-		return nil, nil, pb_go_v1.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED, fmt.Errorf("signing bundle: %w", err)
+	bundles := make([][]byte, 0, len(payloads))
+	for _, payload := range payloads {
+		content := &sign.DSSEData{
+			Data:        payload,
+			PayloadType: "application/vnd.in-toto+json",
+		}
+		bundle, err := cbundle.SignData(ctx, content, keypair, idToken, certBytes, chainBytes, signingConfig, trustedMaterial, signOpts)
+		if err != nil {
+			return nil, nil, pb_go_v1.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED, fmt.Errorf("signing bundle: %w", err)
+		}
+		bundles = append(bundles, bundle)
 	}
 
-	return bundle, keypair.GetPublicKey(), keypair.GetHashAlgorithm(), nil
+	return bundles, keypair.GetPublicKey(), keypair.GetHashAlgorithm(), nil
 }
 
 type BundleComponents struct {
@@ -0,0 +1,99 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signcommon
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/pivkey"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// HardwareAttestation returns the PEM encoded hardware attestation chain of
+// the signing key of ko, or nil if there is none. The chain is read from
+// ko.HardwareAttestationPath when set, and otherwise fetched from the PIV
+// token when signing with a security key.
+func HardwareAttestation(ctx context.Context, ko options.KeyOpts) ([]byte, error) {
+	switch {
+	case ko.HardwareAttestationPath != "":
+		chain, err := os.ReadFile(filepath.Clean(ko.HardwareAttestationPath))
+		if err != nil {
+			return nil, fmt.Errorf("reading hardware attestation: %w", err)
+		}
+		if _, err := cosign.ParseHardwareAttestation(chain); err != nil {
+			return nil, err
+		}
+		return chain, nil
+	case ko.Sk:
+		chain, err := pivAttestation(ko.Slot)
+		if err != nil {
+			// Keys imported onto the token cannot be attested.
+			ui.Warnf(ctx, "no hardware attestation retrieved from the PIV token: %v", err)
+			return nil, nil
+		}
+		return chain, nil
+	}
+	return nil, nil
+}
+
+// CheckHardwareAttestation checks that the PEM encoded hardware attestation
+// chain attests pub, so that a mismatched chain is caught when signing
+// rather than when verifying.
+func CheckHardwareAttestation(chainPEM []byte, pub crypto.PublicKey) error {
+	chain, err := cosign.ParseHardwareAttestation(chainPEM)
+	if err != nil {
+		return err
+	}
+	if err := cryptoutils.EqualKeys(chain[0].PublicKey, pub); err != nil {
+		return errors.New("hardware attestation does not attest the signing key")
+	}
+	return nil
+}
+
+// pivAttestation returns the attestation of the key in slot, followed by the
+// attestation certificate of the token that signed it.
+func pivAttestation(slot string) ([]byte, error) {
+	sk, err := pivkey.GetKeyWithSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	defer sk.Close()
+	leaf, err := sk.Attest()
+	if err != nil {
+		return nil, fmt.Errorf("attesting key: %w", err)
+	}
+	intermediate, err := sk.GetAttestationCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("getting attestation certificate: %w", err)
+	}
+	leafPEM, err := cryptoutils.MarshalCertificateToPEM(leaf)
+	if err != nil {
+		return nil, err
+	}
+	intermediatePEM, err := cryptoutils.MarshalCertificateToPEM(intermediate)
+	if err != nil {
+		return nil, err
+	}
+	return append(leafPEM, intermediatePEM...), nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signcommon
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/internal/test"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+func TestHardwareAttestation(t *testing.T) {
+	root, rootKey, err := test.GenerateRootCa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, key, err := test.GenerateLeafCert("piv", "", root, rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainPEM, err := cryptoutils.MarshalCertificateToPEM(leaf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "attestation.pem")
+	if err := os.WriteFile(path, chainPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := HardwareAttestation(context.Background(), options.KeyOpts{HardwareAttestationPath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, chainPEM) {
+		t.Errorf("HardwareAttestation() = %q, want %q", got, chainPEM)
+	}
+	if err := CheckHardwareAttestation(got, key.Public()); err != nil {
+		t.Errorf("CheckHardwareAttestation() error = %v", err)
+	}
+	if err := CheckHardwareAttestation(got, rootKey.Public()); err == nil {
+		t.Error("CheckHardwareAttestation() of another key should fail")
+	}
+
+	if got, err := HardwareAttestation(context.Background(), options.KeyOpts{KeyRef: "cosign.key"}); err != nil || got != nil {
+		t.Errorf("HardwareAttestation() without a token or file = %q, %v, want nil", got, err)
+	}
+	if err := os.WriteFile(path, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := HardwareAttestation(context.Background(), options.KeyOpts{HardwareAttestationPath: path}); err == nil {
+		t.Error("HardwareAttestation() of a file without certificates should fail")
+	}
+}
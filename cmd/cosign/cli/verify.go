@@ -53,12 +53,21 @@ against the transparency log.`,
   # verify image with an on-disk signed image from 'cosign save'
   cosign verify --key cosign.pub --local-image <PATH>
 
+  # verify image with any key of a key set maintained by 'cosign key rotate'
+  cosign verify --keyset cosign.keyset.json <IMAGE>
+
+  # verify image with a public key, requiring the key to be attested by a YubiKey
+  cosign verify --key cosign.pub --require-hardware-key --hardware-attestation-roots yubico-piv-ca.pem <IMAGE>
+
   # verify image with a trusted root
   cosign verify --trusted-root trusted_root.json <IMAGE>
 
   # verify image with public key provided by URL
   cosign verify --key https://host.for/[FILE] <IMAGE>
 
+  # verify image with any of the OpenSSH keys a GitHub user publishes
+  cosign verify --key https://github.com/[USER].keys <IMAGE>
+
   # verify image with a key stored in an environment variable
   cosign verify --key env://[ENV_VAR] <IMAGE>
 
@@ -71,11 +80,20 @@ against the transparency log.`,
   # verify image with public key stored in a Kubernetes secret
   cosign verify --key k8s://[NAMESPACE]/[KEY] <IMAGE>
 
+  # verify image with a public key stored in a Kubernetes ConfigMap
+  cosign verify --key 'k8s://[NAMESPACE]/[CONFIGMAP]?kind=configmap&pub=[KEY]' <IMAGE>
+
+  # verify image with public key provided by a signer plugin
+  cosign verify --key plugin://[NAME]/[KEY] <IMAGE>
+
   # verify image with public key stored in GitLab with project name
   cosign verify --key gitlab://[OWNER]/[PROJECT_NAME] <IMAGE>
 
   # verify image with public key stored in GitLab with project id
-  cosign verify --key gitlab://[PROJECT_ID] <IMAGE>`,
+  cosign verify --key gitlab://[PROJECT_ID] <IMAGE>
+
+  # verify image and record the decision in a Verification Summary Attestation signed with a key
+  cosign verify --certificate-identity foo@example.com --certificate-oidc-issuer https://accounts.google.com --emit-vsa vsa.sigstore.json --vsa-key vsa.key <IMAGE>`,
 
 		Args:             cobra.MinimumNArgs(1),
 		PersistentPreRun: options.BindViper,
@@ -97,9 +115,12 @@ against the transparency log.`,
 			v := &verify.VerifyCommand{
 				RegistryOptions:              o.Registry,
 				CertVerifyOptions:            o.CertVerify,
+				RevocationOptions:            o.Revocation,
+				HardwareAttestationOptions:   o.HardwareAttestation,
 				CommonVerifyOptions:          o.CommonVerifyOptions,
 				CheckClaims:                  o.CheckClaims,
 				KeyRef:                       o.Key,
+				KeySetRef:                    o.KeySet,
 				CertRef:                      o.CertVerify.Cert,
 				CertChain:                    o.CertVerify.CertChain,
 				CAIntermediates:              o.CertVerify.CAIntermediates,
@@ -129,6 +150,7 @@ against the transparency log.`,
 				UseSignedTimestamps:          o.CommonVerifyOptions.UseSignedTimestamps,
 				NewBundleFormat:              o.CommonVerifyOptions.NewBundleFormat,
 				AllowCertificateChain:        o.CommonVerifyOptions.AllowCertificateChain,
+				VSA:                          o.VSA,
 			}
 
 			if o.CommonVerifyOptions.MaxWorkers == 0 {
@@ -179,6 +201,9 @@ against the transparency log.`,
   # verify image attestations with an on-disk signed image from 'cosign save'
   cosign verify-attestation --key cosign.pub --local-image <PATH>
 
+  # verify image attestations with any key of a key set maintained by 'cosign key rotate'
+  cosign verify-attestation --keyset cosign.keyset.json <IMAGE>
+
   # verify image with public key provided by URL
   cosign verify-attestation --key https://host.for/<FILE> <IMAGE>
 
@@ -198,11 +223,29 @@ against the transparency log.`,
   cosign verify-attestation --key cosign.pub --type <PREDICATE_TYPE> --policy <REGO_POLICY> <IMAGE>
 
   # verify image with public key and validate attestation based on CUE policy
-  cosign verify-attestation --key cosign.pub --type <PREDICATE_TYPE> --policy <CUE_POLICY> <IMAGE>`,
+  cosign verify-attestation --key cosign.pub --type <PREDICATE_TYPE> --policy <CUE_POLICY> <IMAGE>
+
+  # verify provenance against a policy and attach a signed Verification Summary Attestation to the image
+  cosign verify-attestation --key cosign.pub --type slsaprovenance1 --policy <REGO_POLICY> --vsa-key vsa.key --vsa-attach <IMAGE>
+
+  # verify that the latest vulnerability scan is at most a week old and has no unsuppressed critical findings
+  cosign verify-attestation --key cosign.pub --type vuln --max-age 7d --max-severity high --vex vex.json <IMAGE>
+
+  # print the vulnerabilities of all scans that no OpenVEX attestation suppresses, checked by a policy
+  cosign verify-attestation --key cosign.pub --type vuln --residual --policy <CUE_POLICY> <IMAGE>
+
+  # verify provenance and SBOM attestations during a migration, whether attached as bundles or to the legacy .att tag
+  cosign verify-attestation --key cosign.pub --type slsaprovenance1 --merge-attestation-formats --require-predicate-type spdxjson <IMAGE>
+
+  # verify only the provenance from a given builder and print its build type
+  cosign verify-attestation --key cosign.pub --type slsaprovenance1 --filter '@.predicate.runDetails.builder.id=="<BUILDER_ID>"' --jsonpath '{.predicate.buildDefinition.buildType}' <IMAGE>`,
 
 		Args:             cobra.MinimumNArgs(1),
 		PersistentPreRun: options.BindViper,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Predicate.RegisterSchemas(); err != nil {
+				return err
+			}
 			if o.CommonVerifyOptions.PrivateInfrastructure {
 				o.CommonVerifyOptions.IgnoreTlog = true
 			}
@@ -217,6 +260,7 @@ against the transparency log.`,
 				CommonVerifyOptions:          o.CommonVerifyOptions,
 				CheckClaims:                  o.CheckClaims,
 				CertVerifyOptions:            o.CertVerify,
+				RevocationOptions:            o.Revocation,
 				CertRef:                      o.CertVerify.Cert,
 				CertChain:                    o.CertVerify.CertChain,
 				CAIntermediates:              o.CertVerify.CAIntermediates,
@@ -229,12 +273,14 @@ against the transparency log.`,
 				IgnoreSCT:                    o.CertVerify.IgnoreSCT,
 				SCTRef:                       o.CertVerify.SCT,
 				KeyRef:                       o.Key,
+				KeySetRef:                    o.KeySet,
 				Sk:                           o.SecurityKey.Use,
 				Slot:                         o.SecurityKey.Slot,
 				Output:                       o.Output,
 				RekorURL:                     o.Rekor.URL,
 				PredicateType:                o.Predicate.Type,
 				Policies:                     o.Policies,
+				Convert:                      o.Convert,
 				LocalImage:                   o.LocalImage,
 				NameOptions:                  o.Registry.NameOptions(),
 				Offline:                      o.CommonVerifyOptions.Offline,
@@ -243,6 +289,11 @@ against the transparency log.`,
 				MaxWorkers:                   o.CommonVerifyOptions.MaxWorkers,
 				HashAlgorithm:                hashAlgorithm,
 				UseSignedTimestamps:          o.CommonVerifyOptions.UseSignedTimestamps,
+				VSA:                          o.VSA,
+				Query:                        o.Query,
+				Vuln:                         o.Vuln,
+				MergeAttestationFormats:      o.MergeAttestationFormats,
+				RequirePredicateTypes:        o.RequirePredicateTypes,
 			}
 
 			if o.CommonVerifyOptions.MaxWorkers == 0 {
@@ -288,6 +339,9 @@ The blob may be specified as a path to a file or - for stdin.`,
   # Verify a blob with an on-disk public key
   cosign verify-blob --bundle artifact.sigstore.json --key cosign.pub <blob>
 
+  # Verify a blob with any key of a key set maintained by 'cosign key rotate'
+  cosign verify-blob --bundle artifact.sigstore.json --keyset cosign.keyset.json <blob>
+
   # Verify a blob against Azure Key Vault
   cosign verify-blob --bundle artifact.sigstore.json --key azurekms://[VAULT_NAME][VAULT_URI]/[KEY] <blob>
 
@@ -305,15 +359,32 @@ The blob may be specified as a path to a file or - for stdin.`,
 
   # Verify a blob against GitLab with project id
   cosign verify-blob --bundle artifact.sigstore.json --key gitlab://[PROJECT_ID] <blob>
+
+  # Verify the digest of an artifact without downloading it
+  cosign verify-blob --bundle artifact.sigstore.json --key cosign.pub --digest sha256:[HEX DIGEST]
+
+  # Verify a directory against a manifest signed with sign-blob --tree
+  cosign verify-blob --bundle manifest.sigstore.json --key cosign.pub --tree --tree-manifest manifest.json <DIRECTORY>
 `,
 
-		Args:             cobra.ExactArgs(1),
+		Args:             cobra.MaximumNArgs(1),
 		PersistentPreRun: options.BindViper,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if o.CommonVerifyOptions.PrivateInfrastructure {
 				o.CommonVerifyOptions.IgnoreTlog = true
 			}
 
+			if (o.Digest != "") == (len(args) == 1) {
+				return fmt.Errorf("requires exactly one of a blob argument or --digest")
+			}
+			if o.Digest != "" && (o.BundlePath == "" || !o.CommonVerifyOptions.NewBundleFormat) {
+				return fmt.Errorf("--digest requires --bundle in the new bundle format")
+			}
+			blobRef := o.Digest
+			if len(args) == 1 {
+				blobRef = args[0]
+			}
+
 			hashAlgorithm, err := o.SignatureDigest.HashAlgorithm()
 			if err != nil {
 				return err
@@ -331,7 +402,9 @@ The blob may be specified as a path to a file or - for stdin.`,
 			}
 			verifyBlobCmd := &verify.VerifyBlobCmd{
 				KeyOpts:                      ko,
+				KeySetRef:                    o.KeySet,
 				CertVerifyOptions:            o.CertVerify,
+				RevocationOptions:            o.Revocation,
 				CertRef:                      o.CertVerify.Cert,
 				CertChain:                    o.CertVerify.CertChain,
 				CARoots:                      o.CertVerify.CARoots,
@@ -359,7 +432,13 @@ The blob may be specified as a path to a file or - for stdin.`,
 				ui.Warnf(ctx, ignoreTLogMessage, "blob")
 			}
 
-			return verifyBlobCmd.Exec(ctx, args[0])
+			if o.Tree {
+				if o.TreeManifest == "" {
+					return fmt.Errorf("must specify --tree-manifest with --tree")
+				}
+				return verifyBlobCmd.ExecTree(ctx, blobRef, o.TreeManifest)
+			}
+			return verifyBlobCmd.Exec(ctx, blobRef)
 		},
 	}
 
@@ -377,7 +456,8 @@ func VerifyBlobAttestation() *cobra.Command {
 You may specify either a key or a kms reference to verify against.
 
 Signed material is provided with the --bundle flag.
-The blob may be specified as a path to a file.`,
+The blob may be specified as a path to a file. When the attestation has
+several subjects, e.g. all files of a release, the blob must match one of them.`,
 		Example: ` cosign verify-blob-attestation --bundle <path> --certificate-identity <identity> --certificate-oidc-issuer <issuer> <blob>
 
   # Verify a blob attestation (keyless)
@@ -386,6 +466,9 @@ The blob may be specified as a path to a file.`,
   # Verify a blob attestation with a public key
   cosign verify-blob-attestation --bundle artifact.sigstore.json --key cosign.pub <blob>
 
+  # Verify a blob attestation with any key of a key set maintained by 'cosign key rotate'
+  cosign verify-blob-attestation --bundle artifact.sigstore.json --keyset cosign.keyset.json <blob>
+
   # Verify a blob attestation with Azure KMS
   cosign verify-blob-attestation --bundle artifact.sigstore.json --key azurekms://[VAULT_NAME][VAULT_URI]/[KEY] <blob>
 
@@ -398,11 +481,17 @@ The blob may be specified as a path to a file.`,
   # Verify a blob attestation with Hashicorp Vault
   cosign verify-blob-attestation --bundle artifact.sigstore.json --key hashivault://[KEY] <blob>
 
+  # Verify that a file is one of the subjects of a release attestation
+  cosign verify-blob-attestation --bundle release.sigstore.json --key cosign.pub dist/app-linux-amd64.tar.gz
+
 `,
 
 		Args:             cobra.MaximumNArgs(1),
 		PersistentPreRun: options.BindViper,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.RegisterSchemas(); err != nil {
+				return err
+			}
 			if o.CommonVerifyOptions.PrivateInfrastructure {
 				o.CommonVerifyOptions.IgnoreTlog = true
 			}
@@ -424,10 +513,12 @@ The blob may be specified as a path to a file.`,
 			}
 			v := verify.VerifyBlobAttestationCommand{
 				KeyOpts:                      ko,
+				KeySetRef:                    o.KeySet,
 				PredicateType:                o.Type,
 				CheckClaims:                  o.CheckClaims,
 				SignaturePath:                o.SignaturePath,
 				CertVerifyOptions:            o.CertVerify,
+				RevocationOptions:            o.Revocation,
 				CertRef:                      o.CertVerify.Cert,
 				CertChain:                    o.CertVerify.CertChain,
 				CARoots:                      o.CertVerify.CARoots,
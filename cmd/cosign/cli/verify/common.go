@@ -199,6 +199,62 @@ func SetTrustedMaterial(ctx context.Context, trustedRootPath, certChain, caRoots
 	return nil
 }
 
+// SetRevocationList loads the revocation list given by the revocation
+// options into co, verifying it with the revocation list key.
+func SetRevocationList(ctx context.Context, o options.RevocationOptions, co *cosign.CheckOpts) error {
+	if o.RevocationList == "" && o.RevocationListKey == "" {
+		return nil
+	}
+	if o.RevocationList == "" || o.RevocationListKey == "" {
+		return fmt.Errorf("--revocation-list and --revocation-list-key must be provided together")
+	}
+	verifier, err := csignature.PublicKeyFromKeyRef(ctx, o.RevocationListKey)
+	if err != nil {
+		return fmt.Errorf("loading revocation list key: %w", err)
+	}
+	co.RevocationList, err = cosign.LoadRevocationList(o.RevocationList, verifier)
+	if err != nil {
+		return fmt.Errorf("loading revocation list: %w", err)
+	}
+	return nil
+}
+
+// SetKeySet loads the key set written by 'cosign key rotate' at path into
+// co. A key set is trusted like a key, except that signatures are only
+// accepted within the validity window of the key that made them.
+func SetKeySet(path string, co *cosign.CheckOpts) error {
+	if path == "" {
+		return nil
+	}
+	ks, err := cosign.LoadKeySet(path)
+	if err != nil {
+		return fmt.Errorf("loading key set: %w", err)
+	}
+	co.KeySet = ks
+	return nil
+}
+
+// SetHardwareAttestationRoots loads the vendor roots given by the hardware
+// attestation options into co, requiring signatures to carry a hardware
+// attestation of their signing key.
+func SetHardwareAttestationRoots(o options.HardwareAttestationOptions, co *cosign.CheckOpts) error {
+	if !o.RequireHardwareKey && o.HardwareAttestationRoots == "" {
+		return nil
+	}
+	if !o.RequireHardwareKey || o.HardwareAttestationRoots == "" {
+		return fmt.Errorf("--require-hardware-key and --hardware-attestation-roots must be provided together")
+	}
+	roots, err := loadCertChainFromFileOrURL(o.HardwareAttestationRoots)
+	if err != nil {
+		return fmt.Errorf("loading hardware attestation roots: %w", err)
+	}
+	co.HardwareAttestationRoots = x509.NewCertPool()
+	for _, root := range roots {
+		co.HardwareAttestationRoots.AddCert(root)
+	}
+	return nil
+}
+
 // PrintVerificationHeader prints boilerplate information after successful verification.
 func PrintVerificationHeader(ctx context.Context, imgRef string, co *cosign.CheckOpts, bundleVerified, fulcioVerified bool) {
 	ui.Infof(ctx, "\nVerification for %s --", imgRef)
@@ -218,6 +274,15 @@ func PrintVerificationHeader(ctx context.Context, imgRef string, co *cosign.Chec
 	if co.SigVerifier != nil {
 		ui.Infof(ctx, "  - The signatures were verified against the specified public key")
 	}
+	if co.KeySet != nil {
+		ui.Infof(ctx, "  - The signatures were verified against a key of the key set that was valid when they were logged or timestamped")
+	}
+	if co.RevocationList != nil {
+		ui.Infof(ctx, "  - The signing keys, certificates and identities were not revoked when the signatures were logged or timestamped")
+	}
+	if co.HardwareAttestationRoots != nil {
+		ui.Infof(ctx, "  - The signing keys were attested to have been generated on a hardware token by a trusted vendor root")
+	}
 	if fulcioVerified {
 		ui.Infof(ctx, "  - The code-signing certificate was verified using trusted certificate authority certificates")
 	}
@@ -16,6 +16,7 @@
 package verify
 
 import (
+	"cmp"
 	"context"
 	"crypto"
 	"encoding/json"
@@ -45,9 +46,12 @@ import (
 type VerifyCommand struct {
 	options.RegistryOptions
 	options.CertVerifyOptions
+	options.RevocationOptions
+	options.HardwareAttestationOptions
 	options.CommonVerifyOptions
 	CheckClaims                  bool
 	KeyRef                       string
+	KeySetRef                    string
 	CertRef                      string
 	CertGithubWorkflowTrigger    string
 	CertGithubWorkflowSha        string
@@ -79,6 +83,7 @@ type VerifyCommand struct {
 	ExperimentalOCI11            bool
 	NewBundleFormat              bool
 	AllowCertificateChain        bool
+	VSA                          options.VSAOptions
 }
 
 // Exec runs the verification command
@@ -87,6 +92,10 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 		return flag.ErrHelp
 	}
 
+	if err := checkVSAOptions(c.VSA, c.LocalImage, len(images)); err != nil {
+		return err
+	}
+
 	switch c.Attachment {
 	case "sbom":
 		fmt.Fprintln(os.Stderr, options.SBOMAttachmentDeprecation)
@@ -101,12 +110,15 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 	// SHA256 for keys that require a different algorithm (e.g. P-521 ECDSA keys).
 
 	// key and cert identity are mutually exclusive
-	if options.NOf(c.KeyRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
+	if options.NOf(c.KeyRef, c.KeySetRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
 		return &options.KeyAndIdentityParseError{}
 	}
+	// A key set is trusted like a key, except that signatures are only
+	// accepted within the validity window of the key that made them.
+	keyRef := cmp.Or(c.KeyRef, c.KeySetRef)
 
 	var identities []cosign.Identity
-	if c.KeyRef == "" && !c.Sk {
+	if keyRef == "" && !c.Sk {
 		identities, err = c.Identities()
 		if err != nil {
 			return err
@@ -144,7 +156,7 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 		NewBundleFormat:              c.NewBundleFormat,
 		AllowCertificateChain:        c.AllowCertificateChain,
 	}
-	vOfflineKey := verifyOfflineWithKey(c.KeyRef, c.CertRef, c.Sk, co)
+	vOfflineKey := verifyOfflineWithKey(keyRef, c.CertRef, c.Sk, co)
 
 	// Auto-detect bundle format for local images
 	if c.LocalImage {
@@ -180,7 +192,7 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 		}
 	}
 
-	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, c.KeyRef, c.Sk), keylessVerification(c.KeyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
+	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, keyRef, c.Sk), keylessVerification(keyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
 	if err != nil {
 		return fmt.Errorf("setting up clients and keys: %w", err)
 	}
@@ -194,6 +206,15 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 		return fmt.Errorf("loading verifier from key opts: %w", err)
 	}
 	defer closeSV()
+	if err := SetRevocationList(ctx, c.RevocationOptions, co); err != nil {
+		return err
+	}
+	if err := SetHardwareAttestationRoots(c.HardwareAttestationOptions, co); err != nil {
+		return err
+	}
+	if err := SetKeySet(c.KeySetRef, co); err != nil {
+		return err
+	}
 
 	if c.CertRef != "" && c.SCTRef != "" {
 		sct, err := os.ReadFile(filepath.Clean(c.SCTRef))
@@ -209,7 +230,7 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 	//    Fulcio root trust (or user supplied root trust)
 	// TODO(nsmith5): Refactor this verification logic to pass back _how_ verification
 	// was performed so we don't need to use this fragile logic here.
-	fulcioVerified := (co.SigVerifier == nil)
+	fulcioVerified := (co.SigVerifier == nil && co.KeySet == nil)
 
 	for _, img := range images {
 		var verified []oci.Signature
@@ -235,6 +256,23 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 				return fmt.Errorf("parsing reference: %w", err)
 			}
 
+			if !co.NewBundleFormat {
+				ref, err = sign.GetAttachedImageRef(ref, c.Attachment, ociremoteOpts...)
+				if err != nil {
+					return fmt.Errorf("resolving attachment type %s for image %s: %w", c.Attachment, img, err)
+				}
+			}
+			var vsaDigest name.Digest
+			if c.VSA.Enabled() {
+				// Verify by digest, so that the VSA is issued for the image
+				// that was verified even if the tag moves in the meantime.
+				vsaDigest, err = ociremote.ResolveDigest(ref, ociremoteOpts...)
+				if err != nil {
+					return err
+				}
+				ref = vsaDigest
+			}
+
 			if co.NewBundleFormat {
 				// OCI bundle always contains attestation
 				verified, bundleVerified, err = cosign.VerifyImageAttestations(ctx, ref, co, c.NameOptions...)
@@ -247,11 +285,6 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 					verified = verifiedOutput
 				}
 			} else {
-				ref, err = sign.GetAttachedImageRef(ref, c.Attachment, ociremoteOpts...)
-				if err != nil {
-					return fmt.Errorf("resolving attachment type %s for image %s: %w", c.Attachment, img, err)
-				}
-
 				verified, bundleVerified, err = cosign.VerifyImageSignatures(ctx, ref, co)
 				if err != nil {
 					return cosignError.WrapError(err)
@@ -260,6 +293,18 @@ func (c *VerifyCommand) Exec(ctx context.Context, images []string) (err error) {
 
 			PrintVerificationHeader(ctx, ref.Name(), co, bundleVerified, fulcioVerified)
 			PrintVerification(ctx, verified, c.Output)
+
+			if c.VSA.Enabled() {
+				if err := emitVSA(ctx, vsaRequest{
+					opts:     c.VSA,
+					registry: c.RegistryOptions,
+					rekorURL: c.RekorURL,
+					digest:   vsaDigest,
+					inputs:   verified,
+				}); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -16,19 +16,23 @@
 package verify
 
 import (
+	"cmp"
 	"context"
 	"crypto"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/v3/internal/ui"
 	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
 	"github.com/sigstore/cosign/v3/pkg/cosign/cue"
 	"github.com/sigstore/cosign/v3/pkg/cosign/rego"
 	"github.com/sigstore/cosign/v3/pkg/oci"
@@ -42,9 +46,11 @@ import (
 type VerifyAttestationCommand struct {
 	options.RegistryOptions
 	options.CertVerifyOptions
+	options.RevocationOptions
 	options.CommonVerifyOptions
 	CheckClaims                  bool
 	KeyRef                       string
+	KeySetRef                    string
 	CertRef                      string
 	CertGithubWorkflowTrigger    string
 	CertGithubWorkflowSha        string
@@ -62,6 +68,7 @@ type VerifyAttestationCommand struct {
 	RekorURL                     string
 	PredicateType                string
 	Policies                     []string
+	Convert                      string
 	LocalImage                   bool
 	NameOptions                  []name.Option
 	Offline                      bool
@@ -70,6 +77,11 @@ type VerifyAttestationCommand struct {
 	MaxWorkers                   int
 	UseSignedTimestamps          bool
 	HashAlgorithm                crypto.Hash
+	VSA                          options.VSAOptions
+	Query                        options.AttestationQueryOptions
+	Vuln                         options.VulnPolicyOptions
+	MergeAttestationFormats      bool
+	RequirePredicateTypes        []string
 }
 
 // Exec runs the verification command
@@ -78,22 +90,45 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 		return flag.ErrHelp
 	}
 
+	if err := checkVSAOptions(c.VSA, c.LocalImage, len(images)); err != nil {
+		return err
+	}
+	query, err := c.Query.Query()
+	if err != nil {
+		return err
+	}
+	vuln, err := newVulnCheck(c.Vuln, c.PredicateType)
+	if err != nil {
+		return err
+	}
+	if c.Convert != "" {
+		if !slices.Contains(attestation.SBOMConversions(), c.Convert) {
+			return fmt.Errorf("invalid --convert %q, expected one of %s", c.Convert, strings.Join(attestation.SBOMConversions(), ", "))
+		}
+		if vuln != nil {
+			return errors.New("--convert applies to SBOM attestations and cannot be combined with vulnerability policies")
+		}
+	}
+
 	// key and cert identity are mutually exclusive
-	if options.NOf(c.KeyRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
+	if options.NOf(c.KeyRef, c.KeySetRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
 		return &options.KeyAndIdentityParseError{}
 	}
+	// A key set is trusted like a key, except that signatures are only
+	// accepted within the validity window of the key that made them.
+	keyRef := cmp.Or(c.KeyRef, c.KeySetRef)
 
 	// c.HashAlgorithm may be 0 (unset) here, in which case LoadVerifierFromKeyOrCert
 	// picks the digest algorithm that matches the provided key, rather than assuming
 	// SHA256 for keys that require a different algorithm (e.g. P-521 ECDSA keys).
 
 	// We can't have both a key and a security key
-	if options.NOf(c.KeyRef, c.Sk) > 1 {
+	if options.NOf(keyRef, c.Sk) > 1 {
 		return &options.KeyParseError{}
 	}
 
 	var identities []cosign.Identity
-	if c.KeyRef == "" && !c.Sk {
+	if keyRef == "" && !c.Sk {
 		identities, err = c.Identities()
 		if err != nil {
 			return err
@@ -126,8 +161,9 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 		UseSignedTimestamps:          c.TSACertChainPath != "" || c.UseSignedTimestamps,
 		NewBundleFormat:              c.NewBundleFormat,
 		AllowCertificateChain:        c.AllowCertificateChain,
+		MergeAttestationFormats:      c.MergeAttestationFormats,
 	}
-	vOfflineKey := verifyOfflineWithKey(c.KeyRef, c.CertRef, c.Sk, co)
+	vOfflineKey := verifyOfflineWithKey(keyRef, c.CertRef, c.Sk, co)
 
 	// Auto-detect bundle format for local images
 	if c.LocalImage {
@@ -136,15 +172,27 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 			return fmt.Errorf("checking local image format: %w", err)
 		}
 		co.NewBundleFormat = hasBundles
+		if c.MergeAttestationFormats && c.NewBundleFormat && !hasBundles {
+			ui.Warnf(ctx, "--merge-attestation-formats has no effect, %s has no Sigstore bundles, verifying only the legacy attestations", images[0])
+		}
 	} else {
 		ref, err := name.ParseReference(images[0], c.NameOptions...)
 		if err == nil && c.NewBundleFormat {
 			newBundles, _, err := cosign.GetBundles(ctx, ref, co.RegistryClientOpts, c.NameOptions...)
 			if len(newBundles) == 0 || err != nil {
 				co.NewBundleFormat = false
+				switch {
+				case c.MergeAttestationFormats && err != nil:
+					ui.Warnf(ctx, "--merge-attestation-formats has no effect, verifying only the legacy attestations of %s: fetching Sigstore bundles: %v", images[0], err)
+				case c.MergeAttestationFormats:
+					ui.Warnf(ctx, "--merge-attestation-formats has no effect, %s has no Sigstore bundles, verifying only the legacy attestations", images[0])
+				}
 			}
 		}
 	}
+	if c.MergeAttestationFormats && !c.NewBundleFormat {
+		ui.Warnf(ctx, "--merge-attestation-formats has no effect without --new-bundle-format")
+	}
 
 	if c.CheckClaims {
 		co.ClaimVerifier = cosign.IntotoSubjectClaimVerifier
@@ -159,7 +207,7 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 		return err
 	}
 
-	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, c.KeyRef, c.Sk), keylessVerification(c.KeyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
+	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, keyRef, c.Sk), keylessVerification(keyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
 	if err != nil {
 		return fmt.Errorf("setting up clients and keys: %w", err)
 	}
@@ -173,6 +221,12 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 		return fmt.Errorf("loading verifierfrom key opts: %w", err)
 	}
 	defer closeSV()
+	if err := SetRevocationList(ctx, c.RevocationOptions, co); err != nil {
+		return err
+	}
+	if err := SetKeySet(c.KeySetRef, co); err != nil {
+		return err
+	}
 
 	if c.CertRef != "" && c.SCTRef != "" {
 		sct, err := os.ReadFile(filepath.Clean(c.SCTRef))
@@ -187,11 +241,12 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 	// 2. We're going to find an x509 certificate on the signature and verify against Fulcio root trust
 	// TODO(nsmith5): Refactor this verification logic to pass back _how_ verification
 	// was performed so we don't need to use this fragile logic here.
-	fulcioVerified := (co.SigVerifier == nil)
+	fulcioVerified := (co.SigVerifier == nil && co.KeySet == nil)
 
 	for _, imageRef := range images {
 		var verified []oci.Signature
 		var bundleVerified bool
+		var digest name.Digest
 
 		if c.LocalImage {
 			verified, bundleVerified, err = cosign.VerifyLocalImageAttestations(ctx, imageRef, co)
@@ -203,6 +258,16 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 			if err != nil {
 				return err
 			}
+			if c.VSA.Enabled() || vuln != nil {
+				// Verify by digest, so that the VSA is issued for, and VEX
+				// statements are matched against, the image that was
+				// verified even if the tag moves in the meantime.
+				digest, err = ociremote.ResolveDigest(ref, ociremoteOpts...)
+				if err != nil {
+					return err
+				}
+				ref = digest
+			}
 
 			verified, bundleVerified, err = cosign.VerifyImageAttestations(ctx, ref, co, c.NameOptions...)
 			if err != nil {
@@ -210,6 +275,10 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 			}
 		}
 
+		if err := checkRequiredPredicateTypes(ctx, verified, c.RequirePredicateTypes); err != nil {
+			return fmt.Errorf("%s: %w", imageRef, err)
+		}
+
 		var cuePolicies, regoPolicies []string
 
 		for _, policy := range c.Policies {
@@ -224,6 +293,7 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 		}
 
 		var checked []oci.Signature
+		var checkedPayloads [][]byte
 		var validationErrors []error
 		// To aid in determining if there's a mismatch in what predicateType
 		// we're looking for and what we checked, keep track of them here so
@@ -239,6 +309,27 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 				// This is not the predicate type we're looking for.
 				continue
 			}
+			if ok, err := query.Matches(payload); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+			if c.Convert != "" {
+				payload, err = attestation.ConvertStatementSBOM(payload, c.Convert)
+				if errors.Is(err, attestation.ErrUnknownSBOMFormat) {
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("converting SBOM attestation: %w", err)
+				}
+			}
+
+			if vuln != nil && vuln.residual {
+				// Policies apply to the merged report instead.
+				checked = append(checked, vp)
+				checkedPayloads = append(checkedPayloads, payload)
+				continue
+			}
 
 			if len(cuePolicies) > 0 {
 				ui.Infof(ctx, "will be validating against CUE policies: %v", cuePolicies)
@@ -259,6 +350,7 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 			}
 
 			checked = append(checked, vp)
+			checkedPayloads = append(checkedPayloads, payload)
 		}
 
 		if len(validationErrors) > 0 {
@@ -270,14 +362,91 @@ func (c *VerifyAttestationCommand) Exec(ctx context.Context, images []string) (e
 		}
 
 		if len(checked) == 0 {
+			if c.Convert != "" {
+				return fmt.Errorf("none of the attestations of predicate type %s is an SBOM, found: %s", c.PredicateType, strings.Join(checkedPredicateTypes, ","))
+			}
+			if len(c.Query.Filters) > 0 {
+				return fmt.Errorf("none of the attestations of predicate type %s matched the filters, found: %s", c.PredicateType, strings.Join(checkedPredicateTypes, ","))
+			}
 			return fmt.Errorf("none of the attestations matched the predicate type: %s, found: %s", c.PredicateType, strings.Join(checkedPredicateTypes, ","))
 		}
 
+		var residual []byte
+		if vuln != nil {
+			// digest is unset for local images.
+			report, err := vuln.report(ctx, verified, checked, digest.DigestStr())
+			if err != nil {
+				return err
+			}
+			if vuln.residual {
+				residual, err = json.Marshal(report)
+				if err != nil {
+					return err
+				}
+				if err := validateResidualReport(ctx, residual, cuePolicies, regoPolicies); err != nil {
+					return err
+				}
+			}
+			if err := vuln.policy.Evaluate(report); err != nil {
+				return fmt.Errorf("vulnerability scan of %s failed: %w", imageRef, err)
+			}
+		}
+
 		// TODO: add CUE validation report to `PrintVerificationHeader`.
 		PrintVerificationHeader(ctx, imageRef, co, bundleVerified, fulcioVerified)
-		// The attestations are always JSON, so use the raw "text" mode for outputting them instead of conversion
-		PrintVerification(ctx, checked, "text")
+		switch {
+		case residual != nil:
+			fmt.Println(string(residual))
+		case query.HasProjection():
+			for _, payload := range checkedPayloads {
+				if err := query.Project(os.Stdout, payload); err != nil {
+					return err
+				}
+			}
+		default:
+			// The attestations are always JSON, so use the raw "text" mode for outputting them instead of conversion
+			PrintVerification(ctx, checked, "text")
+		}
+
+		if c.VSA.Enabled() {
+			if err := emitVSA(ctx, vsaRequest{
+				opts:     c.VSA,
+				registry: c.RegistryOptions,
+				rekorURL: c.RekorURL,
+				digest:   digest,
+				inputs:   checked,
+				policies: c.Policies,
+			}); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
+
+// checkRequiredPredicateTypes checks that the verified attestations include
+// one of each required predicate type.
+func checkRequiredPredicateTypes(ctx context.Context, verified []oci.Signature, required []string) error {
+	if len(required) == 0 {
+		return nil
+	}
+	var found []string
+	for _, vp := range verified {
+		_, predicateType, err := policy.AttestationToPayloadJSON(ctx, options.PredicateCustom, vp)
+		if err != nil {
+			return fmt.Errorf("reading attestation: %w", err)
+		}
+		found = append(found, predicateType)
+	}
+	var missing []string
+	for _, r := range required {
+		if !slices.ContainsFunc(found, func(pt string) bool { return attestation.MatchesPredicateType(r, pt) }) {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no verified attestation of required predicate types %s, found: %s", strings.Join(missing, ","), strings.Join(found, ","))
+	}
+	return nil
+}
@@ -16,11 +16,15 @@ package verify
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
 )
 
 func TestVerifyAttestationMissingSubject(t *testing.T) {
@@ -126,3 +130,58 @@ func TestVerifyAttestationSkWithoutIdentities(t *testing.T) {
 		t.Fatalf("expected PIV error, got: %v", err)
 	}
 }
+
+func TestCheckRequiredPredicateTypes(t *testing.T) {
+	var verified []oci.Signature
+	for _, predicateType := range []string{"https://slsa.dev/provenance/v1", "https://spdx.dev/Document"} {
+		statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","predicateType":%q,"predicate":{}}`, predicateType)
+		att, err := static.NewAttestation([]byte(fmt.Sprintf(`{"payloadType":"application/vnd.in-toto+json","payload":%q,"signatures":[]}`,
+			base64.StdEncoding.EncodeToString([]byte(statement)))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		verified = append(verified, att)
+	}
+
+	ctx := context.Background()
+	if err := checkRequiredPredicateTypes(ctx, verified, nil); err != nil {
+		t.Errorf("checkRequiredPredicateTypes() without requirements error = %v", err)
+	}
+	if err := checkRequiredPredicateTypes(ctx, verified, []string{"slsaprovenance1", "https://spdx.dev/Document"}); err != nil {
+		t.Errorf("checkRequiredPredicateTypes() error = %v", err)
+	}
+	err := checkRequiredPredicateTypes(ctx, verified, []string{"spdxjson", "vuln"})
+	if err == nil || !strings.Contains(err.Error(), "vuln") || strings.Contains(err.Error(), "spdxjson") {
+		t.Errorf("checkRequiredPredicateTypes() error = %v, want vuln missing", err)
+	}
+}
+
+func TestVerifyAttestationConvert(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		cmd     VerifyAttestationCommand
+		wantErr string
+	}{
+		{
+			name:    "unknown conversion",
+			cmd:     VerifyAttestationCommand{PredicateType: "spdxjson", Convert: "spdx"},
+			wantErr: "invalid --convert",
+		},
+		{
+			name: "vulnerability policy",
+			cmd: VerifyAttestationCommand{
+				PredicateType: "vuln",
+				Convert:       "components",
+				Vuln:          options.VulnPolicyOptions{MaxSeverity: "high"},
+			},
+			wantErr: "cannot be combined",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Exec(context.Background(), []string{"foo"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Exec() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,9 +16,10 @@
 package verify
 
 import (
-	"bytes"
+	"cmp"
 	"context"
 	"crypto"
+	"crypto/ed25519"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
@@ -41,6 +42,7 @@ import (
 	sgverify "github.com/sigstore/sigstore-go/pkg/verify"
 
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
 )
 
 func isb64(data []byte) bool {
@@ -52,6 +54,8 @@ func isb64(data []byte) bool {
 type VerifyBlobCmd struct {
 	options.KeyOpts
 	options.CertVerifyOptions
+	options.RevocationOptions
+	KeySetRef                    string
 	CertRef                      string
 	CAIntermediates              string
 	CARoots                      string
@@ -79,23 +83,26 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 	// SHA256 for keys that require a different algorithm (e.g. P-521 ECDSA keys).
 
 	// Require a certificate/key OR a local bundle file that has the cert.
-	if options.NOf(c.KeyRef, c.CertRef, c.Sk, c.BundlePath) == 0 {
-		return fmt.Errorf("provide a key with --key or --sk, a certificate to verify against with --certificate, or a bundle with --bundle")
+	if options.NOf(c.KeyRef, c.KeySetRef, c.CertRef, c.Sk, c.BundlePath) == 0 {
+		return fmt.Errorf("provide a key with --key, --keyset or --sk, a certificate to verify against with --certificate, or a bundle with --bundle")
 	}
 
 	// key and cert identity are mutually exclusive
-	if options.NOf(c.KeyRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
+	if options.NOf(c.KeyRef, c.KeySetRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
 		return &options.KeyAndIdentityParseError{}
 	}
+	// A key set is trusted like a key, except that signatures are only
+	// accepted within the validity window of the key that made them.
+	keyRef := cmp.Or(c.KeyRef, c.KeySetRef)
 
 	// Key, sk, and cert are mutually exclusive.
-	if options.NOf(c.KeyRef, c.Sk, c.CertRef) > 1 {
+	if options.NOf(keyRef, c.Sk, c.CertRef) > 1 {
 		return &options.PubKeyParseError{}
 	}
 
 	var identities []cosign.Identity
 	var err error
-	if c.KeyRef == "" && !c.Sk {
+	if keyRef == "" && !c.Sk {
 		identities, err = c.Identities()
 		if err != nil {
 			return err
@@ -116,7 +123,7 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 		AllowCertificateChain:        c.AllowCertificateChain,
 	}
 	co.NewBundleFormat = c.KeyOpts.NewBundleFormat && checkNewBundle(c.BundlePath, co.BundleOptions()...)
-	vOfflineKey := verifyOfflineWithKey(c.KeyRef, c.CertRef, c.Sk, co)
+	vOfflineKey := verifyOfflineWithKey(keyRef, c.CertRef, c.Sk, co)
 
 	// User provides a key or certificate. Otherwise, verification requires a Fulcio certificate
 	// provided in an attached bundle or OCI annotation.
@@ -127,6 +134,12 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 		return fmt.Errorf("loading verifier from key opts: %w", err)
 	}
 	defer closeSV()
+	if err := SetRevocationList(ctx, c.RevocationOptions, co); err != nil {
+		return err
+	}
+	if err := SetKeySet(c.KeySetRef, co); err != nil {
+		return err
+	}
 
 	err = SetTrustedMaterial(ctx, c.TrustedRootPath, c.CertChain, c.CARoots, c.CAIntermediates, c.TSACertChainPath, vOfflineKey, co)
 	if err != nil {
@@ -143,17 +156,11 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 			return err
 		}
 
-		var artifactPolicyOption sgverify.ArtifactPolicyOption
-		blobBytes, err := payloadBytes(blobRef)
+		artifactPolicyOption, closePayload, err := artifactPolicy(blobRef)
 		if err != nil {
-			alg, digest, payloadDigestError := payloadDigest(blobRef)
-			if payloadDigestError != nil {
-				return err
-			}
-			artifactPolicyOption = sgverify.WithArtifactDigest(alg, digest)
-		} else {
-			artifactPolicyOption = sgverify.WithArtifact(bytes.NewReader(blobBytes))
+			return err
 		}
+		defer closePayload()
 
 		_, err = cosign.VerifyNewBundle(ctx, co, artifactPolicyOption, bundle)
 		if err != nil {
@@ -164,10 +171,14 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 		return nil
 	}
 
-	blobBytes, err := payloadBytes(blobRef)
+	blobReader, err := blob.OpenFileOrURL(blobRef)
 	if err != nil {
+		if _, _, digestErr := payloadDigest(blobRef); digestErr == nil && errors.Is(err, fs.ErrNotExist) {
+			return errors.New("verifying an artifact by its digest requires a --bundle in the new bundle format")
+		}
 		return err
 	}
+	defer blobReader.Close()
 
 	if c.TrustedRootPath != "" {
 		return fmt.Errorf("--trusted-root only supported with --new-bundle-format")
@@ -178,7 +189,7 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 		return fmt.Errorf("when specifying --use-signed-timestamps or --timestamp-certificate-chain, you must also specify --rfc3161-timestamp-path")
 	}
 
-	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, c.KeyRef, c.Sk), keylessVerification(c.KeyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
+	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, keyRef, c.Sk), keylessVerification(keyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
 	if err != nil {
 		return fmt.Errorf("setting up clients and keys: %w", err)
 	}
@@ -205,8 +216,8 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 			cert = bundleCert
 		}
 		// A verifier must come either from a certificate from the bundle,
-		// or provided via --key, --sk, or --certificate.
-		if co.SigVerifier == nil && cert == nil {
+		// or provided via --key, --keyset, --sk, or --certificate.
+		if co.SigVerifier == nil && co.KeySet == nil && cert == nil {
 			return fmt.Errorf("bundle does not contain cert for verification, please provide public key")
 		}
 
@@ -274,18 +285,83 @@ func (c *VerifyBlobCmd) Exec(ctx context.Context, blobRef string) error {
 	if err != nil {
 		return err
 	}
-	signature, err := static.NewSignature(blobBytes, sig, opts...)
+
+	hashFuncs, pure, err := blobHashFuncs(c.HashAlgorithm, co.SigVerifier, cert)
 	if err != nil {
 		return err
 	}
-	if _, err = cosign.VerifyBlobSignature(ctx, signature, co); err != nil {
-		return err
+	// The keys of a key set may use any algorithm, and the blob is
+	// verified against each of them.
+	pure = pure || co.KeySet != nil
+	if pure {
+		// Pure Ed25519 signs the blob itself rather than a digest of it.
+		blobBytes, err := io.ReadAll(blobReader)
+		if err != nil {
+			return err
+		}
+		signature, err := static.NewSignature(blobBytes, sig, opts...)
+		if err != nil {
+			return err
+		}
+		if _, err = cosign.VerifyBlobSignature(ctx, signature, co); err != nil {
+			return err
+		}
+	} else {
+		digests, err := cosign.HashBlob(blobReader, hashFuncs...)
+		if err != nil {
+			return fmt.Errorf("hashing %s: %w", blobRef, err)
+		}
+		signature, err := static.NewSignature(nil, sig, opts...)
+		if err != nil {
+			return err
+		}
+		if _, err = cosign.VerifyBlobDigestSignature(ctx, signature, digests, co); err != nil {
+			return err
+		}
 	}
 
 	ui.Infof(ctx, "Verified OK")
 	return nil
 }
 
+// blobHashFuncs returns the hash functions a blob signature by the
+// verifier's keys or the certificate may have been made with, or pure if a
+// key is an Ed25519 key that signs the blob itself.
+func blobHashFuncs(hashAlgorithm crypto.Hash, verifier signature.Verifier, cert *x509.Certificate) (hashFuncs []crypto.Hash, pure bool, err error) {
+	hashFuncs = []crypto.Hash{crypto.SHA256}
+	if hashAlgorithm != 0 {
+		hashFuncs = append(hashFuncs, hashAlgorithm)
+	}
+	var verifiers []signature.Verifier
+	if mkv, ok := verifier.(*cosign.MultiKeyVerifier); ok {
+		verifiers = mkv.Verifiers()
+	} else if verifier != nil {
+		verifiers = []signature.Verifier{verifier}
+	}
+	var pubs []crypto.PublicKey
+	for _, v := range verifiers {
+		pub, err := v.PublicKey()
+		if err != nil {
+			return nil, false, err
+		}
+		pubs = append(pubs, pub)
+	}
+	if cert != nil {
+		pubs = append(pubs, cert.PublicKey)
+	}
+	for _, pub := range pubs {
+		if _, ok := pub.(ed25519.PublicKey); ok {
+			return nil, true, nil
+		}
+		details, err := signature.GetDefaultAlgorithmDetails(pub)
+		if err != nil {
+			continue
+		}
+		hashFuncs = append(hashFuncs, details.GetHashType())
+	}
+	return hashFuncs, false, nil
+}
+
 // base64signature returns the base64 encoded signature
 func base64signature(sigRef, bundlePath string) (string, error) {
 	var targetSig []byte
@@ -330,6 +406,22 @@ func payloadBytes(blobRef string) ([]byte, error) {
 	return blobBytes, nil
 }
 
+// artifactPolicy returns the artifact policy for blobRef. Files, URLs and
+// stdin are streamed into the verifier rather than read into memory. If
+// blobRef is not an existing file, it is parsed as a digest of the form
+// <algorithm>:<hex>.
+func artifactPolicy(blobRef string) (sgverify.ArtifactPolicyOption, func() error, error) {
+	rc, err := blob.OpenFileOrURL(blobRef)
+	if err != nil {
+		alg, digest, payloadDigestError := payloadDigest(blobRef)
+		if payloadDigestError != nil || !errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, err
+		}
+		return sgverify.WithArtifactDigest(alg, digest), func() error { return nil }, nil
+	}
+	return sgverify.WithArtifact(rc), rc.Close, nil
+}
+
 func payloadDigest(blobRef string) (string, []byte, error) {
 	hexAlg, hexDigest, ok := strings.Cut(blobRef, ":")
 	if !ok {
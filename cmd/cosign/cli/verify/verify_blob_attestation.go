@@ -16,6 +16,7 @@
 package verify
 
 import (
+	"cmp"
 	"context"
 	"crypto"
 	"crypto/x509"
@@ -48,7 +49,9 @@ import (
 type VerifyBlobAttestationCommand struct {
 	options.KeyOpts
 	options.CertVerifyOptions
+	options.RevocationOptions
 
+	KeySetRef       string
 	CertRef         string
 	CertChain       string
 	CAIntermediates string
@@ -91,22 +94,25 @@ func (c *VerifyBlobAttestationCommand) Exec(ctx context.Context, artifactPath st
 	// SHA256 for keys that require a different algorithm (e.g. P-521 ECDSA keys).
 
 	// Require a certificate/key OR a local bundle file that has the cert.
-	if options.NOf(c.KeyRef, c.CertRef, c.Sk, c.BundlePath) == 0 {
-		return fmt.Errorf("provide a key with --key or --sk, a certificate to verify against with --certificate, or a bundle with --bundle")
+	if options.NOf(c.KeyRef, c.KeySetRef, c.CertRef, c.Sk, c.BundlePath) == 0 {
+		return fmt.Errorf("provide a key with --key, --keyset or --sk, a certificate to verify against with --certificate, or a bundle with --bundle")
 	}
 
 	// key and cert identity are mutually exclusive
-	if options.NOf(c.KeyRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
+	if options.NOf(c.KeyRef, c.KeySetRef, c.CertIdentity, c.CertIdentityRegexp) > 1 {
 		return &options.KeyAndIdentityParseError{}
 	}
+	// A key set is trusted like a key, except that signatures are only
+	// accepted within the validity window of the key that made them.
+	keyRef := cmp.Or(c.KeyRef, c.KeySetRef)
 
 	// We can't have both a key and a security key
-	if options.NOf(c.KeyRef, c.Sk) > 1 {
+	if options.NOf(keyRef, c.Sk) > 1 {
 		return &options.KeyParseError{}
 	}
 
 	var identities []cosign.Identity
-	if c.KeyRef == "" && !c.Sk {
+	if keyRef == "" && !c.Sk {
 		identities, err = c.Identities()
 		if err != nil {
 			return err
@@ -127,7 +133,7 @@ func (c *VerifyBlobAttestationCommand) Exec(ctx context.Context, artifactPath st
 		AllowCertificateChain:        c.AllowCertificateChain,
 	}
 	co.NewBundleFormat = c.NewBundleFormat && checkNewBundle(c.BundlePath, co.BundleOptions()...)
-	vOfflineKey := verifyOfflineWithKey(c.KeyRef, c.CertRef, c.Sk, co)
+	vOfflineKey := verifyOfflineWithKey(keyRef, c.CertRef, c.Sk, co)
 
 	// User provides a key or certificate. Otherwise, verification requires a Fulcio certificate
 	// provided in an attached bundle or OCI annotation.
@@ -138,6 +144,12 @@ func (c *VerifyBlobAttestationCommand) Exec(ctx context.Context, artifactPath st
 		return fmt.Errorf("loading verifier from key opts: %w", err)
 	}
 	defer closeSV()
+	if err := SetRevocationList(ctx, c.RevocationOptions, co); err != nil {
+		return err
+	}
+	if err := SetKeySet(c.KeySetRef, co); err != nil {
+		return err
+	}
 
 	var h v1.Hash
 	var digest []byte
@@ -283,7 +295,7 @@ func (c *VerifyBlobAttestationCommand) Exec(ctx context.Context, artifactPath st
 		return fmt.Errorf("when specifying --use-signed-timestamps or --timestamp-certificate-chain, you must also specify --rfc3161-timestamp-path")
 	}
 
-	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, c.KeyRef, c.Sk), keylessVerification(c.KeyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
+	err = SetLegacyClientsAndKeys(ctx, c.IgnoreTlog, shouldVerifySCT(c.IgnoreSCT, keyRef, c.Sk), keylessVerification(keyRef, c.Sk), c.RekorURL, c.TSACertChainPath, c.CertChain, c.CARoots, c.CAIntermediates, co)
 	if err != nil {
 		return fmt.Errorf("setting up clients and keys: %w", err)
 	}
@@ -318,8 +330,8 @@ func (c *VerifyBlobAttestationCommand) Exec(ctx context.Context, artifactPath st
 			cert = bundleCert
 		}
 		// A verifier must come either from a certificate from the bundle,
-		// or provided via --key, --sk, or --certificate.
-		if co.SigVerifier == nil && cert == nil {
+		// or provided via --key, --keyset, --sk, or --certificate.
+		if co.SigVerifier == nil && co.KeySet == nil && cert == nil {
 			return fmt.Errorf("bundle does not contain cert for verification, please provide public key")
 		}
 
@@ -19,6 +19,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
@@ -851,6 +852,261 @@ func TestVerifyBlobWithUnsetHashAlgorithmMatchesKeyDefault(t *testing.T) {
 	}
 }
 
+func TestVerifyBlobLegacyEd25519(t *testing.T) {
+	ctx := context.Background()
+	td := t.TempDir()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := writeBlobFile(t, td, string(pubPEM), "key.pub")
+	blobPath := writeBlobFile(t, td, "someblob", "blob.txt")
+	sigPath := writeBlobFile(t, td, base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte("someblob"))), "blob.sig")
+
+	// Pure Ed25519 signs the blob itself, so it cannot be verified by digest.
+	cmd := VerifyBlobCmd{
+		KeyOpts:    options.KeyOpts{KeyRef: keyPath},
+		SigRef:     sigPath,
+		IgnoreTlog: true,
+	}
+	if err := cmd.Exec(ctx, blobPath); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	writeBlobFile(t, td, "otherblob", "blob.txt")
+	if err := cmd.Exec(ctx, blobPath); err == nil {
+		t.Error("Exec() verified a modified blob")
+	}
+}
+
+func TestVerifyBlobLegacyDigest(t *testing.T) {
+	td := t.TempDir()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("someblob"))
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := VerifyBlobCmd{
+		KeyOpts:    options.KeyOpts{KeyRef: writeBlobFile(t, td, string(pubPEM), "key.pub")},
+		SigRef:     writeBlobFile(t, td, base64.StdEncoding.EncodeToString(sig), "blob.sig"),
+		IgnoreTlog: true,
+	}
+	err = cmd.Exec(context.Background(), "sha256:"+hex.EncodeToString(digest[:]))
+	if err == nil || !strings.Contains(err.Error(), "requires a --bundle in the new bundle format") {
+		t.Errorf("Exec() with a digest error = %v", err)
+	}
+}
+
+func TestVerifyBlobRevokedKey(t *testing.T) {
+	ctx := context.Background()
+	td := t.TempDir()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(td, "key.pub")
+	if err := os.WriteFile(keyPath, pubPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	blobPath := writeBlobFile(t, td, "someblob", "blob.txt")
+	digest := sha256.Sum256([]byte("someblob"))
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigPath := filepath.Join(td, "blob.sig")
+	if err := os.WriteFile(sigPath, []byte(base64.StdEncoding.EncodeToString(sig)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	authority, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authoritySigner, err := signature.LoadECDSASignerVerifier(authority, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorityPEM, err := cryptoutils.MarshalPublicKeyToPEM(&authority.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorityPath := filepath.Join(td, "revocation.pub")
+	if err := os.WriteFile(authorityPath, authorityPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := cosign.PublicKeyID(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		effective time.Time
+		wantErr   bool
+	}{
+		{"revoked", time.Now().Add(-time.Hour), true},
+		{"revoked in the future", time.Now().Add(time.Hour), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := cosign.SignRevocationList(&cosign.RevocationList{
+				Revocations: []cosign.Revocation{{KeyID: keyID, EffectiveTime: tt.effective, Reason: "leaked"}},
+			}, authoritySigner)
+			if err != nil {
+				t.Fatal(err)
+			}
+			listPath := filepath.Join(td, "revocations.json")
+			if err := os.WriteFile(listPath, envelope, 0600); err != nil {
+				t.Fatal(err)
+			}
+			cmd := VerifyBlobCmd{
+				KeyOpts:    options.KeyOpts{KeyRef: keyPath},
+				SigRef:     sigPath,
+				IgnoreTlog: true,
+				RevocationOptions: options.RevocationOptions{
+					RevocationList:    listPath,
+					RevocationListKey: authorityPath,
+				},
+			}
+			err = cmd.Exec(ctx, blobPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exec() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "revoked") {
+				t.Errorf("Exec() error = %v, want a revocation error", err)
+			}
+		})
+	}
+}
+
+func TestVerifyBlobKeySet(t *testing.T) {
+	ctx := context.Background()
+	td := t.TempDir()
+
+	rekorPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rekorSigner, err := signature.LoadECDSASignerVerifier(rekorPriv, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemRekor, err := cryptoutils.MarshalPublicKeyToPEM(rekorSigner.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SIGSTORE_REKOR_PUBLIC_KEY", writeBlobFile(t, td, string(pemRekor), "rekor_pub.key"))
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadECDSASignerVerifier(priv, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := []byte("someblob")
+	sig, err := signer.SignMessage(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64sig := base64.StdEncoding.EncodeToString(sig)
+	blobPath := writeBlobFile(t, td, string(blob), "blob.txt")
+	sigPath := writeBlobFile(t, td, b64sig, "blob.sig")
+	bundlePath := makeLocalBundleWithoutCert(t, *rekorSigner, blob, sig, pubPEM, true)
+
+	otherPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPEM, err := cryptoutils.MarshalPublicKeyToPEM(&otherPriv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retired := time.Now().Add(-time.Hour)
+
+	for _, tt := range []struct {
+		name    string
+		keys    [][]byte
+		retired bool
+		wantErr string
+	}{
+		{name: "valid key", keys: [][]byte{otherPEM, pubPEM}},
+		{name: "retired key", keys: [][]byte{pubPEM}, retired: true, wantErr: "outside the validity window"},
+		{name: "unknown key", keys: [][]byte{otherPEM}, wantErr: "comparing public key"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := &cosign.KeySet{}
+			for _, pem := range tt.keys {
+				k, err := cosign.NewKeySet(pem)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ks.Keys = append(ks.Keys, k.Keys...)
+			}
+			if tt.retired {
+				ks.Keys[0].NotAfter = &retired
+			}
+			b, err := json.Marshal(ks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cmd := VerifyBlobCmd{
+				KeyOpts:   options.KeyOpts{BundlePath: bundlePath},
+				KeySetRef: writeBlobFile(t, td, string(b), "keyset.json"),
+				SigRef:    sigPath,
+			}
+			err = cmd.Exec(ctx, blobPath)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Exec() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Exec() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A key set needs a time to check the validity windows against.
+	ks, err := cosign.NewKeySet(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := VerifyBlobCmd{
+		KeySetRef:  writeBlobFile(t, td, string(b), "keyset.json"),
+		SigRef:     sigPath,
+		IgnoreTlog: true,
+	}
+	if err := cmd.Exec(ctx, blobPath); err == nil || !strings.Contains(err.Error(), "transparency log entry or signed timestamp") {
+		t.Errorf("Exec() without a log entry error = %v", err)
+	}
+}
+
 func makeRekorEntry(t *testing.T, rekorSigner signature.ECDSASignerVerifier,
 	pyld, sig, svBytes []byte, expiryValid bool) *models.LogEntry {
 	ctx := context.Background()
@@ -0,0 +1,84 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/blob"
+)
+
+// TreeMismatchError is returned by ExecTree when the signature over the
+// manifest is valid but the directory no longer matches it.
+type TreeMismatchError struct {
+	Diff *blob.TreeDiff
+}
+
+func (e *TreeMismatchError) Error() string {
+	var parts []string
+	if n := len(e.Diff.Added); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d added", n))
+	}
+	if n := len(e.Diff.Missing); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d missing", n))
+	}
+	if n := len(e.Diff.Modified); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", n))
+	}
+	return "directory does not match signed manifest: " + strings.Join(parts, ", ")
+}
+
+// ExecTree verifies the signature on the tree manifest at manifestPath using
+// the same options as Exec, then re-hashes dir and compares it against the
+// manifest. Every added, missing or modified file is reported.
+func (c *VerifyBlobCmd) ExecTree(ctx context.Context, dir, manifestPath string) error {
+	if err := c.Exec(ctx, manifestPath); err != nil {
+		return fmt.Errorf("verifying tree manifest: %w", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Clean(manifestPath))
+	if err != nil {
+		return err
+	}
+	expected, err := blob.UnmarshalTreeManifest(raw)
+	if err != nil {
+		return err
+	}
+	actual, err := blob.HashTree(dir, manifestPath)
+	if err != nil {
+		return err
+	}
+
+	diff := expected.Diff(actual)
+	if diff.Empty() {
+		ui.Infof(ctx, "Verified %d files in %s", len(actual.Files), dir)
+		return nil
+	}
+	for _, p := range diff.Added {
+		ui.Warnf(ctx, "added: %s", p)
+	}
+	for _, p := range diff.Missing {
+		ui.Warnf(ctx, "missing: %s", p)
+	}
+	for _, p := range diff.Modified {
+		ui.Warnf(ctx, "modified: %s", p)
+	}
+	return &TreeMismatchError{Diff: diff}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/blob"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+func TestVerifyBlobTree(t *testing.T) {
+	ctx := context.Background()
+	td := t.TempDir()
+	dir := filepath.Join(td, "tree")
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeBlobFile(t, dir, "config", "app.conf")
+	writeBlobFile(t, filepath.Join(dir, "bin"), "binary", "app")
+
+	m, err := blob.HashTree(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := m.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := writeBlobFile(t, td, string(manifest), "manifest.json")
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := writeBlobFile(t, td, string(pubPEM), "key.pub")
+	digest := sha256.Sum256(manifest)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigPath := writeBlobFile(t, td, base64.StdEncoding.EncodeToString(sig), "manifest.sig")
+
+	cmd := VerifyBlobCmd{
+		KeyOpts:    options.KeyOpts{KeyRef: keyPath},
+		SigRef:     sigPath,
+		IgnoreTlog: true,
+	}
+	if err := cmd.ExecTree(ctx, dir, manifestPath); err != nil {
+		t.Fatalf("ExecTree() on unchanged tree: %v", err)
+	}
+
+	writeBlobFile(t, dir, "tampered", "app.conf")
+	writeBlobFile(t, dir, "extra", "extra.txt")
+	if err := os.Remove(filepath.Join(dir, "bin", "app")); err != nil {
+		t.Fatal(err)
+	}
+	err = cmd.ExecTree(ctx, dir, manifestPath)
+	var mismatch *TreeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("ExecTree() error = %v, want TreeMismatchError", err)
+	}
+	want := &blob.TreeDiff{
+		Added:    []string{"extra.txt"},
+		Missing:  []string{"bin/app"},
+		Modified: []string{"app.conf"},
+	}
+	if !reflect.DeepEqual(mismatch.Diff, want) {
+		t.Errorf("diff = %+v, want %+v", mismatch.Diff, want)
+	}
+
+	// A manifest that does not match its signature must fail before the
+	// tree is inspected.
+	writeBlobFile(t, td, string(manifest)+" ", "manifest.json")
+	if err := cmd.ExecTree(ctx, dir, manifestPath); err == nil || errors.As(err, &mismatch) {
+		t.Errorf("expected signature failure, got %v", err)
+	}
+}
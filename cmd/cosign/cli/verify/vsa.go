@@ -0,0 +1,183 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	vsa_attest "github.com/in-toto/attestation/go/predicates/vsa/v1"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/attest"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultVSAPolicyURI is recorded as the VSA policy when verification was
+// not driven by a policy file.
+const DefaultVSAPolicyURI = "https://cosign.sigstore.dev/policy/verify/v1"
+
+// vsaRequest describes a successful verification to record in a VSA.
+type vsaRequest struct {
+	opts     options.VSAOptions
+	registry options.RegistryOptions
+	rekorURL string
+	// digest is the verified image.
+	digest name.Digest
+	// inputs are the verified signatures or attestations.
+	inputs []oci.Signature
+	// policies are the CUE or Rego files the inputs were checked against.
+	policies []string
+}
+
+func checkVSAOptions(o options.VSAOptions, localImage bool, images int) error {
+	if !o.Enabled() {
+		return nil
+	}
+	if localImage {
+		return errors.New("--emit-vsa is not supported with --local-image")
+	}
+	if o.Path != "" && images > 1 {
+		return errors.New("--emit-vsa writes a single file and can only be used when verifying one image")
+	}
+	if o.Attach && o.Key == "" {
+		return errors.New("--vsa-attach requires --vsa-key to sign the VSA")
+	}
+	return nil
+}
+
+// vsaPredicate returns the VSA predicate recording that r.digest passed
+// verification.
+func vsaPredicate(r vsaRequest) ([]byte, error) {
+	policy := &vsa_attest.VerificationSummary_Policy{Uri: r.opts.PolicyURI}
+	if len(r.policies) == 1 {
+		b, err := os.ReadFile(filepath.Clean(r.policies[0]))
+		if err != nil {
+			return nil, fmt.Errorf("reading policy: %w", err)
+		}
+		sum := sha256.Sum256(b)
+		policy.Digest = map[string]string{"sha256": hex.EncodeToString(sum[:])}
+		if policy.Uri == "" {
+			policy.Uri = r.policies[0]
+		}
+	}
+	if policy.Uri == "" {
+		policy.Uri = DefaultVSAPolicyURI
+	}
+
+	inputs := make([]*vsa_attest.VerificationSummary_InputAttestation, 0, len(r.inputs))
+	for _, sig := range r.inputs {
+		payload, err := sig.Payload()
+		if err != nil {
+			return nil, fmt.Errorf("reading verified payload: %w", err)
+		}
+		sum := sha256.Sum256(payload)
+		inputs = append(inputs, &vsa_attest.VerificationSummary_InputAttestation{
+			Digest: map[string]string{"sha256": hex.EncodeToString(sum[:])},
+		})
+	}
+
+	return protojson.Marshal(&vsa_attest.VerificationSummary{
+		Verifier:           &vsa_attest.VerificationSummary_Verifier{Id: r.opts.VerifierID},
+		TimeVerified:       timestamppb.Now(),
+		ResourceUri:        r.digest.String(),
+		Policy:             policy,
+		InputAttestations:  inputs,
+		VerificationResult: "PASSED",
+		VerifiedLevels:     r.opts.VerifiedLevels,
+		SlsaVersion:        "1.0",
+	})
+}
+
+// emitVSA writes the VSA for a successful verification and, if a key is
+// given, signs it and optionally attaches it to the image.
+func emitVSA(ctx context.Context, r vsaRequest) error {
+	predicate, err := vsaPredicate(r)
+	if err != nil {
+		return err
+	}
+
+	if r.opts.Key == "" {
+		h, err := v1.NewHash(r.digest.DigestStr())
+		if err != nil {
+			return err
+		}
+		stmt, err := attestation.GenerateStatement(attestation.GenerateOpts{
+			Predicate: bytes.NewReader(predicate),
+			Type:      options.PredicateVSA,
+			Digest:    h.Hex,
+			Repo:      r.digest.Repository.String(),
+		})
+		if err != nil {
+			return err
+		}
+		b, err := stmt.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(r.opts.Path, b, 0600); err != nil {
+			return fmt.Errorf("writing VSA: %w", err)
+		}
+		ui.Infof(ctx, "Wrote VSA to file %s", r.opts.Path)
+		return nil
+	}
+
+	// Sign the VSA exactly as `cosign attest --type vsa` would.
+	td, err := os.MkdirTemp("", "cosign-vsa")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(td)
+	predicatePath := filepath.Join(td, "vsa.json")
+	if err := os.WriteFile(predicatePath, predicate, 0600); err != nil {
+		return err
+	}
+	ac := attest.AttestCommand{
+		KeyOpts: options.KeyOpts{
+			KeyRef:           r.opts.Key,
+			PassFunc:         generate.GetPass,
+			RekorURL:         r.rekorURL,
+			SkipConfirmation: true,
+			NewBundleFormat:  true,
+			BundlePath:       r.opts.Path,
+		},
+		RegistryOptions: r.registry,
+		NoUpload:        !r.opts.Attach,
+		PredicatePath:   predicatePath,
+		PredicateType:   options.PredicateVSA,
+		TlogUpload:      r.opts.TlogUpload,
+		RekorEntryType:  "dsse",
+	}
+	if err := ac.Exec(ctx, r.digest.String()); err != nil {
+		return fmt.Errorf("signing VSA: %w", err)
+	}
+	if r.opts.Attach {
+		ui.Infof(ctx, "Attached VSA to %s", r.digest.String())
+	}
+	return nil
+}
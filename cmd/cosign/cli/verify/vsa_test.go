@@ -0,0 +1,185 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	ociremote "github.com/sigstore/cosign/v3/pkg/oci/remote"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+)
+
+func TestVSAPredicate(t *testing.T) {
+	policy := filepath.Join(t.TempDir(), "policy.rego")
+	if err := os.WriteFile(policy, []byte("package signature\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := static.NewSignature([]byte(`{"payload":"e30="}`), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, err := name.NewDigest("example.com/repo@sha256:" + strings.Repeat("a", 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := vsaRequest{
+		opts: options.VSAOptions{
+			VerifierID:     options.DefaultVSAVerifierID,
+			VerifiedLevels: []string{"SLSA_BUILD_LEVEL_3"},
+		},
+		digest:   digest,
+		inputs:   []oci.Signature{sig},
+		policies: []string{policy},
+	}
+	predicate, err := vsaPredicate(r)
+	if err != nil {
+		t.Fatalf("vsaPredicate() error = %v", err)
+	}
+	pt, _ := attestation.Lookup(options.PredicateVSA)
+	if err := pt.Validate(predicate); err != nil {
+		t.Errorf("predicate is not a valid VSA: %v", err)
+	}
+
+	var got struct {
+		ResourceURI string `json:"resourceUri"`
+		Policy      struct {
+			URI    string            `json:"uri"`
+			Digest map[string]string `json:"digest"`
+		} `json:"policy"`
+		InputAttestations []struct {
+			Digest map[string]string `json:"digest"`
+		} `json:"inputAttestations"`
+	}
+	if err := json.Unmarshal(predicate, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ResourceURI != digest.String() || got.Policy.URI != policy || got.Policy.Digest["sha256"] == "" || len(got.InputAttestations) != 1 {
+		t.Errorf("unexpected predicate %s", predicate)
+	}
+
+	r.policies = nil
+	predicate, err = vsaPredicate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(predicate), DefaultVSAPolicyURI) {
+		t.Errorf("expected default policy URI in %s", predicate)
+	}
+}
+
+func TestEmitVSA(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+	ref, err := name.ParseReference(strings.TrimPrefix(srv.URL, "http://")+"/test/image:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := random.Image(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := ref.Context().Digest(d.String())
+
+	td := t.TempDir()
+	keys, err := cosign.GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(td, "vsa.key")
+	if err := os.WriteFile(keyPath, keys.PrivateBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("COSIGN_PASSWORD", "")
+
+	opts := options.VSAOptions{
+		Path:           filepath.Join(td, "vsa.json"),
+		VerifierID:     options.DefaultVSAVerifierID,
+		VerifiedLevels: []string{"SLSA_BUILD_LEVEL_0"},
+	}
+	r := vsaRequest{
+		opts:     opts,
+		registry: options.RegistryOptions{AllowHTTPRegistry: true},
+		digest:   digest,
+	}
+	if err := emitVSA(t.Context(), r); err != nil {
+		t.Fatalf("emitVSA() error = %v", err)
+	}
+	b, err := os.ReadFile(opts.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt := &attestation.Statement{}
+	if err := stmt.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if stmt.PredicateType != attestation.SLSAVerificationSummaryV1 || stmt.Subject[0].Digest["sha256"] != d.Hex {
+		t.Errorf("unexpected statement %s", b)
+	}
+
+	r.opts.Key = keyPath
+	r.opts.Attach = true
+	r.opts.Path = filepath.Join(td, "vsa.sigstore.json")
+	if err := emitVSA(t.Context(), r); err != nil {
+		t.Fatalf("emitVSA() signed error = %v", err)
+	}
+	if _, err := os.Stat(r.opts.Path); err != nil {
+		t.Errorf("bundle not written: %v", err)
+	}
+	bundles, _, err := cosign.GetBundles(t.Context(), digest, []ociremote.Option{ociremote.WithRemoteOptions(remote.WithContext(t.Context()))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundles) != 1 {
+		t.Errorf("got %d attached bundles, want 1", len(bundles))
+	}
+}
+
+func TestCheckVSAOptions(t *testing.T) {
+	if err := checkVSAOptions(options.VSAOptions{Path: "vsa.json"}, true, 1); err == nil {
+		t.Error("expected error with --local-image")
+	}
+	if err := checkVSAOptions(options.VSAOptions{Attach: true}, false, 1); err == nil {
+		t.Error("expected error attaching without a key")
+	}
+	if err := checkVSAOptions(options.VSAOptions{Path: "vsa.json"}, false, 2); err == nil {
+		t.Error("expected error writing the VSAs of two images to one file")
+	}
+	if err := checkVSAOptions(options.VSAOptions{Attach: true, Key: "cosign.key"}, false, 2); err != nil {
+		t.Errorf("unexpected error attaching the VSAs of two images: %v", err)
+	}
+	if err := checkVSAOptions(options.VSAOptions{}, true, 2); err != nil {
+		t.Errorf("unexpected error when disabled: %v", err)
+	}
+}
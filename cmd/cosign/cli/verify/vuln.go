@@ -0,0 +1,118 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+	"github.com/sigstore/cosign/v3/pkg/cosign/cue"
+	"github.com/sigstore/cosign/v3/pkg/cosign/rego"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/cosign/v3/pkg/policy"
+)
+
+// vulnCheck is the built-in checking of vulnerability scan attestations.
+type vulnCheck struct {
+	policy policy.VulnPolicy
+	// vex are the OpenVEX documents given on the command line. Those
+	// attached to the image are applied first.
+	vex []*policy.OpenVEX
+	// residual is set when the merged report replaces the statements as the
+	// input of policies and the output.
+	residual bool
+}
+
+// newVulnCheck returns the vulnerability check for the flags, or nil if none
+// are set.
+func newVulnCheck(o options.VulnPolicyOptions, predicateType string) (*vulnCheck, error) {
+	if !o.Enabled() {
+		return nil, nil
+	}
+	if !attestation.MatchesPredicateType(predicateType, attestation.CosignVulnProvenanceV01) {
+		return nil, errors.New("--max-age, --max-severity, --vex and --residual require --type vuln")
+	}
+	c := &vulnCheck{residual: o.Residual}
+	if o.MaxAge != "" {
+		maxAge, err := policy.ParseMaxAge(o.MaxAge)
+		if err != nil {
+			return nil, err
+		}
+		c.policy.MaxAge = maxAge
+	}
+	if o.MaxSeverity != "" {
+		sev, err := policy.ParseSeverity(o.MaxSeverity)
+		if err != nil {
+			return nil, err
+		}
+		c.policy.MaxSeverity = &sev
+		c.policy.AllowUnknown = o.AllowUnknownSeverity
+	} else if o.AllowUnknownSeverity {
+		return nil, errors.New("--allow-unknown-severity requires --max-severity")
+	}
+	for _, path := range o.VEX {
+		doc, err := policy.LoadOpenVEX(path)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", path, err)
+		}
+		c.vex = append(c.vex, doc)
+	}
+	return c, nil
+}
+
+// report merges the scans of the checked attestations with the OpenVEX
+// statements of all verified attestations and those of the command line
+// that are about the image with the digest. Without a digest, as for local
+// images, only statements that name no products apply.
+func (c *vulnCheck) report(ctx context.Context, verified, checked []oci.Signature, digest string) (*policy.ResidualReport, error) {
+	vex, err := policy.OpenVEXDocuments(ctx, verified)
+	if err != nil {
+		return nil, fmt.Errorf("reading OpenVEX attestation: %w", err)
+	}
+	scans, err := policy.VulnScans(ctx, checked)
+	if err != nil {
+		return nil, err
+	}
+	return policy.MergeVulnScans(scans, append(vex, c.vex...), digest)
+}
+
+// validateResidualReport validates the merged report against the CUE and
+// Rego policies.
+func validateResidualReport(ctx context.Context, report []byte, cuePolicies, regoPolicies []string) error {
+	var validationErrors []error
+	if len(cuePolicies) > 0 {
+		ui.Infof(ctx, "will be validating the residual vulnerabilities against CUE policies: %v", cuePolicies)
+		if err := cue.ValidateJSON(report, cuePolicies); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+	if len(regoPolicies) > 0 {
+		ui.Infof(ctx, "will be validating the residual vulnerabilities against Rego policies: %v", regoPolicies)
+		validationErrors = append(validationErrors, rego.ValidateJSON(report, regoPolicies)...)
+	}
+	if len(validationErrors) > 0 {
+		ui.Infof(ctx, "There are %d number of errors occurred during the validation:\n", len(validationErrors))
+		for _, v := range validationErrors {
+			ui.Infof(ctx, "- %v", v)
+		}
+		return fmt.Errorf("%d validation errors occurred", len(validationErrors))
+	}
+	return nil
+}
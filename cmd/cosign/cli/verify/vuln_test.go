@@ -0,0 +1,86 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/policy"
+)
+
+func TestNewVulnCheck(t *testing.T) {
+	p, err := newVulnCheck(options.VulnPolicyOptions{}, "custom")
+	if err != nil || p != nil {
+		t.Errorf("newVulnCheck() without flags = %v, %v", p, err)
+	}
+	if _, err := newVulnCheck(options.VulnPolicyOptions{MaxAge: "7d"}, "slsaprovenance1"); err == nil {
+		t.Error("expected error without --type vuln")
+	}
+	if _, err := newVulnCheck(options.VulnPolicyOptions{MaxSeverity: "severe"}, "vuln"); err == nil {
+		t.Error("expected error for an invalid severity")
+	}
+	if _, err := newVulnCheck(options.VulnPolicyOptions{MaxAge: "7d", AllowUnknownSeverity: true}, "vuln"); err == nil {
+		t.Error("expected error for --allow-unknown-severity without --max-severity")
+	}
+	p, err = newVulnCheck(options.VulnPolicyOptions{MaxAge: "7d", MaxSeverity: "HIGH"}, "https://cosign.sigstore.dev/attestation/vuln/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.policy.MaxAge != 7*24*time.Hour || *p.policy.MaxSeverity != policy.SeverityHigh || p.policy.AllowUnknown || p.residual {
+		t.Errorf("newVulnCheck() = %+v", p)
+	}
+	p, err = newVulnCheck(options.VulnPolicyOptions{MaxSeverity: "high", AllowUnknownSeverity: true}, "vuln")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.policy.AllowUnknown {
+		t.Errorf("newVulnCheck() = %+v, want AllowUnknown", p)
+	}
+}
+
+func TestValidateResidualReport(t *testing.T) {
+	td := t.TempDir()
+	cuePolicy := filepath.Join(td, "policy.cue")
+	if err := os.WriteFile(cuePolicy, []byte(`vulnerabilities: [...{severity: !="critical"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	regoPolicy := filepath.Join(td, "policy.rego")
+	if err := os.WriteFile(regoPolicy, []byte(`package signature
+
+default allow = false
+
+allow {
+	count(input.vulnerabilities) == 0
+}
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	clean := []byte(`{"scans":[],"vulnerabilities":[],"suppressed":[]}`)
+	critical := []byte(`{"scans":[],"vulnerabilities":[{"id":"CVE-2024-0001","severity":"critical"}],"suppressed":[]}`)
+	if err := validateResidualReport(t.Context(), clean, []string{cuePolicy}, []string{regoPolicy}); err != nil {
+		t.Errorf("validateResidualReport() error = %v", err)
+	}
+	if err := validateResidualReport(t.Context(), critical, []string{cuePolicy}, nil); err == nil {
+		t.Error("expected the CUE policy to reject a critical vulnerability")
+	}
+	if err := validateResidualReport(t.Context(), critical, nil, []string{regoPolicy}); err == nil {
+		t.Error("expected the Rego policy to reject a vulnerability")
+	}
+}
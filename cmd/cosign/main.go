@@ -37,6 +37,11 @@ func main() {
 	// Fix up flags to POSIX standard flags.
 	ctx := context.Background()
 	for i, arg := range os.Args {
+		// git-sign is invoked by git with gpg-style combined short flags
+		// such as -bsau, which must be left alone.
+		if len(os.Args) > 1 && os.Args[1] == "git-sign" {
+			break
+		}
 		if (strings.HasPrefix(arg, "-") && len(arg) == 2) || (strings.HasPrefix(arg, "--") && len(arg) >= 4) {
 			continue
 		}
@@ -72,6 +72,9 @@ func NewSignerVerifierKeypair(sv signature.SignerVerifier, defaultLoadOptions *[
 	if err != nil {
 		return nil, fmt.Errorf("getting default algorithm details: %w", err)
 	}
+	if !signsDigest(sv) && algo.GetSignatureAlgorithm() == protocommon.PublicKeyDetails_PKIX_ED25519_PH {
+		return nil, errors.New("the signing key only produces pure Ed25519 signatures, not the Ed25519ph signatures required to upload to the transparency log")
+	}
 
 	return &SignerVerifierKeypair{
 		sv:     sv,
@@ -143,6 +146,34 @@ func (k *SignerVerifierKeypair) SignData(ctx context.Context, data []byte) ([]by
 	return sig, digest, nil
 }
 
+// SignsDigest reports whether SignDigest can be used. It cannot for
+// algorithms that sign the message itself, like pure Ed25519, nor for
+// SignerVerifiers that have to be given the message, like ssh-agent keys.
+func (k *SignerVerifierKeypair) SignsDigest() bool {
+	return k.sigAlg.GetHashType() != 0 && signsDigest(k.sv)
+}
+
+// SignDigest signs a digest computed with the keypair's hash algorithm.
+// It fails unless SignsDigest returns true.
+func (k *SignerVerifierKeypair) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	hashType := k.sigAlg.GetHashType()
+	if !k.SignsDigest() {
+		return nil, errors.New("signing algorithm does not support signing a precomputed digest")
+	}
+	if len(digest) != hashType.Size() {
+		return nil, fmt.Errorf("digest length %d does not match %s", len(digest), hashType)
+	}
+	return k.sv.SignMessage(bytes.NewReader(nil), signatureoptions.WithContext(ctx), signatureoptions.WithDigest(digest))
+}
+
+// signsDigest reports whether sv can sign a precomputed digest passed with
+// options.WithDigest. SignerVerifiers that cannot implement a SignsDigest
+// method returning false.
+func signsDigest(sv signature.SignerVerifier) bool {
+	d, ok := sv.(interface{ SignsDigest() bool })
+	return !ok || d.SignsDigest()
+}
+
 // Close closes the underlying SignerVerifier if it has a Close() method.
 func (k *SignerVerifierKeypair) Close() {
 	if closer, ok := k.sv.(interface{ Close() }); ok {
@@ -318,3 +318,43 @@ func TestKMSKeypair_ED25519_Methods(t *testing.T) {
 		}
 	})
 }
+
+// messageSignerVerifier is a mock SignerVerifier that has to be given the
+// message itself, like an ssh-agent key.
+type messageSignerVerifier struct {
+	mockSignerVerifier
+}
+
+func (m *messageSignerVerifier) SignsDigest() bool {
+	return false
+}
+
+func TestSignerVerifierKeypair_MessageSigner(t *testing.T) {
+	ecdsaPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	kp, err := NewSignerVerifierKeypair(&messageSignerVerifier{mockSignerVerifier{pubKey: &ecdsaPriv.PublicKey}}, nil)
+	if err != nil {
+		t.Fatalf("failed to create keypair: %v", err)
+	}
+	if kp.SignsDigest() {
+		t.Error("SignsDigest() = true for a SignerVerifier that has to be given the message")
+	}
+	digest := sha256.Sum256([]byte("data"))
+	if _, err := kp.SignDigest(context.Background(), digest[:]); err == nil {
+		t.Error("SignDigest() succeeded for a SignerVerifier that has to be given the message")
+	}
+
+	_, ed25519Priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	sv := &messageSignerVerifier{mockSignerVerifier{pubKey: ed25519Priv.Public()}}
+	if _, err := NewSignerVerifierKeypair(sv, nil); err == nil || !strings.Contains(err.Error(), "Ed25519ph") {
+		t.Errorf("expected an Ed25519ph error, got %v", err)
+	}
+	if _, err := NewSignerVerifierKeypair(sv, &[]signature.LoadOption{}); err != nil {
+		t.Errorf("unexpected error for pure Ed25519: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/echo":
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+			}
+			var in map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Error(err)
+			}
+			json.NewEncoder(w).Encode(in)
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	c := &Client{
+		BaseURL:   server.URL,
+		Authorize: func(r *http.Request) { r.Header.Set("Authorization", "token test-token") },
+	}
+	var out map[string]string
+	if err := c.Do(ctx, http.MethodPost, "/echo", map[string]string{"key": "value"}, &out); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if out["key"] != "value" {
+		t.Errorf("Do() = %v", out)
+	}
+
+	err := c.Do(ctx, http.MethodGet, "/missing", nil, nil)
+	if !IsNotFound(err) || err.Error() != `404 Not Found: {"message":"not found"}` {
+		t.Errorf("Do() error = %v, want not found", err)
+	}
+
+	err = (&Client{BaseURL: server.URL}).Do(ctx, http.MethodGet, "/echo", nil, nil)
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusUnauthorized || IsNotFound(err) {
+		t.Errorf("Do() error = %v, want unauthorized", err)
+	}
+
+	c.HTTPClient = &http.Client{Timeout: 10 * time.Millisecond}
+	if err := c.Do(ctx, http.MethodGet, "/slow", nil, nil); err == nil {
+		t.Error("expected timeout error")
+	}
+}
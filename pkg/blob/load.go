@@ -80,6 +80,37 @@ func LoadFileOrURL(fileRef string) ([]byte, error) {
 	return raw, nil
 }
 
+// OpenFileOrURL opens fileRef for reading without loading it into memory.
+// It accepts the same references as LoadFileOrURL; "-" reads stdin.
+func OpenFileOrURL(fileRef string) (io.ReadCloser, error) {
+	if fileRef == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	parts := strings.SplitAfterN(fileRef, "://", 2)
+	if len(parts) != 2 {
+		return os.Open(filepath.Clean(fileRef))
+	}
+	switch parts[0] {
+	case "http://", "https://":
+		// #nosec G107
+		resp, err := http.Get(fileRef)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			resp.Body.Close()
+			return nil, fmt.Errorf("loading URL %s: server returned HTTP %d", fileRef, resp.StatusCode)
+		}
+		return resp.Body, nil
+	default:
+		raw, err := LoadFileOrURL(fileRef)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(raw)), nil
+	}
+}
+
 func LoadFileOrURLWithChecksum(fileRef string, checksum string) ([]byte, error) {
 	checksumParts := strings.Split(checksum, ":")
 	if len(checksumParts) >= 3 {
@@ -17,6 +17,9 @@ package blob
 
 import (
 	"bytes"
+	"errors"
+	"io"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -127,6 +130,41 @@ func TestLoadURLNon2xxStatus(t *testing.T) {
 	}
 }
 
+func TestOpenFileOrURL(t *testing.T) {
+	data := []byte("test")
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blob" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rw.Write(data)
+	}))
+	defer server.Close()
+	fname := path.Join(t.TempDir(), "filename.txt")
+	if err := os.WriteFile(fname, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []string{fname, server.URL + "/blob"} {
+		rc, err := OpenFileOrURL(ref)
+		if err != nil {
+			t.Fatalf("OpenFileOrURL(%s) error = %v", ref, err)
+		}
+		actual, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil || !bytes.Equal(actual, data) {
+			t.Errorf("OpenFileOrURL(%s) = %q, %v; want %q", ref, actual, err, data)
+		}
+	}
+
+	if _, err := OpenFileOrURL(server.URL + "/missing"); err == nil || !strings.Contains(err.Error(), "HTTP 404") {
+		t.Errorf("OpenFileOrURL() of a missing URL error = %v", err)
+	}
+	if _, err := OpenFileOrURL(path.Join(t.TempDir(), "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("OpenFileOrURL() of a missing file error = %v, want fs.ErrNotExist", err)
+	}
+}
+
 func TestLoadURLWithChecksum(t *testing.T) {
 	data := []byte("test")
 
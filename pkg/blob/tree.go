@@ -0,0 +1,195 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// TreeManifestVersion is the version of the TreeManifest format.
+const TreeManifestVersion = "v1"
+
+// TreeEntry describes a single file in a TreeManifest. Regular files carry
+// their size and SHA256 digest; symbolic links carry their target and are
+// not followed.
+type TreeEntry struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256,omitempty"`
+	Symlink string `json:"symlink,omitempty"`
+}
+
+// TreeManifest is a deterministic listing of every file below a directory.
+// Entries are sorted by Path, which is relative to the root and always uses
+// forward slashes, so the same tree produces byte-identical manifests on
+// every platform.
+type TreeManifest struct {
+	Version string      `json:"version"`
+	Files   []TreeEntry `json:"files"`
+}
+
+// HashTree walks root and returns a manifest of the files below it.
+// Directories are not recorded; empty directories are therefore ignored.
+// Files other than regular files and symbolic links cause an error.
+//
+// The files at the exclude paths are left out, so that a manifest written
+// inside the tree does not list itself.
+func HashTree(root string, exclude ...string) (*TreeManifest, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	excluded := make(map[string]bool, len(exclude))
+	for _, p := range exclude {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		excluded[abs] = true
+	}
+
+	m := &TreeManifest{Version: TreeManifestVersion, Files: []TreeEntry{}}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if len(excluded) > 0 {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			if excluded[abs] {
+				return nil
+			}
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		entry := TreeEntry{Path: filepath.ToSlash(rel)}
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			entry.Symlink = filepath.ToSlash(target)
+		case d.Type().IsRegular():
+			entry.Size, entry.SHA256, err = hashFile(path)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported file type for %s: %s", path, d.Type())
+		}
+		m.Files = append(m.Files, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hashing tree %s: %w", root, err)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+	return m, nil
+}
+
+func hashFile(path string) (int64, string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Marshal returns the canonical encoding of the manifest, which is the
+// content that gets signed.
+func (m *TreeManifest) Marshal() ([]byte, error) {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// UnmarshalTreeManifest parses a manifest produced by TreeManifest.Marshal.
+func UnmarshalTreeManifest(b []byte) (*TreeManifest, error) {
+	m := &TreeManifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("parsing tree manifest: %w", err)
+	}
+	if m.Version != TreeManifestVersion {
+		return nil, fmt.Errorf("unsupported tree manifest version %q", m.Version)
+	}
+	return m, nil
+}
+
+// TreeDiff lists the paths that differ between two manifests.
+type TreeDiff struct {
+	Added    []string `json:"added,omitempty"`
+	Missing  []string `json:"missing,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+}
+
+// Empty returns true if the manifests that produced d were identical.
+func (d *TreeDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Missing) == 0 && len(d.Modified) == 0
+}
+
+// Diff compares the expected manifest m against actual. Paths only in actual
+// are reported as added, paths only in m as missing, and paths whose size,
+// digest or link target changed as modified.
+func (m *TreeManifest) Diff(actual *TreeManifest) *TreeDiff {
+	expected := make(map[string]TreeEntry, len(m.Files))
+	for _, e := range m.Files {
+		expected[e.Path] = e
+	}
+	d := &TreeDiff{}
+	for _, a := range actual.Files {
+		e, ok := expected[a.Path]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, a.Path)
+		case e != a:
+			d.Modified = append(d.Modified, a.Path)
+		}
+		delete(expected, a.Path)
+	}
+	for p := range expected {
+		d.Missing = append(d.Missing, p)
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Missing)
+	sort.Strings(d.Modified)
+	return d
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHashTree(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"b.txt":       "bravo",
+		"a/nested.go": "package a",
+		"a/empty":     "",
+	})
+
+	m, err := HashTree(root)
+	if err != nil {
+		t.Fatalf("HashTree() error = %v", err)
+	}
+	want := []TreeEntry{
+		{Path: "a/empty", Size: 0, SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{Path: "a/nested.go", Size: 9},
+		{Path: "b.txt", Size: 5},
+	}
+	if len(m.Files) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(m.Files), len(want))
+	}
+	for i := range want {
+		if m.Files[i].Path != want[i].Path || m.Files[i].Size != want[i].Size {
+			t.Errorf("entry %d = %+v, want path %s size %d", i, m.Files[i], want[i].Path, want[i].Size)
+		}
+	}
+	if m.Files[0].SHA256 != want[0].SHA256 {
+		t.Errorf("empty file digest = %s, want %s", m.Files[0].SHA256, want[0].SHA256)
+	}
+
+	// Hashing again must produce byte-identical output.
+	again, err := HashTree(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b1, _ := m.Marshal()
+	b2, _ := again.Marshal()
+	if !bytes.Equal(b1, b2) {
+		t.Error("manifest is not deterministic")
+	}
+
+	parsed, err := UnmarshalTreeManifest(b1)
+	if err != nil {
+		t.Fatalf("UnmarshalTreeManifest() error = %v", err)
+	}
+	if !reflect.DeepEqual(parsed, m) {
+		t.Errorf("round trip mismatch: got %+v, want %+v", parsed, m)
+	}
+}
+
+func TestHashTreeExclude(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"app":           "binary",
+		"manifest.json": "{}",
+	})
+
+	// The manifest path may be given relative to the working directory.
+	t.Chdir(root)
+	m, err := HashTree(root, "manifest.json")
+	if err != nil {
+		t.Fatalf("HashTree() error = %v", err)
+	}
+	if len(m.Files) != 1 || m.Files[0].Path != "app" {
+		t.Errorf("HashTree() = %+v, want only app", m.Files)
+	}
+
+	// Rewriting the excluded manifest does not change the tree.
+	writeTree(t, root, map[string]string{"manifest.json": `{"version":"v1"}`})
+	again, err := HashTree(".", filepath.Join(root, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := m.Diff(again); !d.Empty() {
+		t.Errorf("Diff() = %+v, want empty", d)
+	}
+}
+
+func TestHashTreeNotDirectory(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(f, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := HashTree(f); err == nil {
+		t.Error("expected error hashing a regular file")
+	}
+}
+
+func TestTreeDiff(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"keep":    "same",
+		"change":  "before",
+		"removed": "gone soon",
+	})
+	expected, err := HashTree(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := expected.Diff(expected); !d.Empty() {
+		t.Errorf("self diff not empty: %+v", d)
+	}
+
+	writeTree(t, root, map[string]string{
+		"change": "after",
+		"new":    "hello",
+	})
+	if err := os.Remove(filepath.Join(root, "removed")); err != nil {
+		t.Fatal(err)
+	}
+	actual, err := HashTree(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := expected.Diff(actual)
+	want := &TreeDiff{
+		Added:    []string{"new"},
+		Missing:  []string{"removed"},
+		Modified: []string{"change"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+}
@@ -149,50 +149,60 @@ type Metadata struct {
 type GenerateOpts struct {
 	// Predicate is the source of bytes (e.g. a file) to use as the statement's predicate.
 	Predicate io.Reader
-	// Type is the pre-defined enums (provenance|link|spdx).
-	// default: custom
+	// Type is the short name of a registered predicate type (e.g.
+	// slsaprovenance1, spdxjson, custom) or a predicate type URI.
 	Type string
 	// Digest of the Image reference.
 	Digest string
 	// Repo context of the reference.
 	Repo string
+	// Subjects replace the subject made of Digest and Repo when set, to
+	// attest several artifacts in one statement.
+	Subjects []Subject
 
 	// Function to return the time to set
 	Time func() time.Time
 }
 
-// GenerateStatement returns an in-toto statement based on the provided
-// predicate type (custom|slsaprovenance|slsaprovenance02|slsaprovenance1|spdx|spdxjson|cyclonedx|link).
+// GenerateStatement returns an in-toto statement for the predicate type
+// registered under opts.Type, after validating the predicate. Types that are
+// not registered by name are used as the predicate type URI as is.
 func GenerateStatement(opts GenerateOpts) (*Statement, error) {
+	stmt, err := generateStatement(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Subjects) > 0 {
+		stmt.Subject = resourceDescriptors(opts.Subjects)
+	}
+	return stmt, nil
+}
+
+func generateStatement(opts GenerateOpts) (*Statement, error) {
 	predicate, err := io.ReadAll(opts.Predicate)
 	if err != nil {
 		return nil, err
 	}
 
-	switch opts.Type {
-	case "slsaprovenance":
-		return generateSLSAProvenanceStatementSLSA02(predicate, opts.Digest, opts.Repo)
-	case "slsaprovenance02":
-		return generateSLSAProvenanceStatementSLSA02(predicate, opts.Digest, opts.Repo)
-	case "slsaprovenance1":
-		return generateSLSAProvenanceStatementSLSA1(predicate, opts.Digest, opts.Repo)
-	case "spdx":
-		return generateSPDXStatement(predicate, opts.Digest, opts.Repo, false)
-	case "spdxjson":
-		return generateSPDXStatement(predicate, opts.Digest, opts.Repo, true)
-	case "cyclonedx":
-		return generateCycloneDXStatement(predicate, opts.Digest, opts.Repo)
-	case "link":
-		return generateLinkStatement(predicate, opts.Digest, opts.Repo)
-	case "vuln":
-		return generateVulnStatement(predicate, opts.Digest, opts.Repo)
-	case "openvex":
-		return generateOpenVexStatement(predicate, opts.Digest, opts.Repo)
-	default:
-		stamp := timestamp(opts)
-		predicateType := customType(opts)
-		return generateCustomStatement(predicate, predicateType, opts.Digest, opts.Repo, stamp)
+	predicateType := opts.Type
+	pt, byName := defaultRegistry.Lookup(opts.Type)
+	ok := byName
+	if byName {
+		predicateType = pt.URI
+	} else {
+		pt, ok = defaultRegistry.LookupURI(opts.Type)
 	}
+	if ok && pt.Validate != nil {
+		if err := pt.Validate(predicate); err != nil {
+			return nil, fmt.Errorf("invalid %s predicate: %w", pt.Name, err)
+		}
+	}
+	// Types given by URI keep the generic encoding, even if they match a
+	// built-in type.
+	if byName && pt.generate != nil {
+		return pt.generate(predicate, opts.Digest, opts.Repo)
+	}
+	return generateCustomStatement(predicate, predicateType, opts.Digest, opts.Repo, timestamp(opts))
 }
 
 func generateVulnStatement(predicate []byte, digest string, repo string) (*Statement, error) {
@@ -232,13 +242,6 @@ func timestamp(opts GenerateOpts) string {
 	return now.UTC().Format(time.RFC3339)
 }
 
-func customType(opts GenerateOpts) string {
-	if opts.Type != "custom" {
-		return opts.Type
-	}
-	return CosignCustomProvenanceV01
-}
-
 func generateCustomStatement(rawPayload []byte, customType, digest, repo, timestamp string) (*Statement, error) {
 	payload, err := generateCustomPredicate(rawPayload, customType, timestamp)
 	if err != nil {
@@ -284,12 +287,8 @@ func generateCustomPredicate(rawPayload []byte, customType, timestamp string) (m
 
 func generateSLSAProvenanceStatementSLSA02(rawPayload []byte, digest string, repo string) (*Statement, error) {
 	var predicate slsa02_attest.Provenance
-	err := checkRequiredJSONFields(rawPayload, reflect.TypeOf(predicate.ProtoReflect().Interface()))
-	if err != nil {
-		return nil, fmt.Errorf("provenance predicate: %w", err)
-	}
 	protoOpts := protojson.UnmarshalOptions{DiscardUnknown: true}
-	err = protoOpts.Unmarshal(rawPayload, &predicate)
+	err := protoOpts.Unmarshal(rawPayload, &predicate)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal Provenance predicate: %w", err)
 	}
@@ -335,10 +334,6 @@ func generateSLSAProvenanceStatementSLSA1(rawPayload []byte, digest string, repo
 	}
 
 	var predicate slsa1_attest.Provenance
-	err = checkRequiredJSONFields(modifiedPayload, reflect.TypeOf(predicate.ProtoReflect().Interface()))
-	if err != nil {
-		return nil, fmt.Errorf("provenance predicate: %w", err)
-	}
 	protoOpts := protojson.UnmarshalOptions{DiscardUnknown: true}
 	err = protoOpts.Unmarshal(modifiedPayload, &predicate)
 	if err != nil {
@@ -366,11 +361,7 @@ func generateSLSAProvenanceStatementSLSA1(rawPayload []byte, digest string, repo
 
 func generateLinkStatement(rawPayload []byte, digest string, repo string) (*Statement, error) {
 	var link in_toto.Link
-	err := checkRequiredJSONFields(rawPayload, reflect.TypeOf(link))
-	if err != nil {
-		return nil, fmt.Errorf("link statement: %w", err)
-	}
-	err = json.Unmarshal(rawPayload, &link)
+	err := json.Unmarshal(rawPayload, &link)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal Link statement: %w", err)
 	}
@@ -433,6 +424,11 @@ func generateSPDXStatement(rawPayload []byte, digest string, repo string, parseJ
 		},
 	}
 	if parseJSON {
+		predicateType, err := spdxPredicateType(rawPayload)
+		if err != nil {
+			return nil, err
+		}
+		stmt.PredicateType = predicateType
 		var data map[string]any
 		if err := json.Unmarshal(rawPayload, &data); err != nil {
 			return nil, err
@@ -218,6 +218,42 @@ func TestGenerateStatement(t *testing.T) {
                         }`,
 			wantJSON: `{"_type":"https://in-toto.io/Statement/v0.1","subject":[{"name":"test-repo","digest":{"sha256":"abcdef123456"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{"buildDefinition":{"buildType":"https://example.com/Makefile","externalParameters":{"version":"1.0"},"internalParameters":{},"resolvedDependencies":[{"uri":"git+https://example.com/repo.git","digest":{"sha1":"abcdef123456"}}]},"runDetails":{"builder":{"id":"https://example.com/builder"},"metadata":{"invocationId":"test-invocation"}}}}`,
 		},
+		{
+			name:      "slsaprovenance11",
+			predType:  "slsaprovenance11",
+			predicate: `{"buildDefinition":{"buildType":"https://example.com/Makefile","externalParameters":{"version":"1.0"}},"runDetails":{"builder":{"id":"https://example.com/builder"}}}`,
+			wantJSON:  `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"test-repo","digest":{"sha256":"abcdef123456"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{"buildDefinition":{"buildType":"https://example.com/Makefile","externalParameters":{"version":"1.0"}},"runDetails":{"builder":{"id":"https://example.com/builder"}}}}`,
+		},
+		{
+			name:      "vsa",
+			predType:  "vsa",
+			predicate: `{"verifier":{"id":"https://example.com/verifier"},"timeVerified":"2024-03-11T10:00:00Z","resourceUri":"oci://example.com/image","policy":{"uri":"https://example.com/policy"},"verificationResult":"PASSED","verifiedLevels":["SLSA_BUILD_LEVEL_3"]}`,
+			wantJSON:  `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"test-repo","digest":{"sha256":"abcdef123456"}}],"predicateType":"https://slsa.dev/verification_summary/v1","predicate":{"policy":{"uri":"https://example.com/policy"},"resourceUri":"oci://example.com/image","timeVerified":"2024-03-11T10:00:00Z","verificationResult":"PASSED","verifiedLevels":["SLSA_BUILD_LEVEL_3"],"verifier":{"id":"https://example.com/verifier"}}}`,
+		},
+		{
+			name:      "testresult",
+			predType:  "testresult",
+			predicate: `{"result":"PASSED","configuration":[{"name":".github/workflows/test.yml","digest":{"sha1":"a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"}}],"passedTests":["TestA"]}`,
+			wantJSON:  `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"test-repo","digest":{"sha256":"abcdef123456"}}],"predicateType":"https://in-toto.io/attestation/test-result/v0.1","predicate":{"configuration":[{"digest":{"sha1":"a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"},"name":".github/workflows/test.yml"}],"passedTests":["TestA"],"result":"PASSED"}}`,
+		},
+		{
+			name:      "release",
+			predType:  "release",
+			predicate: `{"purl":"pkg:npm/example@1.0.0","packageId":"1234"}`,
+			wantJSON:  `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"test-repo","digest":{"sha256":"abcdef123456"}}],"predicateType":"https://in-toto.io/attestation/release/v0.2","predicate":{"packageId":"1234","purl":"pkg:npm/example@1.0.0"}}`,
+		},
+		{
+			name:      "spdx 3 json-ld",
+			predType:  "spdxjson",
+			predicate: `{"@context":"https://spdx.org/rdf/3.0.1/spdx-context.jsonld","@graph":[{"type":"SpdxDocument","spdxId":"urn:doc"}]}`,
+			wantJSON:  `{"_type":"https://in-toto.io/Statement/v0.1","subject":[{"name":"test-repo","digest":{"sha256":"abcdef123456"}}],"predicateType":"https://spdx.dev/Document/v3","predicate":{"@context":"https://spdx.org/rdf/3.0.1/spdx-context.jsonld","@graph":[{"spdxId":"urn:doc","type":"SpdxDocument"}]}}`,
+		},
+		{
+			name:      "cyclonedx 1.6",
+			predType:  "cyclonedx",
+			predicate: `{"bomFormat":"CycloneDX","specVersion":"1.6"}`,
+			wantJSON:  `{"_type":"https://in-toto.io/Statement/v0.1","subject":[{"name":"test-repo","digest":{"sha256":"abcdef123456"}}],"predicateType":"https://cyclonedx.org/bom","predicate":{"bomFormat":"CycloneDX","specVersion":"1.6"}}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,122 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	ssldsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/cosign/v3/pkg/types"
+)
+
+// BundleEntry is one attestation of an in-toto attestation bundle: either a
+// signed DSSE envelope or an unsigned statement.
+// See https://github.com/in-toto/attestation/blob/main/spec/v1/bundle.md
+type BundleEntry struct {
+	// Envelope is the DSSE envelope of a signed attestation, or nil for an
+	// unsigned statement.
+	Envelope *ssldsse.Envelope
+	// Statement is the in-toto statement, decoded from the envelope payload
+	// for signed attestations.
+	Statement *Statement
+	// Payload is the JSON encoding of Statement, as found in the bundle.
+	Payload []byte
+}
+
+// HasSubject reports whether the statement has a subject with the given
+// digest.
+func (e BundleEntry) HasSubject(alg, hex string) bool {
+	for _, s := range e.Statement.GetSubject() {
+		if s.GetDigest()[alg] == hex {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadBundle reads an in-toto attestation bundle: a stream of JSON
+// documents, one per line, each a DSSE envelope or an in-toto statement.
+func ReadBundle(r io.Reader) ([]BundleEntry, error) {
+	var entries []BundleEntry
+	decoder := json.NewDecoder(r)
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("attestation %d: %w", len(entries)+1, err)
+		}
+		entry, err := parseBundleEntry(raw)
+		if err != nil {
+			return nil, fmt.Errorf("attestation %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("attestation bundle is empty")
+	}
+	return entries, nil
+}
+
+func parseBundleEntry(raw []byte) (BundleEntry, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return BundleEntry{}, err
+	}
+
+	entry := BundleEntry{Payload: raw}
+	if _, ok := fields["payloadType"]; ok {
+		env := &ssldsse.Envelope{}
+		if err := json.Unmarshal(raw, env); err != nil {
+			return BundleEntry{}, err
+		}
+		if env.PayloadType != types.IntotoPayloadType {
+			return BundleEntry{}, fmt.Errorf("invalid payloadType %s on envelope. Expected %s", env.PayloadType, types.IntotoPayloadType)
+		}
+		if len(env.Signatures) == 0 {
+			return BundleEntry{}, errors.New("envelope has no signatures")
+		}
+		payload, err := env.DecodeB64Payload()
+		if err != nil {
+			return BundleEntry{}, fmt.Errorf("decoding envelope payload: %w", err)
+		}
+		entry.Envelope = env
+		entry.Payload = payload
+	} else if _, ok := fields["_type"]; !ok {
+		return BundleEntry{}, errors.New("not a DSSE envelope or in-toto statement")
+	}
+
+	entry.Statement = &Statement{}
+	if err := entry.Statement.UnmarshalJSON(entry.Payload); err != nil {
+		return BundleEntry{}, fmt.Errorf("parsing statement: %w", err)
+	}
+	if entry.Statement.GetPredicateType() == "" {
+		return BundleEntry{}, errors.New("statement has no predicateType")
+	}
+	return entry, nil
+}
+
+// WriteBundleEnvelope appends a DSSE envelope to an in-toto attestation
+// bundle.
+func WriteBundleEnvelope(w io.Writer, env *ssldsse.Envelope) error {
+	b, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	ssldsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const bundleStatement = `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"a","digest":{"sha256":"abcd"}}],"predicateType":"https://example.com/p/v1","predicate":{}}`
+
+func TestReadBundle(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString(bundleStatement + "\n\n")
+	env := &ssldsse.Envelope{
+		PayloadType: "application/vnd.in-toto+json",
+		Payload:     base64.StdEncoding.EncodeToString([]byte(bundleStatement)),
+		Signatures:  []ssldsse.Signature{{Sig: "c2ln"}},
+	}
+	if err := WriteBundleEnvelope(&buf, env); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatalf("ReadBundle() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].Envelope != nil || entries[1].Envelope == nil {
+		t.Error("expected an unsigned statement followed by an envelope")
+	}
+	for _, e := range entries {
+		if e.Statement.PredicateType != "https://example.com/p/v1" || string(e.Payload) != bundleStatement {
+			t.Errorf("unexpected entry %s", e.Payload)
+		}
+		if !e.HasSubject("sha256", "abcd") || e.HasSubject("sha256", "ef01") {
+			t.Error("HasSubject() mismatch")
+		}
+	}
+
+	for _, tt := range []struct {
+		name, bundle, wantErr string
+	}{
+		{"empty", "\n", "empty"},
+		{"not json", "{", "attestation 1"},
+		{"unknown document", `{"foo":"bar"}`, "not a DSSE envelope"},
+		{"unsigned envelope", `{"payloadType":"application/vnd.in-toto+json","payload":"e30=","signatures":[]}`, "no signatures"},
+		{"other payload type", `{"payloadType":"text/plain","payload":"e30=","signatures":[{"sig":"c2ln"}]}`, "invalid payloadType"},
+		{"no predicate type", `{"_type":"https://in-toto.io/Statement/v1","subject":[]}`, "no predicateType"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadBundle(strings.NewReader(tt.bundle))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ReadBundle() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	slsa1_attest "github.com/in-toto/attestation/go/predicates/provenance/v1"
+	release_attest "github.com/in-toto/attestation/go/predicates/release/v02"
+	testresult_attest "github.com/in-toto/attestation/go/predicates/test_result/v0"
+	vsa_attest "github.com/in-toto/attestation/go/predicates/vsa/v1"
+	in_toto_attest "github.com/in-toto/attestation/go/v1"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// SLSAVerificationSummaryV1 is the predicate type of SLSA Verification
+	// Summary Attestations.
+	SLSAVerificationSummaryV1 = "https://slsa.dev/verification_summary/v1"
+
+	// TestResultV01 is the predicate type of in-toto test result attestations.
+	TestResultV01 = "https://in-toto.io/attestation/test-result/v0.1"
+
+	// ReleaseV02 is the predicate type of in-toto release attestations.
+	ReleaseV02 = "https://in-toto.io/attestation/release/v0.2"
+
+	// SPDXDocumentV3 is the predicate type of SPDX 3 JSON-LD documents.
+	SPDXDocumentV3 = "https://spdx.dev/Document/v3"
+)
+
+// cycloneDXVersions are the CycloneDX specification versions accepted in
+// cyclonedx predicates.
+var cycloneDXVersions = []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6"}
+
+// cycloneDXURIs are the versioned predicate types CycloneDX attestations may
+// carry besides in_toto.PredicateCycloneDX.
+func cycloneDXURIs() []string {
+	uris := make([]string, 0, len(cycloneDXVersions))
+	for _, v := range cycloneDXVersions {
+		uris = append(uris, in_toto.PredicateCycloneDX+"/v"+v)
+	}
+	return uris
+}
+
+// spdxURIs are the versioned predicate types SPDX attestations may carry
+// besides in_toto.PredicateSPDX.
+var spdxURIs = []string{in_toto.PredicateSPDX + "/v2.2", in_toto.PredicateSPDX + "/v2.3", SPDXDocumentV3}
+
+// validateCycloneDX checks that the predicate is a CycloneDX BOM of a known
+// specification version.
+func validateCycloneDX(predicate []byte) error {
+	var bom struct {
+		BOMFormat   string `json:"bomFormat"`
+		SpecVersion string `json:"specVersion"`
+	}
+	if err := json.Unmarshal(predicate, &bom); err != nil {
+		return err
+	}
+	if bom.BOMFormat != "CycloneDX" {
+		return fmt.Errorf("field bomFormat is %q, expected \"CycloneDX\"", bom.BOMFormat)
+	}
+	if bom.SpecVersion != "" && !slices.Contains(cycloneDXVersions, bom.SpecVersion) {
+		return fmt.Errorf("unsupported CycloneDX specVersion %s", bom.SpecVersion)
+	}
+	return nil
+}
+
+// spdxPredicateType detects the SPDX version of a JSON document and returns
+// its predicate type. SPDX 2 documents keep the unversioned type for
+// compatibility with existing policies.
+func spdxPredicateType(predicate []byte) (string, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(predicate, &doc); err != nil {
+		return "", err
+	}
+	if v, ok := doc["spdxVersion"].(string); ok {
+		if !strings.HasPrefix(v, "SPDX-2.") {
+			return "", fmt.Errorf("unsupported spdxVersion %s", v)
+		}
+		return in_toto.PredicateSPDX, nil
+	}
+	if isSPDX3Context(doc["@context"]) {
+		if _, ok := doc["@graph"].([]any); !ok {
+			return "", errors.New("SPDX 3 document has no @graph")
+		}
+		return SPDXDocumentV3, nil
+	}
+	return "", errors.New("required field spdxVersion missing")
+}
+
+// isSPDX3Context reports whether a JSON-LD @context, which may be a string
+// or a list, refers to the SPDX 3 context.
+func isSPDX3Context(ctx any) bool {
+	switch c := ctx.(type) {
+	case string:
+		return strings.Contains(c, "spdx.org/rdf/3.")
+	case []any:
+		return slices.ContainsFunc(c, isSPDX3Context)
+	}
+	return false
+}
+
+func validateSPDXJSON(predicate []byte) error {
+	_, err := spdxPredicateType(predicate)
+	return err
+}
+
+// unmarshalPredicate parses a predicate into its protobuf message, ignoring
+// fields newer than the message.
+func unmarshalPredicate(predicate []byte, msg proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(predicate, msg)
+}
+
+func validateSLSAProvenanceV11(predicate []byte) error {
+	var p slsa1_attest.Provenance
+	if err := unmarshalPredicate(predicate, &p); err != nil {
+		return err
+	}
+	return p.Validate()
+}
+
+func validateVerificationSummary(predicate []byte) error {
+	var vsa vsa_attest.VerificationSummary
+	if err := unmarshalPredicate(predicate, &vsa); err != nil {
+		return err
+	}
+	switch {
+	case vsa.GetVerifier().GetId() == "":
+		return errors.New("required field verifier.id missing")
+	case vsa.GetTimeVerified() == nil:
+		return errors.New("required field timeVerified missing")
+	case vsa.GetResourceUri() == "":
+		return errors.New("required field resourceUri missing")
+	case vsa.GetPolicy() == nil:
+		return errors.New("required field policy missing")
+	case len(vsa.GetVerifiedLevels()) == 0:
+		return errors.New("required field verifiedLevels missing")
+	}
+	if r := vsa.GetVerificationResult(); r != "PASSED" && r != "FAILED" {
+		return fmt.Errorf("verificationResult is %q, expected PASSED or FAILED", r)
+	}
+	return vsa.GetTimeVerified().CheckValid()
+}
+
+func validateTestResult(predicate []byte) error {
+	var tr testresult_attest.TestResult
+	if err := unmarshalPredicate(predicate, &tr); err != nil {
+		return err
+	}
+	if r := tr.GetResult(); r != "PASSED" && r != "WARNED" && r != "FAILED" {
+		return fmt.Errorf("result is %q, expected PASSED, WARNED or FAILED", r)
+	}
+	if len(tr.GetConfiguration()) == 0 {
+		return errors.New("required field configuration missing")
+	}
+	for i, rd := range tr.GetConfiguration() {
+		if err := rd.Validate(); err != nil {
+			return fmt.Errorf("configuration[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateRelease(predicate []byte) error {
+	var r release_attest.Release
+	if err := unmarshalPredicate(predicate, &r); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(r.GetPurl(), "pkg:") {
+		return fmt.Errorf("purl %q is not a package URL", r.GetPurl())
+	}
+	return nil
+}
+
+// generateV1Statement returns a generator that normalizes predicates through
+// the message returned by newMsg and wraps them in an in-toto v1 statement.
+func generateV1Statement(predicateType string, newMsg func() proto.Message) func([]byte, string, string) (*Statement, error) {
+	return func(rawPayload []byte, digest, repo string) (*Statement, error) {
+		msg := newMsg()
+		if err := unmarshalPredicate(rawPayload, msg); err != nil {
+			return nil, fmt.Errorf("unmarshal %s predicate: %w", predicateType, err)
+		}
+		predicateObj, err := protoStructToStruct(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &Statement{
+			Statement: &in_toto_attest.Statement{
+				Type:          in_toto_attest.StatementTypeUri,
+				PredicateType: predicateType,
+				Subject: []*in_toto_attest.ResourceDescriptor{
+					{
+						Name: repo,
+						Digest: map[string]string{
+							"sha256": digest,
+						},
+					},
+				},
+				Predicate: predicateObj,
+			}}, nil
+	}
+}
+
+// generateSLSAProvenanceStatementSLSA11 generates SLSA v1.1 provenance, which
+// shares the v1 predicate type, in an in-toto v1 statement.
+func generateSLSAProvenanceStatementSLSA11(rawPayload []byte, digest string, repo string) (*Statement, error) {
+	stmt, err := generateSLSAProvenanceStatementSLSA1(rawPayload, digest, repo)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Type = in_toto_attest.StatementTypeUri
+	return stmt, nil
+}
@@ -0,0 +1,130 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Query selects in-toto statements with JSONPath filter expressions and
+// projects fields out of the selected ones. A nil Query matches every
+// statement and has no projection.
+type Query struct {
+	filters    []*jsonpath.JSONPath
+	projection *jsonpath.JSONPath
+}
+
+// NewQuery returns a Query for the given filter expressions and projection
+// template. Filters are evaluated against the statement as `@`, e.g.
+// `@.predicate.builder.id=="https://example.com/builder"`, and a statement
+// must match all of them. The projection is a JSONPath template such as
+// `{.predicate.builder.id}`; the braces may be omitted.
+func NewQuery(filters []string, projection string) (*Query, error) {
+	q := &Query{}
+	for _, f := range filters {
+		jp := jsonpath.New("filter").AllowMissingKeys(true)
+		if err := jp.Parse("{[?(" + f + ")]}"); err != nil {
+			return nil, fmt.Errorf("parsing filter %q: %w", f, err)
+		}
+		q.filters = append(q.filters, jp)
+	}
+	if projection != "" {
+		if !strings.Contains(projection, "{") {
+			projection = "{" + projection + "}"
+		}
+		jp := jsonpath.New("projection").AllowMissingKeys(true)
+		if err := jp.Parse(projection); err != nil {
+			return nil, fmt.Errorf("parsing projection %q: %w", projection, err)
+		}
+		q.projection = jp
+	}
+	return q, nil
+}
+
+// HasProjection reports whether the query prints selected fields rather
+// than whole attestations.
+func (q *Query) HasProjection() bool {
+	return q != nil && q.projection != nil
+}
+
+// Matches reports whether the statement matches all filters.
+func (q *Query) Matches(statement []byte) (bool, error) {
+	if q == nil || len(q.filters) == 0 {
+		return true, nil
+	}
+	data, err := decodeStatement(statement)
+	if err != nil {
+		return false, err
+	}
+	for _, jp := range q.filters {
+		results, err := jp.FindResults([]any{data})
+		if err != nil {
+			return false, fmt.Errorf("evaluating filter: %w", err)
+		}
+		if len(results) == 0 || len(results[0]) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Project writes the projection of the statement to w, followed by a
+// newline.
+func (q *Query) Project(w io.Writer, statement []byte) error {
+	data, err := decodeStatement(statement)
+	if err != nil {
+		return err
+	}
+	if err := q.projection.Execute(w, data); err != nil {
+		return fmt.Errorf("evaluating projection: %w", err)
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func decodeStatement(statement []byte) (any, error) {
+	var data any
+	if err := json.Unmarshal(statement, &data); err != nil {
+		return nil, fmt.Errorf("decoding statement: %w", err)
+	}
+	return integers(data), nil
+}
+
+// integers converts whole JSON numbers to int64 so filters can compare them
+// with integer literals, which JSONPath does not compare with floats.
+func integers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = integers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = integers(e)
+		}
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+	}
+	return v
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"bytes"
+	"testing"
+)
+
+const queryStatement = `{
+	"_type": "https://in-toto.io/Statement/v1",
+	"predicateType": "https://cosign.sigstore.dev/attestation/vuln/v1",
+	"predicate": {
+		"scanner": {"uri": "pkg:github/aquasecurity/trivy@244fd47", "result": {"count": 3}},
+		"metadata": {"scanFinishedOn": "2024-05-01T00:00:00Z"}
+	}
+}`
+
+func TestQuery(t *testing.T) {
+	for _, tt := range []struct {
+		filters []string
+		want    bool
+	}{
+		{nil, true},
+		{[]string{`@.predicate.scanner.uri=="pkg:github/aquasecurity/trivy@244fd47"`}, true},
+		{[]string{`@.predicate.scanner.uri=="pkg:github/anchore/grype"`}, false},
+		{[]string{`@.predicate.metadata.scanFinishedOn>"2024-01-01T00:00:00Z"`}, true},
+		{[]string{`@.predicate.metadata.scanFinishedOn>"2024-06-01T00:00:00Z"`}, false},
+		{[]string{`@.predicate.scanner.result.count>2`}, true},
+		{[]string{`@.predicate.scanner.result.count<=2`}, false},
+		{[]string{`@.predicate.scanner`, `@.predicate.missing=="x"`}, false},
+		{[]string{`@.predicate.metadata`}, true},
+	} {
+		q, err := NewQuery(tt.filters, "")
+		if err != nil {
+			t.Fatalf("NewQuery(%v) error = %v", tt.filters, err)
+		}
+		got, err := q.Matches([]byte(queryStatement))
+		if err != nil || got != tt.want {
+			t.Errorf("Matches(%v) = %v, %v, want %v", tt.filters, got, err, tt.want)
+		}
+	}
+
+	if _, err := NewQuery([]string{`@.a==`}, ""); err == nil {
+		t.Error("expected error for an invalid filter")
+	}
+	var nilQuery *Query
+	if ok, err := nilQuery.Matches([]byte("not json")); !ok || err != nil || nilQuery.HasProjection() {
+		t.Error("a nil query should match everything")
+	}
+}
+
+func TestQueryProject(t *testing.T) {
+	for _, tt := range []struct {
+		projection, want string
+	}{
+		{"{.predicate.scanner.uri}", "pkg:github/aquasecurity/trivy@244fd47\n"},
+		{".predicate.scanner.result.count", "3\n"},
+		{"{.predicateType} {.predicate.metadata.scanFinishedOn}", "https://cosign.sigstore.dev/attestation/vuln/v1 2024-05-01T00:00:00Z\n"},
+		{"{.predicate.missing}", "\n"},
+	} {
+		q, err := NewQuery(nil, tt.projection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !q.HasProjection() {
+			t.Fatal("expected a projection")
+		}
+		var buf bytes.Buffer
+		if err := q.Project(&buf, []byte(queryStatement)); err != nil {
+			t.Fatalf("Project(%s) error = %v", tt.projection, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("Project(%s) = %q, want %q", tt.projection, buf.String(), tt.want)
+		}
+	}
+}
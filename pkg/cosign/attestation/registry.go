@@ -0,0 +1,365 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	slsa02_attest "github.com/in-toto/attestation/go/predicates/provenance/v02"
+	slsa1_attest "github.com/in-toto/attestation/go/predicates/provenance/v1"
+	release_attest "github.com/in-toto/attestation/go/predicates/release/v02"
+	testresult_attest "github.com/in-toto/attestation/go/predicates/test_result/v0"
+	vsa_attest "github.com/in-toto/attestation/go/predicates/vsa/v1"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	slsa02 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// PredicateType describes a predicate type that attestations can be created
+// and verified for.
+type PredicateType struct {
+	// Name is the short name accepted by --type, e.g. "slsaprovenance1".
+	Name string
+	// URI is the in-toto predicateType of the statement.
+	URI string
+	// AltURIs are other predicate types statements of this type may carry,
+	// such as versioned URIs.
+	AltURIs []string
+	// Validate, if set, rejects malformed predicates before a statement is
+	// generated.
+	Validate func(predicate []byte) error
+
+	// generate builds the statement for built-in types. Other types embed
+	// the predicate JSON as is.
+	generate func(predicate []byte, digest, repo string) (*Statement, error)
+}
+
+// Matches reports whether a statement with the given predicate type is of
+// this type.
+func (pt PredicateType) Matches(uri string) bool {
+	return uri == pt.URI || slices.Contains(pt.AltURIs, uri)
+}
+
+// Registry holds predicate types by short name.
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]PredicateType
+	names []string
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{types: map[string]PredicateType{}}
+}
+
+// Register adds pt to the registry. Names must be unique and URIs must be
+// valid absolute URIs.
+func (r *Registry) Register(pt PredicateType) error {
+	if pt.Name == "" {
+		return errors.New("predicate type name is required")
+	}
+	if _, err := url.ParseRequestURI(pt.URI); err != nil {
+		return fmt.Errorf("predicate type %s: invalid URI %q", pt.Name, pt.URI)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[pt.Name]; ok {
+		return fmt.Errorf("predicate type %s is already registered", pt.Name)
+	}
+	r.types[pt.Name] = pt
+	r.names = append(r.names, pt.Name)
+	return nil
+}
+
+// Lookup returns the predicate type registered under name.
+func (r *Registry) Lookup(name string) (PredicateType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	pt, ok := r.types[name]
+	return pt, ok
+}
+
+// LookupURI returns the first predicate type matching uri.
+func (r *Registry) LookupURI(uri string) (PredicateType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, name := range r.names {
+		if pt := r.types[name]; pt.Matches(uri) {
+			return pt, true
+		}
+	}
+	return PredicateType{}, false
+}
+
+// ResolveURI returns the URI of the predicate type registered under t, or t
+// itself if it is a valid URI.
+func (r *Registry) ResolveURI(t string) (string, error) {
+	if pt, ok := r.Lookup(t); ok {
+		return pt.URI, nil
+	}
+	if _, err := url.ParseRequestURI(t); err != nil {
+		return "", fmt.Errorf("invalid predicate type: %s", t)
+	}
+	return t, nil
+}
+
+// MatchesPredicateType reports whether a statement with predicate type uri
+// matches t, which is a short name or a predicate type URI. Canonical URIs
+// of registered types also match their alternative URIs.
+func (r *Registry) MatchesPredicateType(t, uri string) bool {
+	if pt, ok := r.Lookup(t); ok {
+		return pt.Matches(uri)
+	}
+	if pt, ok := r.LookupURI(t); ok && pt.URI == t {
+		return pt.Matches(uri)
+	}
+	return t == uri
+}
+
+// Names returns the registered short names in registration order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.names...)
+}
+
+// RegisterSchema registers a predicate type whose predicates must validate
+// against the given JSON Schema. The predicate type URI is taken from the
+// schema's $id (or id) keyword.
+func (r *Registry) RegisterSchema(name string, schema []byte) error {
+	var ids struct {
+		DollarID string `json:"$id"`
+		ID       string `json:"id"`
+	}
+	if err := json.Unmarshal(schema, &ids); err != nil {
+		return fmt.Errorf("parsing schema for %s: %w", name, err)
+	}
+	uri := ids.DollarID
+	if uri == "" {
+		uri = ids.ID
+	}
+	if uri == "" {
+		return fmt.Errorf("schema for %s has no $id to use as the predicate type URI", name)
+	}
+	s := &spec.Schema{}
+	if err := json.Unmarshal(schema, s); err != nil {
+		return fmt.Errorf("parsing schema for %s: %w", name, err)
+	}
+	if err := spec.ExpandSchema(s, s, nil); err != nil {
+		return fmt.Errorf("resolving schema for %s: %w", name, err)
+	}
+	return r.Register(PredicateType{
+		Name:     name,
+		URI:      strings.TrimSuffix(uri, "#"),
+		Validate: schemaValidator(s),
+	})
+}
+
+// RegisterSchemaFile is RegisterSchema with the schema read from path.
+func (r *Registry) RegisterSchemaFile(name, path string) error {
+	schema, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("reading schema for %s: %w", name, err)
+	}
+	return r.RegisterSchema(name, schema)
+}
+
+func schemaValidator(s *spec.Schema) func([]byte) error {
+	return func(predicate []byte) error {
+		var data any
+		if err := json.Unmarshal(predicate, &data); err != nil {
+			return err
+		}
+		return validate.AgainstSchema(s, data, strfmt.Default)
+	}
+}
+
+// defaultRegistry holds the built-in predicate types and any registered by
+// the caller.
+var defaultRegistry = newBuiltinRegistry()
+
+// Register adds pt to the default registry.
+func Register(pt PredicateType) error {
+	return defaultRegistry.Register(pt)
+}
+
+// RegisterSchemaFile registers a JSON Schema predicate type in the default
+// registry.
+func RegisterSchemaFile(name, path string) error {
+	return defaultRegistry.RegisterSchemaFile(name, path)
+}
+
+// Lookup returns the predicate type registered under name in the default
+// registry.
+func Lookup(name string) (PredicateType, bool) {
+	return defaultRegistry.Lookup(name)
+}
+
+// ResolvePredicateType returns the URI of the predicate type registered under
+// t in the default registry, or t itself if it is a valid URI.
+func ResolvePredicateType(t string) (string, error) {
+	return defaultRegistry.ResolveURI(t)
+}
+
+// MatchesPredicateType reports whether a statement with predicate type uri
+// matches t in the default registry.
+func MatchesPredicateType(t, uri string) bool {
+	return defaultRegistry.MatchesPredicateType(t, uri)
+}
+
+// PredicateTypeNames returns the short names in the default registry.
+func PredicateTypeNames() []string {
+	return defaultRegistry.Names()
+}
+
+func newBuiltinRegistry() *Registry {
+	r := NewRegistry()
+	for _, pt := range []PredicateType{
+		{
+			Name:     "slsaprovenance",
+			URI:      slsa02.PredicateSLSAProvenance,
+			Validate: requiredFields(reflect.TypeOf(&slsa02_attest.Provenance{})),
+			generate: generateSLSAProvenanceStatementSLSA02,
+		},
+		{
+			Name:     "slsaprovenance02",
+			URI:      slsa02.PredicateSLSAProvenance,
+			Validate: requiredFields(reflect.TypeOf(&slsa02_attest.Provenance{})),
+			generate: generateSLSAProvenanceStatementSLSA02,
+		},
+		{
+			Name:     "slsaprovenance1",
+			URI:      slsa1.PredicateSLSAProvenance,
+			Validate: requiredFields(reflect.TypeOf(&slsa1_attest.Provenance{})),
+			generate: generateSLSAProvenanceStatementSLSA1,
+		},
+		{
+			Name:     "slsaprovenance11",
+			URI:      slsa1.PredicateSLSAProvenance,
+			Validate: validateSLSAProvenanceV11,
+			generate: generateSLSAProvenanceStatementSLSA11,
+		},
+		{
+			Name:     "link",
+			URI:      in_toto.PredicateLinkV1,
+			Validate: requiredFields(reflect.TypeOf(in_toto.Link{})),
+			generate: generateLinkStatement,
+		},
+		{
+			Name:    "spdx",
+			URI:     in_toto.PredicateSPDX,
+			AltURIs: spdxURIs,
+			generate: func(predicate []byte, digest, repo string) (*Statement, error) {
+				return generateSPDXStatement(predicate, digest, repo, false)
+			},
+		},
+		{
+			Name:     "spdxjson",
+			URI:      in_toto.PredicateSPDX,
+			AltURIs:  spdxURIs,
+			Validate: validateSPDXJSON,
+			generate: func(predicate []byte, digest, repo string) (*Statement, error) {
+				return generateSPDXStatement(predicate, digest, repo, true)
+			},
+		},
+		{
+			Name:     "cyclonedx",
+			URI:      in_toto.PredicateCycloneDX,
+			AltURIs:  cycloneDXURIs(),
+			Validate: validateCycloneDX,
+			generate: generateCycloneDXStatement,
+		},
+		{
+			Name:     "vuln",
+			URI:      CosignVulnProvenanceV01,
+			Validate: requiredFields(reflect.TypeOf(CosignVulnPredicate{})),
+			generate: generateVulnStatement,
+		},
+		{
+			Name:     "openvex",
+			URI:      OpenVexNamespace,
+			Validate: requiredString("@context", OpenVexNamespace),
+			generate: generateOpenVexStatement,
+		},
+		{
+			Name:     "vsa",
+			URI:      SLSAVerificationSummaryV1,
+			Validate: validateVerificationSummary,
+			generate: generateV1Statement(SLSAVerificationSummaryV1, func() proto.Message { return &vsa_attest.VerificationSummary{} }),
+		},
+		{
+			Name:     "testresult",
+			URI:      TestResultV01,
+			Validate: validateTestResult,
+			generate: generateV1Statement(TestResultV01, func() proto.Message { return &testresult_attest.TestResult{} }),
+		},
+		{
+			Name:     "release",
+			URI:      ReleaseV02,
+			Validate: validateRelease,
+			generate: generateV1Statement(ReleaseV02, func() proto.Message { return &release_attest.Release{} }),
+		},
+		{
+			Name: "custom",
+			URI:  CosignCustomProvenanceV01,
+		},
+	} {
+		if err := r.Register(pt); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// requiredFields checks that the predicate has every non-optional JSON field
+// of typ.
+func requiredFields(typ reflect.Type) func([]byte) error {
+	return func(predicate []byte) error {
+		return checkRequiredJSONFields(predicate, typ)
+	}
+}
+
+// requiredString checks that the predicate is a JSON object with a string
+// field named key, starting with prefix.
+func requiredString(key, prefix string) func([]byte) error {
+	return func(predicate []byte) error {
+		var data map[string]any
+		if err := json.Unmarshal(predicate, &data); err != nil {
+			return err
+		}
+		v, ok := data[key].(string)
+		if !ok {
+			return fmt.Errorf("required field %s missing", key)
+		}
+		if !strings.HasPrefix(v, prefix) {
+			return fmt.Errorf("field %s is %q, expected %q", key, v, prefix)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testResultSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"$id": "https://example.com/TestResult/v1",
+	"type": "object",
+	"required": ["result"],
+	"properties": {
+		"result": {"enum": ["PASSED", "FAILED"]},
+		"passedTests": {"type": "array", "items": {"$ref": "#/definitions/test"}}
+	},
+	"definitions": {
+		"test": {"type": "string"}
+	}
+}`
+
+func TestRegistrySchema(t *testing.T) {
+	r := NewRegistry()
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(testResultSchema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterSchemaFile("testresult", path); err != nil {
+		t.Fatalf("RegisterSchemaFile() error = %v", err)
+	}
+	if err := r.RegisterSchemaFile("testresult", path); err == nil {
+		t.Error("expected error registering a name twice")
+	}
+
+	uri, err := r.ResolveURI("testresult")
+	if err != nil || uri != "https://example.com/TestResult/v1" {
+		t.Errorf("ResolveURI() = %q, %v", uri, err)
+	}
+	pt, ok := r.LookupURI(uri)
+	if !ok || pt.Name != "testresult" {
+		t.Errorf("LookupURI() = %+v, %v", pt, ok)
+	}
+
+	for _, tt := range []struct {
+		predicate string
+		wantErr   bool
+	}{
+		{`{"result":"PASSED","passedTests":["a","b"]}`, false},
+		{`{"result":"SKIPPED"}`, true},
+		{`{"passedTests":[]}`, true},
+		{`{"result":"PASSED","passedTests":[1]}`, true},
+		{`not json`, true},
+	} {
+		if err := pt.Validate([]byte(tt.predicate)); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%s) error = %v, wantErr %v", tt.predicate, err, tt.wantErr)
+		}
+	}
+
+	if err := r.RegisterSchema("noid", []byte(`{"type":"object"}`)); err == nil {
+		t.Error("expected error for a schema without $id")
+	}
+}
+
+func TestRegistryResolveURI(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"slsaprovenance1", "https://slsa.dev/provenance/v1", false},
+		{"custom", CosignCustomProvenanceV01, false},
+		{"https://example.com/predicate/v1", "https://example.com/predicate/v1", false},
+		{"notatype", "", true},
+	} {
+		got, err := ResolvePredicateType(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ResolvePredicateType(%s) = %q, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestGenerateStatementValidation(t *testing.T) {
+	for _, tt := range []struct {
+		predType  string
+		predicate string
+	}{
+		{"cyclonedx", `{"bomFormat":"SPDX"}`},
+		{"spdxjson", `{"name":"doc"}`},
+		{"openvex", `{"@id":"some-id"}`},
+		{"vuln", `{"scanner":{"uri":"test-scanner"}}`},
+		{"link", `{"name":"test-link"}`},
+		{"https://openvex.dev/ns", `{"@context":"https://example.com"}`},
+		{"cyclonedx", `{"bomFormat":"CycloneDX","specVersion":"2.0"}`},
+		{"spdxjson", `{"@context":"https://spdx.org/rdf/3.0.1/spdx-context.jsonld"}`},
+		{"slsaprovenance11", `{"buildDefinition":{"buildType":"test"},"runDetails":{"builder":{"id":"x"}}}`},
+		{"vsa", `{"verifier":{"id":"v"},"timeVerified":"2024-03-11T10:00:00Z","resourceUri":"r","policy":{"uri":"p"},"verificationResult":"MAYBE","verifiedLevels":["SLSA_BUILD_LEVEL_1"]}`},
+		{"testresult", `{"result":"PASSED"}`},
+		{"release", `{"purl":"npm/example"}`},
+	} {
+		t.Run(tt.predType, func(t *testing.T) {
+			_, err := GenerateStatement(GenerateOpts{
+				Predicate: strings.NewReader(tt.predicate),
+				Type:      tt.predType,
+			})
+			if err == nil || !strings.Contains(err.Error(), "invalid") {
+				t.Errorf("GenerateStatement() error = %v, want validation error", err)
+			}
+		})
+	}
+}
+
+func TestMatchesPredicateType(t *testing.T) {
+	for _, tt := range []struct {
+		t, uri string
+		want   bool
+	}{
+		{"cyclonedx", "https://cyclonedx.org/bom", true},
+		{"cyclonedx", "https://cyclonedx.org/bom/v1.6", true},
+		{"https://cyclonedx.org/bom", "https://cyclonedx.org/bom/v1.5", true},
+		{"https://cyclonedx.org/bom/v1.6", "https://cyclonedx.org/bom", false},
+		{"spdxjson", "https://spdx.dev/Document/v3", true},
+		{"vsa", "https://slsa.dev/verification_summary/v1", true},
+		{"slsaprovenance11", "https://slsa.dev/provenance/v1", true},
+		{"testresult", "https://in-toto.io/attestation/release/v0.2", false},
+		{"https://example.com/predicate/v1", "https://example.com/predicate/v1", true},
+	} {
+		if got := MatchesPredicateType(tt.t, tt.uri); got != tt.want {
+			t.Errorf("MatchesPredicateType(%s, %s) = %v, want %v", tt.t, tt.uri, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,526 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SBOMFormat is a format ParseSBOM recognizes.
+type SBOMFormat string
+
+const (
+	SBOMFormatSPDX          SBOMFormat = "spdx"
+	SBOMFormatSPDXJSON      SBOMFormat = "spdxjson"
+	SBOMFormatSPDX3         SBOMFormat = "spdx3"
+	SBOMFormatCycloneDXJSON SBOMFormat = "cyclonedx"
+	SBOMFormatCycloneDXXML  SBOMFormat = "cyclonedxxml"
+	SBOMFormatSyft          SBOMFormat = "syft"
+)
+
+// SBOM conversion targets of `cosign download attestation --convert`.
+const (
+	// SBOMConvertCycloneDX converts to a CycloneDX 1.6 JSON BOM.
+	SBOMConvertCycloneDX = "cyclonedx"
+	// SBOMConvertComponents converts to the normalized SBOM component list.
+	SBOMConvertComponents = "components"
+)
+
+// SBOMConversions returns the accepted conversion targets.
+func SBOMConversions() []string {
+	return []string{SBOMConvertCycloneDX, SBOMConvertComponents}
+}
+
+// ErrUnknownSBOMFormat is returned when a document is not an SBOM in a
+// recognized format.
+var ErrUnknownSBOMFormat = errors.New("unrecognized SBOM format")
+
+// SBOM is the normalized component list of an SBOM, the same whatever format
+// the producer chose, so that a policy such as "no GPL-3.0 components" can
+// be written once.
+type SBOM struct {
+	// Format is the format of the original document.
+	Format     SBOMFormat  `json:"format"`
+	Components []Component `json:"components"`
+}
+
+// Component is a package listed in an SBOM.
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+	// LicenseExpression is the SPDX license expression of the component,
+	// with several licenses joined with AND.
+	LicenseExpression string `json:"licenseExpression,omitempty"`
+	// Licenses are the license identifiers in LicenseExpression.
+	Licenses []string `json:"licenses"`
+}
+
+func newComponent(name, version, purl string, licenses ...string) Component {
+	var expressions []string
+	for _, l := range licenses {
+		if l = strings.TrimSpace(l); l != "" && l != "NOASSERTION" && l != "NONE" {
+			expressions = append(expressions, l)
+		}
+	}
+	expression := strings.Join(expressions, " AND ")
+	if len(expressions) > 1 {
+		for i, e := range expressions {
+			if strings.Contains(e, " ") {
+				expressions[i] = "(" + e + ")"
+			}
+		}
+		expression = strings.Join(expressions, " AND ")
+	}
+	return Component{
+		Name:              name,
+		Version:           version,
+		PURL:              purl,
+		LicenseExpression: expression,
+		Licenses:          licenseIDs(expression),
+	}
+}
+
+// licenseIDs returns the license identifiers of an SPDX license expression,
+// without operators and license exceptions.
+func licenseIDs(expression string) []string {
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expression))
+	ids := []string{}
+	for i := 0; i < len(fields); i++ {
+		switch strings.ToUpper(fields[i]) {
+		case "AND", "OR":
+		case "WITH":
+			i++
+		default:
+			if !slices.Contains(ids, fields[i]) {
+				ids = append(ids, fields[i])
+			}
+		}
+	}
+	return ids
+}
+
+// ParseSBOM detects the format of an SBOM and returns its components. SPDX
+// tag-value, SPDX 2 JSON, SPDX 3 JSON-LD, CycloneDX JSON and XML and Syft
+// JSON are recognized.
+func ParseSBOM(b []byte) (*SBOM, error) {
+	b = bytes.TrimSpace(b)
+	switch {
+	case bytes.HasPrefix(b, []byte("<")):
+		return parseCycloneDXXML(b)
+	case bytes.HasPrefix(b, []byte("{")):
+		var doc struct {
+			BOMFormat   string          `json:"bomFormat"`
+			SPDXVersion string          `json:"spdxVersion"`
+			Context     any             `json:"@context"`
+			Artifacts   json.RawMessage `json:"artifacts"`
+		}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, fmt.Errorf("parsing SBOM: %w", err)
+		}
+		switch {
+		case doc.BOMFormat == "CycloneDX":
+			return parseCycloneDXJSON(b)
+		case doc.SPDXVersion != "":
+			return parseSPDXJSON(b)
+		case isSPDX3Context(doc.Context):
+			return parseSPDX3(b)
+		case doc.Artifacts != nil:
+			return parseSyft(b)
+		}
+	case bytes.Contains(b, []byte("SPDXVersion:")):
+		return parseSPDXTagValue(b)
+	}
+	return nil, ErrUnknownSBOMFormat
+}
+
+// StatementSBOM returns the components of the SBOM in the predicate of an
+// in-toto statement. Predicates holding the document as a string, such as
+// SPDX tag-value or CycloneDX XML, are supported.
+func StatementSBOM(statement []byte) (*SBOM, error) {
+	var s struct {
+		Predicate json.RawMessage `json:"predicate"`
+	}
+	if err := json.Unmarshal(statement, &s); err != nil {
+		return nil, fmt.Errorf("decoding statement: %w", err)
+	}
+	predicate := s.Predicate
+	var text string
+	if err := json.Unmarshal(predicate, &text); err == nil {
+		predicate = []byte(text)
+	} else {
+		// Custom predicates wrap the document in a Data field.
+		var custom struct {
+			Data json.RawMessage `json:"Data"`
+		}
+		if err := json.Unmarshal(predicate, &custom); err == nil && custom.Data != nil {
+			if err := json.Unmarshal(custom.Data, &text); err == nil {
+				predicate = []byte(text)
+			} else {
+				predicate = custom.Data
+			}
+		}
+	}
+	return ParseSBOM(predicate)
+}
+
+// ConvertStatementSBOM returns the in-toto statement with its SBOM predicate
+// replaced by the SBOM in the target format, so that a policy can be
+// evaluated against the subjects and the converted SBOM together.
+func ConvertStatementSBOM(statement []byte, target string) ([]byte, error) {
+	sbom, err := StatementSBOM(statement)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := sbom.Convert(target)
+	if err != nil {
+		return nil, err
+	}
+	var s map[string]json.RawMessage
+	if err := json.Unmarshal(statement, &s); err != nil {
+		return nil, fmt.Errorf("decoding statement: %w", err)
+	}
+	s["predicate"] = converted
+	return json.Marshal(s)
+}
+
+// Convert returns the SBOM in the target format as JSON.
+func (s *SBOM) Convert(target string) ([]byte, error) {
+	switch target {
+	case SBOMConvertComponents:
+		return json.Marshal(s)
+	case SBOMConvertCycloneDX:
+		return json.Marshal(s.cycloneDX())
+	}
+	return nil, fmt.Errorf("invalid SBOM conversion %q, expected one of %s", target, strings.Join(SBOMConversions(), ", "))
+}
+
+type cdxLicense struct {
+	License    *cdxLicenseID `json:"license,omitempty"`
+	Expression string        `json:"expression,omitempty"`
+}
+
+type cdxLicenseID struct {
+	ID   string `json:"id,omitempty" xml:"id"`
+	Name string `json:"name,omitempty" xml:"name"`
+}
+
+type cdxComponent struct {
+	Type       string         `json:"type"`
+	BOMRef     string         `json:"bom-ref,omitempty"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	PURL       string         `json:"purl,omitempty"`
+	Licenses   []cdxLicense   `json:"licenses,omitempty"`
+	Components []cdxComponent `json:"components,omitempty"`
+}
+
+type cdxBOM struct {
+	BOMFormat   string         `json:"bomFormat"`
+	SpecVersion string         `json:"specVersion"`
+	Version     int            `json:"version"`
+	Components  []cdxComponent `json:"components"`
+}
+
+func (s *SBOM) cycloneDX() cdxBOM {
+	bom := cdxBOM{BOMFormat: "CycloneDX", SpecVersion: "1.6", Version: 1, Components: []cdxComponent{}}
+	// bom-refs must be unique, and a package may be listed more than once.
+	refs := map[string]bool{}
+	for i, c := range s.Components {
+		component := cdxComponent{
+			Type:    "library",
+			BOMRef:  c.PURL,
+			Name:    c.Name,
+			Version: c.Version,
+			PURL:    c.PURL,
+		}
+		if component.BOMRef == "" || refs[component.BOMRef] {
+			component.BOMRef = fmt.Sprintf("component-%d", i)
+		}
+		refs[component.BOMRef] = true
+		if c.LicenseExpression != "" {
+			component.Licenses = []cdxLicense{{Expression: c.LicenseExpression}}
+		}
+		bom.Components = append(bom.Components, component)
+	}
+	return bom
+}
+
+func cycloneDXComponents(components []cdxComponent) []Component {
+	var flat []Component
+	for _, c := range components {
+		var licenses []string
+		for _, l := range c.Licenses {
+			switch {
+			case l.Expression != "":
+				licenses = append(licenses, l.Expression)
+			case l.License != nil && l.License.ID != "":
+				licenses = append(licenses, l.License.ID)
+			case l.License != nil:
+				licenses = append(licenses, l.License.Name)
+			}
+		}
+		flat = append(flat, newComponent(c.Name, c.Version, c.PURL, licenses...))
+		flat = append(flat, cycloneDXComponents(c.Components)...)
+	}
+	return flat
+}
+
+func parseCycloneDXJSON(b []byte) (*SBOM, error) {
+	var bom cdxBOM
+	if err := json.Unmarshal(b, &bom); err != nil {
+		return nil, fmt.Errorf("parsing CycloneDX JSON: %w", err)
+	}
+	return &SBOM{Format: SBOMFormatCycloneDXJSON, Components: nonNil(cycloneDXComponents(bom.Components))}, nil
+}
+
+type cdxXMLComponent struct {
+	Name     string `xml:"name"`
+	Version  string `xml:"version"`
+	PURL     string `xml:"purl"`
+	Licenses struct {
+		License    []cdxLicenseID `xml:"license"`
+		Expression []string       `xml:"expression"`
+	} `xml:"licenses"`
+	Components []cdxXMLComponent `xml:"components>component"`
+}
+
+func (c cdxXMLComponent) toJSON() cdxComponent {
+	component := cdxComponent{Name: c.Name, Version: c.Version, PURL: c.PURL}
+	for _, l := range c.Licenses.License {
+		component.Licenses = append(component.Licenses, cdxLicense{License: &l})
+	}
+	for _, e := range c.Licenses.Expression {
+		component.Licenses = append(component.Licenses, cdxLicense{Expression: e})
+	}
+	for _, nested := range c.Components {
+		component.Components = append(component.Components, nested.toJSON())
+	}
+	return component
+}
+
+func parseCycloneDXXML(b []byte) (*SBOM, error) {
+	var bom struct {
+		XMLName    xml.Name          `xml:"bom"`
+		Components []cdxXMLComponent `xml:"components>component"`
+	}
+	if err := xml.Unmarshal(b, &bom); err != nil {
+		return nil, fmt.Errorf("parsing CycloneDX XML: %w", err)
+	}
+	components := make([]cdxComponent, 0, len(bom.Components))
+	for _, c := range bom.Components {
+		components = append(components, c.toJSON())
+	}
+	return &SBOM{Format: SBOMFormatCycloneDXXML, Components: nonNil(cycloneDXComponents(components))}, nil
+}
+
+// spdxLicense returns the concluded license of an SPDX package, or the
+// declared one when it was not concluded.
+func spdxLicense(concluded, declared string) string {
+	if concluded != "" && concluded != "NOASSERTION" {
+		return concluded
+	}
+	return declared
+}
+
+func parseSPDXJSON(b []byte) (*SBOM, error) {
+	var doc struct {
+		Packages []struct {
+			Name             string `json:"name"`
+			VersionInfo      string `json:"versionInfo"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+			ExternalRefs     []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("parsing SPDX JSON: %w", err)
+	}
+	sbom := &SBOM{Format: SBOMFormatSPDXJSON, Components: []Component{}}
+	for _, p := range doc.Packages {
+		var purl string
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				purl = ref.ReferenceLocator
+				break
+			}
+		}
+		sbom.Components = append(sbom.Components, newComponent(p.Name, p.VersionInfo, purl, spdxLicense(p.LicenseConcluded, p.LicenseDeclared)))
+	}
+	return sbom, nil
+}
+
+func parseSPDX3(b []byte) (*SBOM, error) {
+	var doc struct {
+		Graph []struct {
+			Type              string   `json:"type"`
+			SPDXID            string   `json:"spdxId"`
+			Name              string   `json:"name"`
+			PackageVersion    string   `json:"software_packageVersion"`
+			PackageURL        string   `json:"software_packageUrl"`
+			RelationshipType  string   `json:"relationshipType"`
+			From              string   `json:"from"`
+			To                []string `json:"to"`
+			LicenseExpression string   `json:"simplelicensing_licenseExpression"`
+		} `json:"@graph"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("parsing SPDX 3 document: %w", err)
+	}
+	expressions := map[string]string{}
+	concluded := map[string][]string{}
+	declared := map[string][]string{}
+	for _, e := range doc.Graph {
+		switch {
+		case e.LicenseExpression != "":
+			expressions[e.SPDXID] = e.LicenseExpression
+		case e.RelationshipType == "hasConcludedLicense":
+			concluded[e.From] = append(concluded[e.From], e.To...)
+		case e.RelationshipType == "hasDeclaredLicense":
+			declared[e.From] = append(declared[e.From], e.To...)
+		}
+	}
+	resolve := func(ids []string) []string {
+		var licenses []string
+		for _, id := range ids {
+			licenses = append(licenses, expressions[id])
+		}
+		return licenses
+	}
+	sbom := &SBOM{Format: SBOMFormatSPDX3, Components: []Component{}}
+	for _, e := range doc.Graph {
+		if e.Type != "software_Package" {
+			continue
+		}
+		licenses := resolve(concluded[e.SPDXID])
+		if len(licenses) == 0 {
+			licenses = resolve(declared[e.SPDXID])
+		}
+		sbom.Components = append(sbom.Components, newComponent(e.Name, e.PackageVersion, e.PackageURL, licenses...))
+	}
+	return sbom, nil
+}
+
+func parseSPDXTagValue(b []byte) (*SBOM, error) {
+	sbom := &SBOM{Format: SBOMFormatSPDX, Components: []Component{}}
+	type pkg struct {
+		name, version, purl, concluded, declared string
+	}
+	var current *pkg
+	flush := func() {
+		if current != nil {
+			sbom.Components = append(sbom.Components, newComponent(current.name, current.version, current.purl, spdxLicense(current.concluded, current.declared)))
+		}
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, len(b)+1)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		// Skip multi-line <text> values.
+		if strings.HasPrefix(value, "<text>") && !strings.Contains(value, "</text>") {
+			for scanner.Scan() && !strings.Contains(scanner.Text(), "</text>") {
+			}
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "PackageName":
+			flush()
+			current = &pkg{name: value}
+		case "FileName", "SnippetSPDXID":
+			// Files and snippets end the package information.
+			flush()
+			current = nil
+		}
+		if current == nil {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "PackageVersion":
+			current.version = value
+		case "PackageLicenseConcluded":
+			current.concluded = value
+		case "PackageLicenseDeclared":
+			current.declared = value
+		case "ExternalRef":
+			if f := strings.Fields(value); len(f) == 3 && f[1] == "purl" && current.purl == "" {
+				current.purl = f[2]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parsing SPDX tag-value document: %w", err)
+	}
+	flush()
+	return sbom, nil
+}
+
+func parseSyft(b []byte) (*SBOM, error) {
+	var doc struct {
+		Artifacts []struct {
+			Name     string            `json:"name"`
+			Version  string            `json:"version"`
+			PURL     string            `json:"purl"`
+			Licenses []json.RawMessage `json:"licenses"`
+		} `json:"artifacts"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("parsing Syft JSON: %w", err)
+	}
+	sbom := &SBOM{Format: SBOMFormatSyft, Components: []Component{}}
+	for _, a := range doc.Artifacts {
+		var licenses []string
+		for _, raw := range a.Licenses {
+			// Older schemas list license names, newer ones objects.
+			var name string
+			if err := json.Unmarshal(raw, &name); err == nil {
+				licenses = append(licenses, name)
+				continue
+			}
+			var license struct {
+				Value          string `json:"value"`
+				SPDXExpression string `json:"spdxExpression"`
+			}
+			if err := json.Unmarshal(raw, &license); err != nil {
+				return nil, fmt.Errorf("parsing license of %s: %w", a.Name, err)
+			}
+			licenses = append(licenses, cmp.Or(license.SPDXExpression, license.Value))
+		}
+		sbom.Components = append(sbom.Components, newComponent(a.Name, a.Version, a.PURL, licenses...))
+	}
+	return sbom, nil
+}
+
+func nonNil(components []Component) []Component {
+	if components == nil {
+		return []Component{}
+	}
+	return components
+}
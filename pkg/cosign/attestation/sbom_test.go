@@ -0,0 +1,237 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const spdxTagValueSBOM = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+DocumentComment: <text>Generated
+over two lines</text>
+
+PackageName: openssl
+PackageVersion: 3.1.0
+PackageLicenseConcluded: NOASSERTION
+PackageLicenseDeclared: Apache-2.0
+ExternalRef: PACKAGE-MANAGER purl pkg:apk/alpine/openssl@3.1.0
+
+PackageName: readline
+PackageVersion: 8.2
+PackageLicenseConcluded: GPL-3.0-or-later WITH Bison-exception-2.2
+
+FileName: ./README
+LicenseConcluded: MIT
+`
+
+const spdxJSONSBOM = `{
+	"spdxVersion": "SPDX-2.3",
+	"packages": [
+		{"name": "openssl", "versionInfo": "3.1.0", "licenseDeclared": "Apache-2.0",
+		 "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:apk/alpine/openssl@3.1.0"}]},
+		{"name": "readline", "versionInfo": "8.2", "licenseConcluded": "GPL-3.0-or-later WITH Bison-exception-2.2", "licenseDeclared": "NOASSERTION"}
+	]
+}`
+
+const spdx3SBOM = `{
+	"@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld",
+	"@graph": [
+		{"type": "software_Package", "spdxId": "urn:openssl", "name": "openssl", "software_packageVersion": "3.1.0", "software_packageUrl": "pkg:apk/alpine/openssl@3.1.0"},
+		{"type": "software_Package", "spdxId": "urn:readline", "name": "readline", "software_packageVersion": "8.2"},
+		{"type": "simplelicensing_LicenseExpression", "spdxId": "urn:apache", "simplelicensing_licenseExpression": "Apache-2.0"},
+		{"type": "simplelicensing_LicenseExpression", "spdxId": "urn:gpl", "simplelicensing_licenseExpression": "GPL-3.0-or-later WITH Bison-exception-2.2"},
+		{"type": "Relationship", "relationshipType": "hasDeclaredLicense", "from": "urn:openssl", "to": ["urn:apache"]},
+		{"type": "Relationship", "relationshipType": "hasConcludedLicense", "from": "urn:readline", "to": ["urn:gpl"]}
+	]
+}`
+
+const cycloneDXJSONSBOM = `{
+	"bomFormat": "CycloneDX",
+	"specVersion": "1.5",
+	"components": [
+		{"type": "library", "name": "openssl", "version": "3.1.0", "purl": "pkg:apk/alpine/openssl@3.1.0", "licenses": [{"license": {"id": "Apache-2.0"}}],
+		 "components": [{"type": "library", "name": "readline", "version": "8.2", "licenses": [{"expression": "GPL-3.0-or-later WITH Bison-exception-2.2"}]}]}
+	]
+}`
+
+const cycloneDXXMLSBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.5" version="1">
+  <components>
+    <component type="library">
+      <name>openssl</name>
+      <version>3.1.0</version>
+      <purl>pkg:apk/alpine/openssl@3.1.0</purl>
+      <licenses><license><id>Apache-2.0</id></license></licenses>
+    </component>
+    <component type="library">
+      <name>readline</name>
+      <version>8.2</version>
+      <licenses><expression>GPL-3.0-or-later WITH Bison-exception-2.2</expression></licenses>
+    </component>
+  </components>
+</bom>`
+
+const syftSBOM = `{
+	"artifacts": [
+		{"name": "openssl", "version": "3.1.0", "purl": "pkg:apk/alpine/openssl@3.1.0", "licenses": ["Apache-2.0"]},
+		{"name": "readline", "version": "8.2", "licenses": [{"value": "GPL-3.0-or-later", "spdxExpression": "GPL-3.0-or-later WITH Bison-exception-2.2"}]}
+	],
+	"descriptor": {"name": "syft"}
+}`
+
+func TestParseSBOM(t *testing.T) {
+	want := []Component{
+		{Name: "openssl", Version: "3.1.0", PURL: "pkg:apk/alpine/openssl@3.1.0", LicenseExpression: "Apache-2.0", Licenses: []string{"Apache-2.0"}},
+		{Name: "readline", Version: "8.2", LicenseExpression: "GPL-3.0-or-later WITH Bison-exception-2.2", Licenses: []string{"GPL-3.0-or-later"}},
+	}
+	for _, tt := range []struct {
+		format SBOMFormat
+		doc    string
+	}{
+		{SBOMFormatSPDX, spdxTagValueSBOM},
+		{SBOMFormatSPDXJSON, spdxJSONSBOM},
+		{SBOMFormatSPDX3, spdx3SBOM},
+		{SBOMFormatCycloneDXJSON, cycloneDXJSONSBOM},
+		{SBOMFormatCycloneDXXML, cycloneDXXMLSBOM},
+		{SBOMFormatSyft, syftSBOM},
+	} {
+		t.Run(string(tt.format), func(t *testing.T) {
+			sbom, err := ParseSBOM([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("ParseSBOM() error = %v", err)
+			}
+			if sbom.Format != tt.format {
+				t.Errorf("Format = %s, want %s", sbom.Format, tt.format)
+			}
+			if !reflect.DeepEqual(sbom.Components, want) {
+				t.Errorf("Components = %+v, want %+v", sbom.Components, want)
+			}
+		})
+	}
+
+	for _, doc := range []string{`{"foo": "bar"}`, "hello", ""} {
+		if _, err := ParseSBOM([]byte(doc)); !errors.Is(err, ErrUnknownSBOMFormat) {
+			t.Errorf("ParseSBOM(%q) error = %v, want ErrUnknownSBOMFormat", doc, err)
+		}
+	}
+}
+
+func TestLicenseIDs(t *testing.T) {
+	got := licenseIDs("(MIT OR Apache-2.0) AND GPL-2.0-only WITH Classpath-exception-2.0 AND MIT")
+	if want := []string{"MIT", "Apache-2.0", "GPL-2.0-only"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("licenseIDs() = %v, want %v", got, want)
+	}
+	c := newComponent("a", "", "", "MIT OR BSD-3-Clause", "NOASSERTION", "ISC")
+	if c.LicenseExpression != "(MIT OR BSD-3-Clause) AND ISC" {
+		t.Errorf("LicenseExpression = %q", c.LicenseExpression)
+	}
+}
+
+func TestStatementSBOM(t *testing.T) {
+	tagValue, err := json.Marshal(map[string]any{"predicateType": "https://spdx.dev/Document", "predicate": spdxTagValueSBOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom, err := json.Marshal(map[string]any{"predicate": map[string]any{"Data": cycloneDXXMLSBOM, "Timestamp": "2024-01-01T00:00:00Z"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range [][]byte{
+		tagValue,
+		custom,
+		[]byte(`{"predicate": ` + syftSBOM + `}`),
+	} {
+		sbom, err := StatementSBOM(statement)
+		if err != nil {
+			t.Fatalf("StatementSBOM() error = %v", err)
+		}
+		if len(sbom.Components) != 2 {
+			t.Errorf("StatementSBOM() = %+v", sbom)
+		}
+	}
+	if _, err := StatementSBOM([]byte(`{"predicate": {"builder": {}}}`)); !errors.Is(err, ErrUnknownSBOMFormat) {
+		t.Errorf("StatementSBOM() error = %v, want ErrUnknownSBOMFormat", err)
+	}
+}
+
+func TestSBOMConvert(t *testing.T) {
+	sbom, err := ParseSBOM([]byte(spdxJSONSBOM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sbom.Components = append(sbom.Components, sbom.Components[0])
+
+	b, err := sbom.Convert(SBOMConvertCycloneDX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateCycloneDX(b); err != nil {
+		t.Errorf("converted BOM is invalid: %v", err)
+	}
+	var bom cdxBOM
+	if err := json.Unmarshal(b, &bom); err != nil {
+		t.Fatal(err)
+	}
+	if len(bom.Components) != 3 || bom.Components[0].BOMRef == bom.Components[2].BOMRef {
+		t.Errorf("unexpected components %+v", bom.Components)
+	}
+	converted, err := ParseSBOM(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(converted.Components, sbom.Components) {
+		t.Errorf("round trip = %+v, want %+v", converted.Components, sbom.Components)
+	}
+
+	b, err = sbom.Convert(SBOMConvertComponents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var components SBOM
+	if err := json.Unmarshal(b, &components); err != nil || components.Format != SBOMFormatSPDXJSON {
+		t.Errorf("Convert(components) = %s, %v", b, err)
+	}
+
+	if _, err := sbom.Convert("spdx"); err == nil {
+		t.Error("expected error for an unsupported conversion")
+	}
+}
+
+func TestConvertStatementSBOM(t *testing.T) {
+	statement := []byte(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"image","digest":{"sha256":"abc"}}],"predicateType":"https://syft.dev/bom","predicate":` + syftSBOM + `}`)
+	b, err := ConvertStatementSBOM(statement, SBOMConvertComponents)
+	if err != nil {
+		t.Fatalf("ConvertStatementSBOM() error = %v", err)
+	}
+	var got struct {
+		Subject       []map[string]any `json:"subject"`
+		PredicateType string           `json:"predicateType"`
+		Predicate     SBOM             `json:"predicate"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Subject) != 1 || got.PredicateType != "https://syft.dev/bom" || got.Predicate.Format != SBOMFormatSyft || len(got.Predicate.Components) != 2 {
+		t.Errorf("ConvertStatementSBOM() = %s", b)
+	}
+
+	if _, err := ConvertStatementSBOM([]byte(`{"predicate": {"builder": {}}}`), SBOMConvertComponents); !errors.Is(err, ErrUnknownSBOMFormat) {
+		t.Errorf("ConvertStatementSBOM() error = %v, want ErrUnknownSBOMFormat", err)
+	}
+}
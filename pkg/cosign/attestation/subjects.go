@@ -0,0 +1,117 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	in_toto_attest "github.com/in-toto/attestation/go/v1"
+)
+
+// Subject is a subject of a generated statement.
+type Subject struct {
+	Name string
+	// Digest maps digest algorithms, e.g. sha256, to hex encoded digests.
+	Digest map[string]string
+}
+
+// digestAlgorithms maps the length of hex encoded digests to the
+// algorithms producing them.
+var digestAlgorithms = map[int]string{64: "sha256", 96: "sha384", 128: "sha512"}
+
+// digestSubject matches NAME@ALGORITHM:HEX.
+var digestSubject = regexp.MustCompile(`^(.+)@(sha256|sha384|sha512):([0-9a-fA-F]+)$`)
+
+// bsdChecksum matches a line of a BSD style checksums file, as written by
+// `shasum --tag`: ALGORITHM (NAME) = HEX.
+var bsdChecksum = regexp.MustCompile(`^(SHA256|SHA384|SHA512) \((.+)\) = ([0-9a-fA-F]+)$`)
+
+// ParseDigestSubject parses a subject given as NAME@ALGORITHM:HEX, such as
+// a platform manifest ghcr.io/org/app@sha256:.... It reports false if s is
+// not of this form.
+func ParseDigestSubject(s string) (Subject, bool, error) {
+	m := digestSubject.FindStringSubmatch(s)
+	if m == nil {
+		return Subject{}, false, nil
+	}
+	subject, err := newSubject(m[1], m[2], m[3])
+	return subject, true, err
+}
+
+// ParseChecksums parses the subjects of a checksums file as written by
+// sha256sum, sha512sum or `shasum --tag`. The digest algorithm of
+// sha*sum lines is inferred from the digest length.
+func ParseChecksums(r io.Reader) ([]Subject, error) {
+	var subjects []Subject
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var subject Subject
+		var err error
+		if m := bsdChecksum.FindStringSubmatch(line); m != nil {
+			subject, err = newSubject(m[2], strings.ToLower(m[1]), m[3])
+		} else {
+			digest, name, ok := strings.Cut(line, " ")
+			if !ok {
+				return nil, fmt.Errorf("line %d: expected a digest and a file name", n)
+			}
+			// A leading '*' marks files hashed in binary mode.
+			name = strings.TrimPrefix(strings.TrimLeft(name, " "), "*")
+			algorithm, ok := digestAlgorithms[len(digest)]
+			if !ok {
+				return nil, fmt.Errorf("line %d: unrecognized digest length %d", n, len(digest))
+			}
+			subject, err = newSubject(name, algorithm, digest)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		subjects = append(subjects, subject)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(subjects) == 0 {
+		return nil, fmt.Errorf("no checksums found")
+	}
+	return subjects, nil
+}
+
+func newSubject(name, algorithm, digest string) (Subject, error) {
+	if name == "" {
+		return Subject{}, fmt.Errorf("subject has no name")
+	}
+	if _, err := hex.DecodeString(digest); err != nil || digestAlgorithms[len(digest)] != algorithm {
+		return Subject{}, fmt.Errorf("invalid %s digest %q of %s", algorithm, digest, name)
+	}
+	return Subject{Name: name, Digest: map[string]string{algorithm: strings.ToLower(digest)}}, nil
+}
+
+func resourceDescriptors(subjects []Subject) []*in_toto_attest.ResourceDescriptor {
+	descriptors := make([]*in_toto_attest.ResourceDescriptor, 0, len(subjects))
+	for _, s := range subjects {
+		descriptors = append(descriptors, &in_toto_attest.ResourceDescriptor{Name: s.Name, Digest: s.Digest})
+	}
+	return descriptors
+}
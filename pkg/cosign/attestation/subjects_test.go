@@ -0,0 +1,98 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseChecksums(t *testing.T) {
+	sha256Hex := strings.Repeat("a", 64)
+	sha512Hex := strings.Repeat("B", 128)
+	checksums := sha256Hex + "  app-linux-amd64.tar.gz\n" +
+		"# comment\n\n" +
+		sha512Hex + " *app-windows-amd64.zip\n" +
+		"SHA384 (app.spdx.json) = " + strings.Repeat("c", 96) + "\n"
+	got, err := ParseChecksums(strings.NewReader(checksums))
+	if err != nil {
+		t.Fatalf("ParseChecksums() error = %v", err)
+	}
+	want := []Subject{
+		{Name: "app-linux-amd64.tar.gz", Digest: map[string]string{"sha256": sha256Hex}},
+		{Name: "app-windows-amd64.zip", Digest: map[string]string{"sha512": strings.ToLower(sha512Hex)}},
+		{Name: "app.spdx.json", Digest: map[string]string{"sha384": strings.Repeat("c", 96)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseChecksums() = %+v, want %+v", got, want)
+	}
+
+	for _, tt := range []struct {
+		name, checksums string
+	}{
+		{"empty", "# nothing\n"},
+		{"no name", sha256Hex},
+		{"bad length", "abcd  file"},
+		{"not hex", strings.Repeat("z", 64) + "  file"},
+		{"wrong algorithm", "SHA512 (file) = " + sha256Hex},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseChecksums(strings.NewReader(tt.checksums)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseDigestSubject(t *testing.T) {
+	hex := strings.Repeat("d", 64)
+	s, ok, err := ParseDigestSubject("ghcr.io/org/app@sha256:" + hex)
+	if err != nil || !ok || s.Name != "ghcr.io/org/app" || s.Digest["sha256"] != hex {
+		t.Errorf("ParseDigestSubject() = %+v, %v, %v", s, ok, err)
+	}
+	if _, ok, _ := ParseDigestSubject("dist/app.tar.gz"); ok {
+		t.Error("a file path is not a digest subject")
+	}
+	if _, _, err := ParseDigestSubject("app@sha512:" + hex); err == nil {
+		t.Error("expected error for a digest of the wrong length")
+	}
+}
+
+func TestGenerateStatementSubjects(t *testing.T) {
+	subjects := []Subject{
+		{Name: "a", Digest: map[string]string{"sha256": strings.Repeat("a", 64)}},
+		{Name: "b", Digest: map[string]string{"sha512": strings.Repeat("b", 128)}},
+	}
+	for _, typ := range []string{"custom", "slsaprovenance1", "spdx"} {
+		predicate := `{"buildDefinition": {"buildType": "x"}, "runDetails": {"builder": {"id": "y"}}}`
+		if typ == "spdx" {
+			predicate = "SPDXVersion: SPDX-2.3"
+		}
+		st, err := GenerateStatement(GenerateOpts{
+			Predicate: strings.NewReader(predicate),
+			Type:      typ,
+			Digest:    "ignored",
+			Repo:      "ignored",
+			Subjects:  subjects,
+		})
+		if err != nil {
+			t.Fatalf("GenerateStatement(%s) error = %v", typ, err)
+		}
+		if len(st.Subject) != 2 || st.Subject[0].Name != "a" || st.Subject[1].Digest["sha512"] != subjects[1].Digest["sha512"] {
+			t.Errorf("GenerateStatement(%s) subjects = %v", typ, st.Subject)
+		}
+	}
+}
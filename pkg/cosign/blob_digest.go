@@ -0,0 +1,134 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+// BlobDigests are digests of a blob, keyed by hash function. They let a
+// blob signature be verified without holding the blob in memory.
+type BlobDigests map[crypto.Hash][]byte
+
+// HashBlob reads r to the end and returns its SHA-256 digest and its digest
+// with each of the other hash functions.
+func HashBlob(r io.Reader, hashFuncs ...crypto.Hash) (BlobDigests, error) {
+	hashers := map[crypto.Hash]hash.Hash{crypto.SHA256: sha256.New()}
+	for _, h := range hashFuncs {
+		if _, ok := hashers[h]; !ok && h != 0 {
+			if !h.Available() {
+				return nil, fmt.Errorf("hash function %s is not available", h)
+			}
+			hashers[h] = h.New()
+		}
+	}
+	writers := make([]io.Writer, 0, len(hashers))
+	for _, h := range hashers {
+		writers = append(writers, h)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, err
+	}
+	digests := BlobDigests{}
+	for h, hasher := range hashers {
+		digests[h] = hasher.Sum(nil)
+	}
+	return digests, nil
+}
+
+// ociSignature lets digestedSignature embed oci.Signature, whose Signature
+// method would clash with the embedded field's name.
+type ociSignature = oci.Signature
+
+// digestedSignature is a blob signature whose payload is known only by its
+// digests.
+type digestedSignature struct {
+	ociSignature
+	digests BlobDigests
+}
+
+// Payload fails, as the blob is not held in memory.
+func (s *digestedSignature) Payload() ([]byte, error) {
+	return nil, errors.New("the blob was verified by digest and its content is not available")
+}
+
+// payloadSHA256 returns the SHA-256 digest of the payload of sig.
+func payloadSHA256(sig oci.Signature) ([]byte, error) {
+	if ds, ok := sig.(*digestedSignature); ok {
+		return ds.digests[crypto.SHA256], nil
+	}
+	payload, err := sig.Payload()
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(payload)
+	return h[:], nil
+}
+
+// VerifyBlobDigestSignature verifies a blob signature like
+// VerifyBlobSignature, using digests of the blob instead of its content. The
+// payload of sig is ignored. The digests must include the one the signature
+// was made over; signing algorithms that sign the message itself, like pure
+// Ed25519, cannot be verified this way.
+func VerifyBlobDigestSignature(ctx context.Context, sig oci.Signature, digests BlobDigests, co *CheckOpts) (bundleVerified bool, err error) {
+	if len(digests[crypto.SHA256]) != sha256.Size {
+		return false, errors.New("a SHA-256 digest of the blob is required")
+	}
+	return verifyInternal(ctx, &digestedSignature{ociSignature: sig, digests: digests}, v1.Hash{}, verifyDigestedSignature, co)
+}
+
+// verifyDigestedSignature verifies the signature of a digestedSignature
+// against each of its digests, so that it is accepted whichever of the hash
+// functions it was made with.
+func verifyDigestedSignature(ctx context.Context, verifier signature.Verifier, sig payloader) error {
+	ds, ok := sig.(*digestedSignature)
+	if !ok {
+		return fmt.Errorf("unexpected signature type %T", sig)
+	}
+	b64sig, err := ds.Base64Signature()
+	if err != nil {
+		return err
+	}
+	rawSig, err := base64.StdEncoding.DecodeString(b64sig)
+	if err != nil {
+		return err
+	}
+	err = errors.New("no digest of the blob to verify")
+	for _, h := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		digest, ok := ds.digests[h]
+		if !ok {
+			continue
+		}
+		if err = verifier.VerifySignature(bytes.NewReader(rawSig), bytes.NewReader(nil),
+			options.WithContext(ctx), options.WithDigest(digest), options.WithCryptoSignerOpts(h)); err == nil {
+			return nil
+		}
+	}
+	return err
+}
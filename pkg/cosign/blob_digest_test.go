@@ -0,0 +1,104 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+func TestHashBlob(t *testing.T) {
+	blob := []byte("someblob")
+	digests, err := HashBlob(bytes.NewReader(blob), crypto.SHA384, crypto.SHA256, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sha256.Sum256(blob)
+	if len(digests) != 2 || !bytes.Equal(digests[crypto.SHA256], want[:]) || len(digests[crypto.SHA384]) != crypto.SHA384.Size() {
+		t.Errorf("HashBlob() = %x", digests)
+	}
+}
+
+func TestVerifyBlobDigestSignature(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte(strings.Repeat("large blob ", 1000))
+
+	for _, tt := range []struct {
+		curve elliptic.Curve
+		hash  crypto.Hash
+	}{
+		{elliptic.P256(), crypto.SHA256},
+		{elliptic.P384(), crypto.SHA384},
+	} {
+		t.Run(tt.hash.String(), func(t *testing.T) {
+			pk, err := ecdsa.GenerateKey(tt.curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sv, err := signature.LoadECDSASignerVerifier(pk, tt.hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rawSig, err := sv.SignMessage(bytes.NewReader(blob))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := static.NewSignature(nil, base64.StdEncoding.EncodeToString(rawSig))
+			if err != nil {
+				t.Fatal(err)
+			}
+			co := &CheckOpts{SigVerifier: sv, IgnoreTlog: true}
+
+			digests, err := HashBlob(bytes.NewReader(blob), tt.hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := VerifyBlobDigestSignature(ctx, sig, digests, co); err != nil {
+				t.Errorf("VerifyBlobDigestSignature() error = %v", err)
+			}
+
+			// Without the digest the signature was made over, verification fails.
+			if tt.hash != crypto.SHA256 {
+				if _, err := VerifyBlobDigestSignature(ctx, sig, BlobDigests{crypto.SHA256: digests[crypto.SHA256]}, co); err == nil {
+					t.Error("VerifyBlobDigestSignature() succeeded without the signed digest")
+				}
+			}
+
+			other, err := HashBlob(bytes.NewReader(append(blob, '!')), tt.hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := VerifyBlobDigestSignature(ctx, sig, other, co); err == nil {
+				t.Error("VerifyBlobDigestSignature() verified a different blob")
+			}
+		})
+	}
+
+	if _, err := VerifyBlobDigestSignature(ctx, nil, BlobDigests{}, &CheckOpts{}); err == nil {
+		t.Error("VerifyBlobDigestSignature() accepted digests without SHA-256")
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	sgbundle "github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/sign"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+)
+
+// PrehashedData is a sign.Content for an artifact that has already been
+// hashed, either by streaming it through a hash or on another host. Only
+// the digest is signed, so the artifact itself never has to be in memory.
+type PrehashedData struct {
+	Digest        []byte
+	HashAlgorithm protocommon.HashAlgorithm
+}
+
+var _ sign.Content = (*PrehashedData)(nil)
+
+// PreAuthEncoding returns nil; the digest is signed by a DigestSigner instead.
+func (pd *PrehashedData) PreAuthEncoding() []byte {
+	return nil
+}
+
+func (pd *PrehashedData) Bundle(bundle *protobundle.Bundle, signature, _ []byte, _ protocommon.HashAlgorithm) {
+	bundle.Content = &protobundle.Bundle_MessageSignature{
+		MessageSignature: &protocommon.MessageSignature{
+			MessageDigest: &protocommon.HashOutput{
+				Algorithm: pd.HashAlgorithm,
+				Digest:    pd.Digest,
+			},
+			Signature: signature,
+		},
+	}
+}
+
+// DigestSigner is implemented by keypairs that can sign a precomputed digest.
+type DigestSigner interface {
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// prehashedKeypair signs the digest of a PrehashedData when asked to sign its
+// (empty) pre-auth encoding, and delegates every other request, such as the
+// proof of possession sent to Fulcio, to the wrapped keypair.
+type prehashedKeypair struct {
+	sign.Keypair
+	signer DigestSigner
+	digest []byte
+}
+
+func (k *prehashedKeypair) SignData(ctx context.Context, data []byte) ([]byte, []byte, error) {
+	if len(data) != 0 {
+		return k.Keypair.SignData(ctx, data)
+	}
+	sig, err := k.signer.SignDigest(ctx, k.digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, k.digest, nil
+}
+
+func newPrehashedKeypair(pd *PrehashedData, keypair sign.Keypair) (sign.Keypair, error) {
+	if pd.HashAlgorithm != keypair.GetHashAlgorithm() {
+		return nil, fmt.Errorf("digest algorithm %s does not match the signing algorithm's hash %s", pd.HashAlgorithm, keypair.GetHashAlgorithm())
+	}
+	ds, ok := keypair.(DigestSigner)
+	if !ok {
+		return nil, fmt.Errorf("signing key does not support signing a precomputed digest")
+	}
+	return &prehashedKeypair{Keypair: keypair, signer: ds, digest: pd.Digest}, nil
+}
+
+// verifyPrehashedBundle mirrors the post-signing verification done by
+// sign.Bundle, which needs the artifact bytes, using the artifact digest.
+func verifyPrehashedBundle(b *protobundle.Bundle, pd *PrehashedData, trustedMaterial root.TrustedMaterial, hasCert bool) error {
+	var opts []verify.VerifierOption
+	vm := b.GetVerificationMaterial()
+	timestamps := len(vm.GetTimestampVerificationData().GetRfc3161Timestamps())
+	tlogEntries := len(vm.GetTlogEntries())
+	if timestamps > 0 {
+		opts = append(opts, verify.WithSignedTimestamps(timestamps))
+	}
+	if tlogEntries > 0 {
+		opts = append(opts, verify.WithTransparencyLog(tlogEntries))
+		if timestamps == 0 {
+			if hasCert {
+				opts = append(opts, verify.WithIntegratedTimestamps(tlogEntries))
+			} else {
+				opts = append(opts, verify.WithNoObserverTimestamps())
+			}
+		}
+	}
+	if timestamps == 0 && tlogEntries == 0 {
+		if hasCert {
+			opts = append(opts, verify.WithCurrentTime())
+		} else {
+			opts = append(opts, verify.WithNoObserverTimestamps())
+		}
+	}
+
+	v, err := verify.NewVerifier(trustedMaterial, opts...)
+	if err != nil {
+		return err
+	}
+	var bundleOpts []sgbundle.Option
+	if len(vm.GetX509CertificateChain().GetCertificates()) > 0 {
+		bundleOpts = append(bundleOpts, sgbundle.AllowCertificateChain())
+	}
+	sb, err := sgbundle.NewBundle(b, bundleOpts...)
+	if err != nil {
+		return err
+	}
+	alg := map[protocommon.HashAlgorithm]string{
+		protocommon.HashAlgorithm_SHA2_256: "sha256",
+		protocommon.HashAlgorithm_SHA2_384: "sha384",
+		protocommon.HashAlgorithm_SHA2_512: "sha512",
+	}[pd.HashAlgorithm]
+	policy := verify.NewPolicy(verify.WithArtifactDigest(alg, pd.Digest), verify.WithoutIdentitiesUnsafe())
+	if _, err := v.Verify(sb, policy); err != nil {
+		return fmt.Errorf("verifying signed digest: %w", err)
+	}
+	return nil
+}
@@ -141,6 +141,19 @@ func SignData(ctx context.Context, content sign.Content, keypair sign.Keypair, i
 		return nil, fmt.Errorf("a timestamp authority must be provided to request a short-lived certificate that will be logged to Rekor")
 	}
 
+	// sign.Bundle verifies the result against the artifact bytes, which a
+	// PrehashedData does not have, so verify against its digest instead.
+	prehashed, isPrehashed := content.(*PrehashedData)
+	var prehashedTrustedRoot root.TrustedMaterial
+	if isPrehashed {
+		var err error
+		keypair, err = newPrehashedKeypair(prehashed, keypair)
+		if err != nil {
+			return nil, err
+		}
+		prehashedTrustedRoot, bundleOpts.TrustedRoot = bundleOpts.TrustedRoot, nil
+	}
+
 	spinner := ui.NewSpinner(ctx, "Signing artifact...")
 	defer spinner.Stop()
 
@@ -148,6 +161,12 @@ func SignData(ctx context.Context, content sign.Content, keypair sign.Keypair, i
 	if err != nil {
 		return nil, fmt.Errorf("error signing bundle: %w", err)
 	}
+	if prehashedTrustedRoot != nil {
+		hasCert := bundleOpts.CertificateProvider != nil || bundleOpts.CertificateChainProvider != nil
+		if err := verifyPrehashedBundle(bundle, prehashed, prehashedTrustedRoot, hasCert); err != nil {
+			return nil, fmt.Errorf("error signing bundle: %w", err)
+		}
+	}
 	return protojson.Marshal(bundle)
 }
 
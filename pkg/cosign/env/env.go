@@ -47,6 +47,7 @@ const (
 	VariableExperimental            Variable = "COSIGN_EXPERIMENTAL"
 	VariableDockerMediaTypes        Variable = "COSIGN_DOCKER_MEDIA_TYPES"
 	VariablePassword                Variable = "COSIGN_PASSWORD"
+	VariableNewPassword             Variable = "COSIGN_NEW_PASSWORD"
 	VariablePKCS11Pin               Variable = "COSIGN_PKCS11_PIN"
 	VariablePKCS11ModulePath        Variable = "COSIGN_PKCS11_MODULE_PATH"
 	VariablePKCS11IgnoreCertificate Variable = "COSIGN_PKCS11_IGNORE_CERTIFICATE"
@@ -74,11 +75,18 @@ const (
 	VariableGoogleServiceAccountName  Variable = "GOOGLE_SERVICE_ACCOUNT_NAME"
 	VariableGitLabHost                Variable = "GITLAB_HOST"
 	VariableGitLabToken               Variable = "GITLAB_TOKEN"
+	VariableGiteaHost                 Variable = "GITEA_HOST"
+	VariableGiteaToken                Variable = "GITEA_TOKEN" //nolint:gosec
+	VariableBitbucketHost             Variable = "BITBUCKET_HOST"
+	VariableBitbucketToken            Variable = "BITBUCKET_TOKEN" //nolint:gosec
+	VariableAzureDevOpsHost           Variable = "AZURE_DEVOPS_HOST"
+	VariableAzureDevOpsToken          Variable = "AZURE_DEVOPS_EXT_PAT" //nolint:gosec
 	VariableBuildkiteAgentAccessToken Variable = "BUILDKITE_AGENT_ACCESS_TOKEN"
 	VariableBuildkiteAgentEndpoint    Variable = "BUILDKITE_AGENT_ENDPOINT"
 	VariableBuildkiteJobID            Variable = "BUILDKITE_JOB_ID"
 	VariableBuildkiteAgentLogLevel    Variable = "BUILDKITE_AGENT_LOG_LEVEL"
 	VariableSourceDateEpoch           Variable = "SOURCE_DATE_EPOCH"
+	VariableSSHAuthSock               Variable = "SSH_AUTH_SOCK"
 )
 
 var (
@@ -100,6 +108,11 @@ var (
 			Expects:     "string with a password (asks on stdin by default)",
 			Sensitive:   true,
 		},
+		VariableNewPassword: {
+			Description: "overrides the new password input of cosign key reencrypt with this value",
+			Expects:     "string with a password (asks on stdin by default)",
+			Sensitive:   true,
+		},
 		VariablePKCS11Pin: {
 			Description: "to be used if PKCS11 PIN is not provided",
 			Expects:     "string with a PIN",
@@ -216,6 +229,42 @@ var (
 			Sensitive:   true,
 			External:    true,
 		},
+		VariableGiteaHost: {
+			Description: "is URL of the Gitea or Forgejo instance",
+			Expects:     "string with the URL of the Gitea or Forgejo instance (https://gitea.com by default)",
+			Sensitive:   false,
+			External:    true,
+		},
+		VariableGiteaToken: {
+			Description: "is a token used to authenticate with Gitea or Forgejo",
+			Expects:     "string with a token",
+			Sensitive:   true,
+			External:    true,
+		},
+		VariableBitbucketHost: {
+			Description: "is URL of the Bitbucket API",
+			Expects:     "string with the URL of the Bitbucket API (https://api.bitbucket.org by default)",
+			Sensitive:   false,
+			External:    true,
+		},
+		VariableBitbucketToken: {
+			Description: "is an access token used to authenticate with Bitbucket",
+			Expects:     "string with an access token",
+			Sensitive:   true,
+			External:    true,
+		},
+		VariableAzureDevOpsHost: {
+			Description: "is URL of the Azure DevOps instance",
+			Expects:     "string with the URL of the Azure DevOps instance (https://dev.azure.com by default)",
+			Sensitive:   false,
+			External:    true,
+		},
+		VariableAzureDevOpsToken: {
+			Description: "is a personal access token used to authenticate with Azure DevOps",
+			Expects:     "string with a personal access token",
+			Sensitive:   true,
+			External:    true,
+		},
 		VariableBuildkiteAgentAccessToken: {
 			Description: "is an access token used to identify the Buildkite agent",
 			Expects:     "string with an access token",
@@ -252,6 +301,12 @@ var (
 			Sensitive:   false,
 			External:    true,
 		},
+		VariableSSHAuthSock: {
+			Description: "is the socket of the ssh-agent used for ssh-agent:// keys",
+			Expects:     "path to a unix socket",
+			Sensitive:   false,
+			External:    true,
+		},
 	}
 )
 
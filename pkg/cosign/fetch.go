@@ -191,7 +191,7 @@ func FetchAttestations(se oci.SignedEntity, predicateType string) ([]Attestation
 				if err := statement.UnmarshalJSON(decodedPayload); err != nil {
 					return fmt.Errorf("unmarshaling statement: %w", err)
 				}
-				if statement.PredicateType != predicateType {
+				if !attestation.MatchesPredicateType(predicateType, statement.PredicateType) {
 					return nil
 				}
 			}
@@ -0,0 +1,217 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/internal/pkg/rest"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/env"
+)
+
+const (
+	ReferenceScheme = "azuredevops"
+
+	defaultHost      = "https://dev.azure.com"
+	defaultGroupName = "cosign"
+	apiVersion       = "7.1"
+)
+
+// Ado stores the key pair in an Azure DevOps pipelines variable group of a
+// project, named "cosign" unless the reference names another group. The
+// password and private key are secret variables; the public key is not
+// secret so that it can be read back.
+type Ado struct{}
+
+func New() *Ado {
+	return &Ado{}
+}
+
+// variableValue omits empty values, which the API returns for secret
+// variables, so that updating a group keeps its other secrets.
+type variableValue struct {
+	Value    string `json:"value,omitempty"`
+	IsSecret bool   `json:"isSecret"`
+}
+
+type variableGroup struct {
+	ID                             int                      `json:"id,omitempty"`
+	Name                           string                   `json:"name"`
+	Type                           string                   `json:"type,omitempty"`
+	Variables                      map[string]variableValue `json:"variables"`
+	VariableGroupProjectReferences []projectReference       `json:"variableGroupProjectReferences,omitempty"`
+}
+
+type projectReference struct {
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	ProjectReference struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name"`
+	} `json:"projectReference"`
+}
+
+func (a *Ado) PutSecret(ctx context.Context, ref string, pf cosign.PassFunc) error {
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	org, project, groupName, err := parseRef(ref)
+	if err != nil {
+		return err
+	}
+
+	keys, err := cosign.GenerateKeyPair(pf)
+	if err != nil {
+		return fmt.Errorf("generating key pair: %w", err)
+	}
+
+	group, err := c.getGroup(ctx, org, project, groupName)
+	if err != nil {
+		return fmt.Errorf("could not get %q variable group: %w", groupName, err)
+	}
+	method, path := http.MethodPut, "/"+url.PathEscape(org)+"/_apis/distributedtask/variablegroups/"
+	if group == nil {
+		group = &variableGroup{Name: groupName, Variables: map[string]variableValue{}}
+		method = http.MethodPost
+	} else {
+		path += strconv.Itoa(group.ID)
+	}
+	group.Type = "Vsts"
+	// The group may be shared with other projects, which keep their access.
+	if !slices.ContainsFunc(group.VariableGroupProjectReferences, func(r projectReference) bool {
+		return strings.EqualFold(r.ProjectReference.Name, project)
+	}) {
+		projectRef := projectReference{Name: groupName}
+		projectRef.ProjectReference.Name = project
+		group.VariableGroupProjectReferences = append(group.VariableGroupProjectReferences, projectRef)
+	}
+	group.Variables["COSIGN_PASSWORD"] = variableValue{Value: string(keys.Password()), IsSecret: true}
+	group.Variables["COSIGN_PRIVATE_KEY"] = variableValue{Value: string(keys.PrivateBytes), IsSecret: true}
+	group.Variables["COSIGN_PUBLIC_KEY"] = variableValue{Value: string(keys.PublicBytes)}
+
+	if err := c.do(ctx, method, path, group, nil); err != nil {
+		return fmt.Errorf("could not write %q variable group: %w", groupName, err)
+	}
+	ui.Infof(ctx, "Password, private key and public key written to \"COSIGN_PASSWORD\", \"COSIGN_PRIVATE_KEY\" and \"COSIGN_PUBLIC_KEY\" in the %q variable group", groupName)
+
+	if err := os.WriteFile("cosign.pub", keys.PublicBytes, 0o600); err != nil {
+		return err
+	}
+	ui.Infof(ctx, "Public key also written to cosign.pub")
+
+	return nil
+}
+
+// GetSecret reads a variable of the variable group. Secret variables cannot
+// be read back, so only the public key is available.
+func (a *Ado) GetSecret(ctx context.Context, ref string, key string) (string, error) {
+	c, err := newClient()
+	if err != nil {
+		return "", err
+	}
+	org, project, groupName, err := parseRef(ref)
+	if err != nil {
+		return "", err
+	}
+	group, err := c.getGroup(ctx, org, project, groupName)
+	if err != nil {
+		return "", fmt.Errorf("could not get %q variable group: %w", groupName, err)
+	}
+	if group == nil {
+		return "", fmt.Errorf("could not find %q variable group", groupName)
+	}
+	v, ok := group.Variables[key]
+	switch {
+	case !ok:
+		return "", fmt.Errorf("could not find %q in the %q variable group", key, groupName)
+	case v.IsSecret:
+		return "", fmt.Errorf("%q is a secret variable and cannot be read back", key)
+	}
+	return v.Value, nil
+}
+
+// parseRef parses azuredevops://<organization>/<project>[/<variable group>].
+func parseRef(ref string) (string, string, string, error) {
+	split := strings.Split(ref, "/")
+	if (len(split) != 2 && len(split) != 3) || slices.Contains(split, "") {
+		return "", "", "", errors.New("could not parse scheme, use azuredevops://<organization>/<project> or azuredevops://<organization>/<project>/<variable group> format")
+	}
+	group := defaultGroupName
+	if len(split) == 3 {
+		group = split[2]
+	}
+	return split[0], split[1], group, nil
+}
+
+type client struct {
+	rest.Client
+}
+
+func newClient() (*client, error) {
+	token, ok := env.LookupEnv(env.VariableAzureDevOpsToken)
+	if !ok {
+		return nil, fmt.Errorf("could not find %q environment variable", env.VariableAzureDevOpsToken.String())
+	}
+	host := defaultHost
+	if h, ok := env.LookupEnv(env.VariableAzureDevOpsHost); ok {
+		host = h
+	}
+	return &client{rest.Client{
+		BaseURL:   strings.TrimSuffix(host, "/"),
+		Authorize: func(r *http.Request) { r.SetBasicAuth("", token) },
+	}}, nil
+}
+
+// getGroup returns the variable group of a project by name, or nil if there
+// is none.
+func (c *client) getGroup(ctx context.Context, org, project, name string) (*variableGroup, error) {
+	var groups struct {
+		Value []variableGroup `json:"value"`
+	}
+	path := "/" + url.PathEscape(org) + "/" + url.PathEscape(project) + "/_apis/distributedtask/variablegroups?groupName=" + url.QueryEscape(name)
+	if err := c.do(ctx, http.MethodGet, path, nil, &groups); err != nil {
+		return nil, err
+	}
+	for i := range groups.Value {
+		if strings.EqualFold(groups.Value[i].Name, name) {
+			if groups.Value[i].Variables == nil {
+				groups.Value[i].Variables = map[string]variableValue{}
+			}
+			return &groups.Value[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// do sends a request for the pinned API version.
+func (c *client) do(ctx context.Context, method, path string, in, out any) error {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return c.Do(ctx, method, path+sep+"api-version="+apiVersion, in, out)
+}
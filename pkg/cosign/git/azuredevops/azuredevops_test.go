@@ -0,0 +1,144 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// variableGroupServer is a stand-in for the Azure DevOps variable groups API
+// of the org organization and its project project.
+func variableGroupServer(t *testing.T) (*httptest.Server, map[int]*variableGroup) {
+	groups := map[int]*variableGroup{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pat, _ := r.BasicAuth(); pat != "test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("api-version") != apiVersion {
+			t.Errorf("unexpected api-version in %s", r.URL)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/org/project/_apis/distributedtask/variablegroups":
+			var value []variableGroup
+			for _, g := range groups {
+				if g.Name == r.URL.Query().Get("groupName") {
+					out := *g
+					out.Variables = map[string]variableValue{}
+					for k, v := range g.Variables {
+						if v.IsSecret {
+							v.Value = ""
+						}
+						out.Variables[k] = v
+					}
+					value = append(value, out)
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"count": len(value), "value": value})
+		case strings.HasPrefix(r.URL.Path, "/org/_apis/distributedtask/variablegroups/"):
+			var g variableGroup
+			if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+				t.Error(err)
+			}
+			if !slices.ContainsFunc(g.VariableGroupProjectReferences, func(r projectReference) bool { return r.ProjectReference.Name == "project" }) {
+				t.Errorf("unexpected project references %+v", g.VariableGroupProjectReferences)
+			}
+			id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/org/_apis/distributedtask/variablegroups/"))
+			if r.Method == http.MethodPost {
+				id = len(groups) + 1
+			} else if groups[id] == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			// Like the service, keep secret values that are not sent.
+			for k, v := range g.Variables {
+				if old, ok := groups[id]; ok && v.IsSecret && v.Value == "" {
+					g.Variables[k] = old.Variables[k]
+				}
+			}
+			g.ID = id
+			groups[id] = &g
+			json.NewEncoder(w).Encode(g)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), groups
+}
+
+func TestPutAndGetSecret(t *testing.T) {
+	server, groups := variableGroupServer(t)
+	defer server.Close()
+	t.Setenv("AZURE_DEVOPS_HOST", server.URL)
+	t.Setenv("AZURE_DEVOPS_EXT_PAT", "test-token")
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	pf := func(bool) ([]byte, error) { return []byte("hunter2"), nil }
+
+	a := New()
+	if err := a.PutSecret(ctx, "org/project", pf); err != nil {
+		t.Fatalf("PutSecret() error = %v", err)
+	}
+	// Another secret in the group survives updates.
+	groups[1].Variables["OTHER_SECRET"] = variableValue{Value: "keep", IsSecret: true}
+	if err := a.PutSecret(ctx, "org/project", pf); err != nil {
+		t.Fatalf("PutSecret() error = %v", err)
+	}
+	if err := a.PutSecret(ctx, "org/project/signing", pf); err != nil {
+		t.Fatalf("PutSecret() error = %v", err)
+	}
+	if len(groups) != 2 || groups[1].Name != "cosign" || groups[2].Name != "signing" {
+		t.Fatalf("unexpected variable groups %+v", groups)
+	}
+	if v := groups[1].Variables; v["COSIGN_PASSWORD"].Value != "hunter2" || !v["COSIGN_PRIVATE_KEY"].IsSecret || v["OTHER_SECRET"].Value != "keep" {
+		t.Errorf("unexpected variables %+v", v)
+	}
+
+	// A group shared with another project stays shared.
+	shared := projectReference{Name: "signing"}
+	shared.ProjectReference.ID = "b0d2f3c4"
+	shared.ProjectReference.Name = "other"
+	groups[2].VariableGroupProjectReferences = append([]projectReference{shared}, groups[2].VariableGroupProjectReferences...)
+	if err := a.PutSecret(ctx, "org/project/signing", pf); err != nil {
+		t.Fatalf("PutSecret() error = %v", err)
+	}
+	if refs := groups[2].VariableGroupProjectReferences; len(refs) != 2 || refs[0] != shared || refs[1].ProjectReference.Name != "project" {
+		t.Errorf("unexpected project references %+v", refs)
+	}
+
+	pub, err := a.GetSecret(ctx, "org/project/signing", "COSIGN_PUBLIC_KEY")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+	if b, err := os.ReadFile("cosign.pub"); err != nil || string(b) != pub {
+		t.Errorf("GetSecret() = %q, want the contents of cosign.pub", pub)
+	}
+	if _, err := a.GetSecret(ctx, "org/project", "COSIGN_PASSWORD"); err == nil {
+		t.Error("expected error reading a secret variable")
+	}
+	if _, err := a.GetSecret(ctx, "org/project/missing", "COSIGN_PUBLIC_KEY"); err == nil {
+		t.Error("expected error reading a missing variable group")
+	}
+	if err := a.PutSecret(ctx, "org", pf); err == nil {
+		t.Error("expected error for a reference without a project")
+	}
+}
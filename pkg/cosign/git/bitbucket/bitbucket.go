@@ -0,0 +1,181 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/internal/pkg/rest"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/env"
+)
+
+const (
+	ReferenceScheme = "bitbucket"
+
+	defaultHost = "https://api.bitbucket.org"
+)
+
+// Bb stores the key pair as Bitbucket Pipelines variables of a repository or
+// a workspace. The password and private key are secured variables; the
+// public key is not secured so that it can be read back.
+type Bb struct{}
+
+func New() *Bb {
+	return &Bb{}
+}
+
+type variable struct {
+	UUID    string `json:"uuid,omitempty"`
+	Key     string `json:"key"`
+	Value   string `json:"value,omitempty"`
+	Secured bool   `json:"secured"`
+}
+
+func (b *Bb) PutSecret(ctx context.Context, ref string, pf cosign.PassFunc) error {
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	scope, err := variablesPath(ref)
+	if err != nil {
+		return err
+	}
+
+	keys, err := cosign.GenerateKeyPair(pf)
+	if err != nil {
+		return fmt.Errorf("generating key pair: %w", err)
+	}
+
+	existing, err := c.listVariables(ctx, scope)
+	if err != nil {
+		return fmt.Errorf("could not list pipeline variables: %w", err)
+	}
+
+	for _, v := range []struct {
+		variable
+		description string
+	}{
+		{variable{Key: "COSIGN_PASSWORD", Value: string(keys.Password()), Secured: true}, "Password"},
+		{variable{Key: "COSIGN_PRIVATE_KEY", Value: string(keys.PrivateBytes), Secured: true}, "Private key"},
+		{variable{Key: "COSIGN_PUBLIC_KEY", Value: string(keys.PublicBytes)}, "Public key"},
+	} {
+		path := scope + "/"
+		method := http.MethodPost
+		if old, ok := existing[v.Key]; ok {
+			path += url.PathEscape(old.UUID)
+			method = http.MethodPut
+		}
+		if err := c.Do(ctx, method, path, v.variable, nil); err != nil {
+			return fmt.Errorf("could not create %q pipeline variable: %w", v.Key, err)
+		}
+		ui.Infof(ctx, "%s written to %q pipeline variable", v.description, v.Key)
+	}
+
+	if err := os.WriteFile("cosign.pub", keys.PublicBytes, 0o600); err != nil {
+		return err
+	}
+	ui.Infof(ctx, "Public key also written to cosign.pub")
+
+	return nil
+}
+
+// GetSecret reads a pipeline variable. Secured variables cannot be read back,
+// so only the public key is available.
+func (b *Bb) GetSecret(ctx context.Context, ref string, key string) (string, error) {
+	c, err := newClient()
+	if err != nil {
+		return "", err
+	}
+	scope, err := variablesPath(ref)
+	if err != nil {
+		return "", err
+	}
+	variables, err := c.listVariables(ctx, scope)
+	if err != nil {
+		return "", fmt.Errorf("could not list pipeline variables: %w", err)
+	}
+	v, ok := variables[key]
+	switch {
+	case !ok:
+		return "", fmt.Errorf("could not find %q pipeline variable", key)
+	case v.Secured:
+		return "", fmt.Errorf("%q is a secured pipeline variable and cannot be read back", key)
+	}
+	return v.Value, nil
+}
+
+// variablesPath returns the API path of the pipeline variables of a
+// bitbucket://<workspace> workspace or a bitbucket://<workspace>/<repo>
+// repository.
+func variablesPath(ref string) (string, error) {
+	split := strings.Split(ref, "/")
+	switch {
+	case len(split) == 1 && split[0] != "":
+		return "/2.0/workspaces/" + url.PathEscape(split[0]) + "/pipelines-config/variables", nil
+	case len(split) == 2 && split[0] != "" && split[1] != "":
+		return "/2.0/repositories/" + url.PathEscape(split[0]) + "/" + url.PathEscape(split[1]) + "/pipelines_config/variables", nil
+	default:
+		return "", errors.New("could not parse scheme, use bitbucket://<workspace> or bitbucket://<workspace>/<repo> format")
+	}
+}
+
+type client struct {
+	rest.Client
+}
+
+func newClient() (*client, error) {
+	token, ok := env.LookupEnv(env.VariableBitbucketToken)
+	if !ok {
+		return nil, fmt.Errorf("could not find %q environment variable", env.VariableBitbucketToken.String())
+	}
+	host := defaultHost
+	if h, ok := env.LookupEnv(env.VariableBitbucketHost); ok {
+		host = h
+	}
+	return &client{rest.Client{
+		BaseURL:   strings.TrimSuffix(host, "/"),
+		Authorize: func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+	}}, nil
+}
+
+// listVariables returns the variables of a scope by key, following
+// pagination.
+func (c *client) listVariables(ctx context.Context, scope string) (map[string]variable, error) {
+	variables := map[string]variable{}
+	next := c.BaseURL + scope + "/?pagelen=100"
+	for next != "" {
+		var page struct {
+			Values []variable `json:"values"`
+			Next   string     `json:"next"`
+		}
+		if err := c.DoURL(ctx, http.MethodGet, next, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, v := range page.Values {
+			variables[v.Key] = v
+		}
+		next = page.Next
+	}
+	return variables, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// pipelinesServer is a stand-in for the Bitbucket pipeline variables API of
+// the workspace/repo repository. It returns one variable per page.
+func pipelinesServer(t *testing.T) (*httptest.Server, map[string]variable) {
+	const base = "/2.0/repositories/workspace/repo/pipelines_config/variables/"
+	variables := map[string]variable{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, base) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		uuid := strings.TrimPrefix(r.URL.Path, base)
+		switch r.Method {
+		case http.MethodGet:
+			var values []variable
+			for _, v := range variables {
+				if v.Secured {
+					v.Value = ""
+				}
+				values = append(values, v)
+			}
+			// Map iteration order changes from one page request to the next.
+			slices.SortFunc(values, func(a, b variable) int { return strings.Compare(a.Key, b.Key) })
+			page := map[string]any{"values": []variable{}}
+			var n int
+			fmt.Sscan(r.URL.Query().Get("page"), &n)
+			if n < len(values) {
+				page["values"] = values[n : n+1]
+			}
+			if n+1 < len(values) {
+				page["next"] = fmt.Sprintf("%s%s?page=%d", server.URL, base, n+1)
+			}
+			json.NewEncoder(w).Encode(page)
+		case http.MethodPost, http.MethodPut:
+			var v variable
+			if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+				t.Error(err)
+			}
+			if r.Method == http.MethodPost {
+				if _, ok := variables[v.Key]; ok {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				v.UUID = "{" + v.Key + "}"
+			} else {
+				if uuid != "{"+v.Key+"}" {
+					t.Errorf("updating %s with uuid %s", v.Key, uuid)
+				}
+				v.UUID = uuid
+			}
+			variables[v.Key] = v
+			json.NewEncoder(w).Encode(v)
+		}
+	}))
+	return server, variables
+}
+
+func TestPutAndGetSecret(t *testing.T) {
+	server, variables := pipelinesServer(t)
+	defer server.Close()
+	t.Setenv("BITBUCKET_HOST", server.URL)
+	t.Setenv("BITBUCKET_TOKEN", "test-token")
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	pf := func(bool) ([]byte, error) { return []byte("hunter2"), nil }
+
+	b := New()
+	for range 2 {
+		if err := b.PutSecret(ctx, "workspace/repo", pf); err != nil {
+			t.Fatalf("PutSecret() error = %v", err)
+		}
+	}
+	if len(variables) != 3 || !variables["COSIGN_PASSWORD"].Secured || variables["COSIGN_PUBLIC_KEY"].Secured {
+		t.Errorf("unexpected variables %+v", variables)
+	}
+
+	pub, err := b.GetSecret(ctx, "workspace/repo", "COSIGN_PUBLIC_KEY")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+	if b, err := os.ReadFile("cosign.pub"); err != nil || string(b) != pub {
+		t.Errorf("GetSecret() = %q, want the contents of cosign.pub", pub)
+	}
+	if _, err := b.GetSecret(ctx, "workspace/repo", "COSIGN_PRIVATE_KEY"); err == nil {
+		t.Error("expected error reading a secured variable")
+	}
+	if err := b.PutSecret(ctx, "workspace/other", pf); err == nil {
+		t.Error("expected error for an unknown repository")
+	}
+}
+
+func TestVariablesPath(t *testing.T) {
+	for ref, want := range map[string]string{
+		"workspace":      "/2.0/workspaces/workspace/pipelines-config/variables",
+		"workspace/repo": "/2.0/repositories/workspace/repo/pipelines_config/variables",
+		"":               "",
+		"/repo":          "",
+		"a/b/c":          "",
+	} {
+		got, err := variablesPath(ref)
+		if got != want || (err != nil) != (want == "") {
+			t.Errorf("variablesPath(%q) = %q, %v, want %q", ref, got, err, want)
+		}
+	}
+}
@@ -19,13 +19,20 @@ import (
 	"context"
 
 	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/azuredevops"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/bitbucket"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/gitea"
 	"github.com/sigstore/cosign/v3/pkg/cosign/git/github"
 	"github.com/sigstore/cosign/v3/pkg/cosign/git/gitlab"
 )
 
 var providerMap = map[string]Git{
-	github.ReferenceScheme: github.New(),
-	gitlab.ReferenceScheme: gitlab.New(),
+	github.ReferenceScheme:       github.New(),
+	gitlab.ReferenceScheme:       gitlab.New(),
+	gitea.ReferenceScheme:        gitea.New(),
+	gitea.ForgejoReferenceScheme: gitea.New(),
+	bitbucket.ReferenceScheme:    bitbucket.New(),
+	azuredevops.ReferenceScheme:  azuredevops.New(),
 }
 
 type Git interface {
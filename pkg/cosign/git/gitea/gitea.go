@@ -0,0 +1,162 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/internal/pkg/rest"
+	"github.com/sigstore/cosign/v3/internal/ui"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/env"
+)
+
+const (
+	ReferenceScheme = "gitea"
+	// ForgejoReferenceScheme is an alias of ReferenceScheme, Forgejo serves
+	// the same API.
+	ForgejoReferenceScheme = "forgejo"
+
+	defaultHost = "https://gitea.com"
+)
+
+// Gt stores the key pair as Gitea Actions secrets of a repository or an
+// organization. The public key is stored as an Actions variable so that it
+// can be read back.
+type Gt struct{}
+
+func New() *Gt {
+	return &Gt{}
+}
+
+func (g *Gt) PutSecret(ctx context.Context, ref string, pf cosign.PassFunc) error {
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	scope, err := scopePath(ref)
+	if err != nil {
+		return err
+	}
+
+	keys, err := cosign.GenerateKeyPair(pf)
+	if err != nil {
+		return fmt.Errorf("generating key pair: %w", err)
+	}
+
+	if err := c.putSecret(ctx, scope, "COSIGN_PASSWORD", keys.Password()); err != nil {
+		return err
+	}
+	ui.Infof(ctx, "Password written to \"COSIGN_PASSWORD\" actions secret")
+
+	if err := c.putSecret(ctx, scope, "COSIGN_PRIVATE_KEY", keys.PrivateBytes); err != nil {
+		return err
+	}
+	ui.Infof(ctx, "Private key written to \"COSIGN_PRIVATE_KEY\" actions secret")
+
+	if err := c.putVariable(ctx, scope, "COSIGN_PUBLIC_KEY", keys.PublicBytes); err != nil {
+		return err
+	}
+	ui.Infof(ctx, "Public key written to \"COSIGN_PUBLIC_KEY\" actions variable")
+
+	if err := os.WriteFile("cosign.pub", keys.PublicBytes, 0o600); err != nil {
+		return err
+	}
+	ui.Infof(ctx, "Public key also written to cosign.pub")
+
+	return nil
+}
+
+// GetSecret reads an Actions variable. Actions secrets are write-only, so
+// only the public key can be read back.
+func (g *Gt) GetSecret(ctx context.Context, ref string, key string) (string, error) {
+	c, err := newClient()
+	if err != nil {
+		return "", err
+	}
+	scope, err := scopePath(ref)
+	if err != nil {
+		return "", err
+	}
+	var variable struct {
+		Data string `json:"data"`
+	}
+	if err := c.Do(ctx, http.MethodGet, scope+"/actions/variables/"+key, nil, &variable); err != nil {
+		if rest.IsNotFound(err) {
+			return "", fmt.Errorf("could not find %q actions variable, actions secrets cannot be read back", key)
+		}
+		return "", err
+	}
+	return variable.Data, nil
+}
+
+// scopePath returns the API path of a gitea://<owner> organization or a
+// gitea://<owner>/<repo> repository.
+func scopePath(ref string) (string, error) {
+	split := strings.Split(ref, "/")
+	switch {
+	case len(split) == 1 && split[0] != "":
+		return "/orgs/" + url.PathEscape(split[0]), nil
+	case len(split) == 2 && split[0] != "" && split[1] != "":
+		return "/repos/" + url.PathEscape(split[0]) + "/" + url.PathEscape(split[1]), nil
+	default:
+		return "", errors.New("could not parse scheme, use gitea://<owner> or gitea://<owner>/<repo> format")
+	}
+}
+
+type client struct {
+	rest.Client
+}
+
+func newClient() (*client, error) {
+	token, ok := env.LookupEnv(env.VariableGiteaToken)
+	if !ok {
+		return nil, fmt.Errorf("could not find %q environment variable", env.VariableGiteaToken.String())
+	}
+	host := defaultHost
+	if h, ok := env.LookupEnv(env.VariableGiteaHost); ok {
+		host = h
+	}
+	return &client{rest.Client{
+		BaseURL:   strings.TrimSuffix(host, "/") + "/api/v1",
+		Authorize: func(r *http.Request) { r.Header.Set("Authorization", "token "+token) },
+	}}, nil
+}
+
+func (c *client) putSecret(ctx context.Context, scope, name string, value []byte) error {
+	if err := c.Do(ctx, http.MethodPut, scope+"/actions/secrets/"+name, map[string]string{"data": string(value)}, nil); err != nil {
+		return fmt.Errorf("could not create %q actions secret: %w", name, err)
+	}
+	return nil
+}
+
+func (c *client) putVariable(ctx context.Context, scope, name string, value []byte) error {
+	path := scope + "/actions/variables/" + name
+	err := c.Do(ctx, http.MethodPut, path, map[string]string{"name": name, "value": string(value)}, nil)
+	if rest.IsNotFound(err) {
+		err = c.Do(ctx, http.MethodPost, path, map[string]string{"value": string(value)}, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("could not create %q actions variable: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// giteaServer is a stand-in for the Gitea Actions secrets and variables API
+// of the owner organization and the owner/repo repository.
+func giteaServer(t *testing.T) (*httptest.Server, map[string]string, map[string]string) {
+	secrets, variables := map[string]string{}, map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+		if !strings.HasPrefix(path, "/orgs/owner/") && !strings.HasPrefix(path, "/repos/owner/repo/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var in map[string]string
+		if r.Body != http.NoBody {
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Error(err)
+			}
+		}
+		switch {
+		case strings.Contains(path, "/actions/secrets/") && r.Method == http.MethodPut:
+			secrets[path] = in["data"]
+			w.WriteHeader(http.StatusCreated)
+		case strings.Contains(path, "/actions/variables/"):
+			_, exists := variables[path]
+			switch r.Method {
+			case http.MethodGet:
+				if !exists {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]string{"data": variables[path]})
+			case http.MethodPut:
+				if !exists {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				variables[path] = in["value"]
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodPost:
+				if exists {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				variables[path] = in["value"]
+				w.WriteHeader(http.StatusCreated)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})), secrets, variables
+}
+
+func TestPutAndGetSecret(t *testing.T) {
+	server, secrets, variables := giteaServer(t)
+	defer server.Close()
+	t.Setenv("GITEA_HOST", server.URL)
+	t.Setenv("GITEA_TOKEN", "test-token")
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	pf := func(bool) ([]byte, error) { return []byte("hunter2"), nil }
+
+	g := New()
+	for _, ref := range []string{"owner", "owner/repo", "owner/repo"} {
+		if err := g.PutSecret(ctx, ref, pf); err != nil {
+			t.Fatalf("PutSecret(%q) error = %v", ref, err)
+		}
+	}
+	if secrets["/repos/owner/repo/actions/secrets/COSIGN_PASSWORD"] != "hunter2" ||
+		secrets["/orgs/owner/actions/secrets/COSIGN_PRIVATE_KEY"] == "" {
+		t.Errorf("unexpected secrets %v", secrets)
+	}
+	if len(variables) != 2 {
+		t.Errorf("unexpected variables %v", variables)
+	}
+
+	pub, err := g.GetSecret(ctx, "owner/repo", "COSIGN_PUBLIC_KEY")
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+	if b, err := os.ReadFile("cosign.pub"); err != nil || string(b) != pub {
+		t.Errorf("GetSecret() = %q, want the contents of cosign.pub", pub)
+	}
+	if _, err := g.GetSecret(ctx, "owner/repo", "COSIGN_PASSWORD"); err == nil {
+		t.Error("expected error reading an actions secret")
+	}
+	if err := g.PutSecret(ctx, "other/repo", pf); err == nil {
+		t.Error("expected error for an unknown repository")
+	}
+}
+
+func TestScopePath(t *testing.T) {
+	for ref, want := range map[string]string{
+		"owner":      "/orgs/owner",
+		"owner/repo": "/repos/owner/repo",
+		"":           "",
+		"owner/":     "",
+		"a/b/c":      "",
+	} {
+		got, err := scopePath(ref)
+		if got != want || (err != nil) != (want == "") {
+			t.Errorf("scopePath(%q) = %q, %v, want %q", ref, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsig
+
+import (
+	"encoding/pem"
+	"errors"
+)
+
+// bundlePEMType is the armor git recognises as an x509 signature. The block
+// holds a Sigstore bundle rather than a CMS structure, so x509 signatures
+// produced by cosign are verified with `cosign verify-commit`.
+const bundlePEMType = "SIGNED MESSAGE"
+
+// ArmorBundle wraps a JSON Sigstore bundle so git stores it as an x509
+// signature.
+func ArmorBundle(bundle []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: bundlePEMType, Bytes: bundle})
+}
+
+// DearmorBundle returns the Sigstore bundle in an armored x509 signature.
+func DearmorBundle(sig []byte) ([]byte, error) {
+	block, _ := pem.Decode(sig)
+	if block == nil || block.Type != bundlePEMType {
+		return nil, errors.New("signature is not an armored Sigstore bundle")
+	}
+	return block.Bytes, nil
+}
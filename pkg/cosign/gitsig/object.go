@@ -0,0 +1,190 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitsig produces and parses the signatures git stores in signed
+// commits and annotated tags.
+package gitsig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Format identifies the kind of signature embedded in a git object, using
+// the names of git's gpg.format setting.
+type Format string
+
+const (
+	FormatSSH     Format = "ssh"
+	FormatX509    Format = "x509"
+	FormatOpenPGP Format = "openpgp"
+)
+
+var armorFormats = []struct {
+	header string
+	format Format
+}{
+	{"-----BEGIN SSH SIGNATURE-----", FormatSSH},
+	{"-----BEGIN SIGNED MESSAGE-----", FormatX509},
+	{"-----BEGIN PGP SIGNATURE-----", FormatOpenPGP},
+	{"-----BEGIN PGP MESSAGE-----", FormatOpenPGP},
+}
+
+// DetectFormat returns the format of an armored signature.
+func DetectFormat(sig []byte) (Format, error) {
+	for _, a := range armorFormats {
+		if bytes.HasPrefix(sig, []byte(a.header)) {
+			return a.format, nil
+		}
+	}
+	return "", errors.New("unrecognized signature armor")
+}
+
+// Object is a signed git commit or annotated tag, split into the payload
+// that was signed and the armored signature.
+type Object struct {
+	Type      string
+	Payload   []byte
+	Signature []byte
+}
+
+// ErrUnsigned is returned when a commit or tag carries no signature.
+var ErrUnsigned = errors.New("object is not signed")
+
+// ParseObject splits the raw content of a commit or tag object, as printed by
+// `git cat-file <type> <rev>`, into its signed payload and signature.
+func ParseObject(objType string, raw []byte) (*Object, error) {
+	switch objType {
+	case "commit":
+		return parseCommit(raw)
+	case "tag":
+		return parseTag(raw)
+	default:
+		return nil, fmt.Errorf("unsupported object type %q, expected commit or tag", objType)
+	}
+}
+
+// parseCommit removes the gpgsig and gpgsig-sha256 headers, whose values
+// continue on lines that start with a single space, from the commit headers.
+// A commit can carry both, each signing the commit as written with one of
+// the object formats. The signature for the object format of the commit,
+// told apart by the length of its tree hash, is the one returned.
+func parseCommit(raw []byte) (*Object, error) {
+	headers, message, found := bytes.Cut(raw, []byte("\n\n"))
+	if !found {
+		return nil, errors.New("malformed commit: no blank line after headers")
+	}
+
+	var payload bytes.Buffer
+	sigs := map[string]*bytes.Buffer{}
+	var sig *bytes.Buffer
+	sigHeader := "gpgsig"
+	for _, line := range strings.SplitAfter(string(headers)+"\n", "\n") {
+		if sig != nil && strings.HasPrefix(line, " ") {
+			sig.WriteString(strings.TrimPrefix(line, " "))
+			continue
+		}
+		sig = nil
+		name, value, _ := strings.Cut(line, " ")
+		switch name {
+		case "gpgsig", "gpgsig-sha256":
+			sig = &bytes.Buffer{}
+			sig.WriteString(value)
+			sigs[name] = sig
+			continue
+		case "tree":
+			if len(strings.TrimSpace(value)) == sha256HexLen {
+				sigHeader = "gpgsig-sha256"
+			}
+		}
+		payload.WriteString(line)
+	}
+	if sigs[sigHeader] == nil || sigs[sigHeader].Len() == 0 {
+		return nil, ErrUnsigned
+	}
+	payload.WriteString("\n")
+	payload.Write(message)
+
+	signature := sigs[sigHeader].Bytes()
+	if !bytes.HasSuffix(signature, []byte("\n")) {
+		signature = append(signature, '\n')
+	}
+	return &Object{Type: "commit", Payload: payload.Bytes(), Signature: signature}, nil
+}
+
+// sha256HexLen is the length of an object name in a SHA-256 repository.
+const sha256HexLen = 64
+
+// parseTag splits off the signature git appends to the tag message.
+func parseTag(raw []byte) (*Object, error) {
+	start := -1
+	for _, a := range armorFormats {
+		var i int
+		if bytes.HasPrefix(raw, []byte(a.header)) {
+			i = 0
+		} else if i = bytes.LastIndex(raw, []byte("\n"+a.header)); i >= 0 {
+			i++
+		}
+		if i > start {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, ErrUnsigned
+	}
+	return &Object{Type: "tag", Payload: raw[:start], Signature: raw[start:]}, nil
+}
+
+// ReadObject reads the commit or annotated tag rev from the repository in
+// dir using the git executable.
+func ReadObject(ctx context.Context, dir, rev string) (*Object, error) {
+	out, err := runGit(ctx, dir, "cat-file", "-t", rev)
+	if err != nil {
+		return nil, err
+	}
+	objType := strings.TrimSpace(string(out))
+	if objType != "commit" && objType != "tag" {
+		return nil, fmt.Errorf("%s is a %s, expected a commit or annotated tag", rev, objType)
+	}
+	raw, err := runGit(ctx, dir, "cat-file", objType, rev)
+	if err != nil {
+		return nil, err
+	}
+	return ParseObject(objType, raw)
+}
+
+// ResolveRev returns the full object name of rev.
+func ResolveRev(ctx context.Context, dir, rev string) (string, error) {
+	out, err := runGit(ctx, dir, "rev-parse", "--verify", "--end-of-options", rev)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...) // #nosec G204
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const unsignedCommit = `tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+author A U Thor <author@example.com> 1700000000 +0000
+committer A U Thor <author@example.com> 1700000000 +0000
+
+initial commit
+`
+
+const signedCommit = `tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+author A U Thor <author@example.com> 1700000000 +0000
+committer A U Thor <author@example.com> 1700000000 +0000
+gpgsig -----BEGIN SSH SIGNATURE-----
+ U1NIU0lH
+ -----END SSH SIGNATURE-----
+
+initial commit
+`
+
+const sha256Tree = "6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321"
+
+const unsignedSHA256Commit = `tree ` + sha256Tree + `
+author A U Thor <author@example.com> 1700000000 +0000
+committer A U Thor <author@example.com> 1700000000 +0000
+
+initial commit
+`
+
+const signedSHA256Commit = `tree ` + sha256Tree + `
+author A U Thor <author@example.com> 1700000000 +0000
+committer A U Thor <author@example.com> 1700000000 +0000
+gpgsig-sha256 -----BEGIN SSH SIGNATURE-----
+ U0hBMjU2
+ -----END SSH SIGNATURE-----
+
+initial commit
+`
+
+// dualSignedCommit carries a signature for each object format, as written
+// by repositories converting between them.
+const dualSignedCommit = `tree %s
+author A U Thor <author@example.com> 1700000000 +0000
+committer A U Thor <author@example.com> 1700000000 +0000
+gpgsig -----BEGIN SSH SIGNATURE-----
+ U0hBMQ==
+ -----END SSH SIGNATURE-----
+gpgsig-sha256 -----BEGIN SIGNED MESSAGE-----
+ U0hBMjU2
+ -----END SIGNED MESSAGE-----
+
+initial commit
+`
+
+const signedTag = `object 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+type commit
+tag v1.0.0
+tagger A U Thor <author@example.com> 1700000000 +0000
+
+release v1.0.0
+-----BEGIN SIGNED MESSAGE-----
+e30=
+-----END SIGNED MESSAGE-----
+`
+
+func TestParseObject(t *testing.T) {
+	tests := []struct {
+		name          string
+		objType       string
+		raw           string
+		wantPayload   string
+		wantSignature string
+		wantFormat    Format
+	}{{
+		name:          "commit",
+		objType:       "commit",
+		raw:           signedCommit,
+		wantPayload:   unsignedCommit,
+		wantSignature: "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n",
+		wantFormat:    FormatSSH,
+	}, {
+		name:          "sha256 commit",
+		objType:       "commit",
+		raw:           signedSHA256Commit,
+		wantPayload:   unsignedSHA256Commit,
+		wantSignature: "-----BEGIN SSH SIGNATURE-----\nU0hBMjU2\n-----END SSH SIGNATURE-----\n",
+		wantFormat:    FormatSSH,
+	}, {
+		name:          "dual-signed sha1 commit",
+		objType:       "commit",
+		raw:           fmt.Sprintf(dualSignedCommit, "4b825dc642cb6eb9a060e54bf8d69288fbee4904"),
+		wantPayload:   unsignedCommit,
+		wantSignature: "-----BEGIN SSH SIGNATURE-----\nU0hBMQ==\n-----END SSH SIGNATURE-----\n",
+		wantFormat:    FormatSSH,
+	}, {
+		name:          "dual-signed sha256 commit",
+		objType:       "commit",
+		raw:           fmt.Sprintf(dualSignedCommit, sha256Tree),
+		wantPayload:   unsignedSHA256Commit,
+		wantSignature: "-----BEGIN SIGNED MESSAGE-----\nU0hBMjU2\n-----END SIGNED MESSAGE-----\n",
+		wantFormat:    FormatX509,
+	}, {
+		name:          "tag",
+		objType:       "tag",
+		raw:           signedTag,
+		wantPayload:   "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype commit\ntag v1.0.0\ntagger A U Thor <author@example.com> 1700000000 +0000\n\nrelease v1.0.0\n",
+		wantSignature: "-----BEGIN SIGNED MESSAGE-----\ne30=\n-----END SIGNED MESSAGE-----\n",
+		wantFormat:    FormatX509,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := ParseObject(tt.objType, []byte(tt.raw))
+			if err != nil {
+				t.Fatalf("ParseObject() error = %v", err)
+			}
+			if string(obj.Payload) != tt.wantPayload {
+				t.Errorf("payload = %q, want %q", obj.Payload, tt.wantPayload)
+			}
+			if string(obj.Signature) != tt.wantSignature {
+				t.Errorf("signature = %q, want %q", obj.Signature, tt.wantSignature)
+			}
+			if f, err := DetectFormat(obj.Signature); err != nil || f != tt.wantFormat {
+				t.Errorf("DetectFormat() = %v, %v, want %v", f, err, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestParseObjectUnsigned(t *testing.T) {
+	if _, err := ParseObject("commit", []byte(unsignedCommit)); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("commit: error = %v, want ErrUnsigned", err)
+	}
+	// A SHA-1 signature does not sign the commit in a SHA-256 repository.
+	sha1Signed := strings.Replace(signedSHA256Commit, "gpgsig-sha256 ", "gpgsig ", 1)
+	if _, err := ParseObject("commit", []byte(sha1Signed)); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("sha256 commit with a sha1 signature: error = %v, want ErrUnsigned", err)
+	}
+	if _, err := ParseObject("tag", []byte("object abc\ntype commit\ntag v1\n\nmessage\n")); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("tag: error = %v, want ErrUnsigned", err)
+	}
+	if _, err := ParseObject("blob", nil); err == nil {
+		t.Error("expected error for blob")
+	}
+}
+
+func TestArmorBundle(t *testing.T) {
+	bundle := []byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"}`)
+	sig := ArmorBundle(bundle)
+	if f, err := DetectFormat(sig); err != nil || f != FormatX509 {
+		t.Errorf("DetectFormat() = %v, %v", f, err)
+	}
+	got, err := DearmorBundle(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(bundle) {
+		t.Errorf("DearmorBundle() = %s, want %s", got, bundle)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"golang.org/x/crypto/ssh"
+)
+
+// Namespace is the SSH signature namespace git uses for commits and tags.
+const Namespace = "git"
+
+const (
+	sshsigMagic      = "SSHSIG"
+	sshsigVersion    = 1
+	sshsigHeader     = "-----BEGIN SSH SIGNATURE-----"
+	sshsigFooter     = "-----END SSH SIGNATURE-----"
+	sshsigLineLength = 70
+)
+
+// sshsigBlob is the armored SSHSIG structure, see PROTOCOL.sshsig in OpenSSH.
+type sshsigBlob struct {
+	Magic         [6]byte
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshsigSignedData is the structure the SSH key actually signs.
+type sshsigSignedData struct {
+	Magic         [6]byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+func signedData(namespace, hashAlgorithm string, message []byte) ([]byte, error) {
+	var h []byte
+	switch hashAlgorithm {
+	case "sha256":
+		sum := sha256.Sum256(message)
+		h = sum[:]
+	case "sha512":
+		sum := sha512.Sum512(message)
+		h = sum[:]
+	default:
+		return nil, fmt.Errorf("unsupported SSH signature hash algorithm %q", hashAlgorithm)
+	}
+	d := sshsigSignedData{Namespace: namespace, HashAlgorithm: hashAlgorithm, Hash: h}
+	copy(d.Magic[:], sshsigMagic)
+	return ssh.Marshal(d), nil
+}
+
+// cryptoSigner adapts a sigstore signer, which may be backed by a KMS or a
+// signer plugin, to the crypto.Signer expected by the ssh package.
+type cryptoSigner struct {
+	sv  signature.SignerVerifier
+	pub crypto.PublicKey
+}
+
+func (s *cryptoSigner) Public() crypto.PublicKey {
+	return s.pub
+}
+
+func (s *cryptoSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts == nil || opts.HashFunc() == crypto.Hash(0) {
+		// Ed25519 signs the message itself.
+		return s.sv.SignMessage(bytes.NewReader(digest))
+	}
+	return s.sv.SignMessage(bytes.NewReader(nil), options.WithDigest(digest), options.WithCryptoSignerOpts(opts))
+}
+
+// SignSSH returns an armored SSH signature over message in the given
+// namespace, in the format produced by `ssh-keygen -Y sign`. Only ECDSA and
+// Ed25519 keys are supported.
+func SignSSH(sv signature.SignerVerifier, namespace string, message []byte) ([]byte, error) {
+	pub, err := sv.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("getting public key: %w", err)
+	}
+	switch pub.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("SSH signatures require an ECDSA or Ed25519 key, got %T", pub)
+	}
+	signer, err := ssh.NewSignerFromSigner(&cryptoSigner{sv: sv, pub: pub})
+	if err != nil {
+		return nil, err
+	}
+
+	const hashAlgorithm = "sha512"
+	data, err := signedData(namespace, hashAlgorithm, message)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(rand.Reader, data)
+	if err != nil {
+		return nil, fmt.Errorf("signing: %w", err)
+	}
+	// Catch signers, such as Ed25519ph keys, whose output is not a valid
+	// SSH signature for their key type.
+	if err := signer.PublicKey().Verify(data, sig); err != nil {
+		return nil, fmt.Errorf("key produced an invalid SSH signature: %w", err)
+	}
+
+	b := sshsigBlob{
+		Version:       sshsigVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: hashAlgorithm,
+		Signature:     ssh.Marshal(sig),
+	}
+	copy(b.Magic[:], sshsigMagic)
+	return armorSSH(ssh.Marshal(b)), nil
+}
+
+func armorSSH(b []byte) []byte {
+	enc := base64.StdEncoding.EncodeToString(b)
+	var buf bytes.Buffer
+	buf.WriteString(sshsigHeader + "\n")
+	for len(enc) > sshsigLineLength {
+		buf.WriteString(enc[:sshsigLineLength] + "\n")
+		enc = enc[sshsigLineLength:]
+	}
+	buf.WriteString(enc + "\n")
+	buf.WriteString(sshsigFooter + "\n")
+	return buf.Bytes()
+}
+
+func dearmorSSH(sig []byte) ([]byte, error) {
+	s := strings.TrimSpace(string(sig))
+	if !strings.HasPrefix(s, sshsigHeader) || !strings.HasSuffix(s, sshsigFooter) {
+		return nil, errors.New("signature is not an armored SSH signature")
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, sshsigHeader), sshsigFooter)
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
+
+// VerifySSH verifies an armored SSH signature over message in the given
+// namespace and returns the public key that produced it. Callers must check
+// that the key is one they trust.
+func VerifySSH(sig []byte, namespace string, message []byte) (ssh.PublicKey, error) {
+	raw, err := dearmorSSH(sig)
+	if err != nil {
+		return nil, err
+	}
+	var b sshsigBlob
+	if err := ssh.Unmarshal(raw, &b); err != nil {
+		return nil, fmt.Errorf("parsing SSH signature: %w", err)
+	}
+	if string(b.Magic[:]) != sshsigMagic || b.Version != sshsigVersion {
+		return nil, errors.New("unsupported SSH signature version")
+	}
+	if b.Namespace != namespace {
+		return nil, fmt.Errorf("SSH signature namespace %q does not match %q", b.Namespace, namespace)
+	}
+	pub, err := ssh.ParsePublicKey(b.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SSH signature public key: %w", err)
+	}
+	s := &ssh.Signature{}
+	if err := ssh.Unmarshal(b.Signature, s); err != nil {
+		return nil, fmt.Errorf("parsing SSH signature: %w", err)
+	}
+	data, err := signedData(b.Namespace, b.HashAlgorithm, message)
+	if err != nil {
+		return nil, err
+	}
+	if err := pub.Verify(data, s); err != nil {
+		return nil, fmt.Errorf("invalid SSH signature: %w", err)
+	}
+	return pub, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"golang.org/x/crypto/ssh"
+)
+
+func testSigners(t *testing.T) map[string]signature.SignerVerifier {
+	t.Helper()
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers := map[string]signature.SignerVerifier{}
+	for name, k := range map[string]crypto.PrivateKey{"p256": p256, "p384": p384, "ed25519": ed} {
+		sv, err := signature.LoadSignerVerifier(k, crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers[name] = sv
+	}
+	return signers
+}
+
+func TestSignVerifySSH(t *testing.T) {
+	msg := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ncommit\n")
+	for name, sv := range testSigners(t) {
+		t.Run(name, func(t *testing.T) {
+			sig, err := SignSSH(sv, Namespace, msg)
+			if err != nil {
+				t.Fatalf("SignSSH() error = %v", err)
+			}
+			if f, err := DetectFormat(sig); err != nil || f != FormatSSH {
+				t.Errorf("DetectFormat() = %v, %v", f, err)
+			}
+			pub, err := VerifySSH(sig, Namespace, msg)
+			if err != nil {
+				t.Fatalf("VerifySSH() error = %v", err)
+			}
+			want, _ := sv.PublicKey()
+			wantSSH, err := ssh.NewPublicKey(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(pub.Marshal(), wantSSH.Marshal()) {
+				t.Error("VerifySSH() returned a different key")
+			}
+			if _, err := VerifySSH(sig, Namespace, append(msg, 'x')); err == nil {
+				t.Error("expected error for modified message")
+			}
+			if _, err := VerifySSH(sig, "file", msg); err == nil {
+				t.Error("expected error for wrong namespace")
+			}
+		})
+	}
+}
+
+func TestSignSSHUnsupportedKey(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sv, err := signature.LoadSignerVerifier(k, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SignSSH(sv, Namespace, []byte("msg")); err == nil {
+		t.Error("expected error for RSA key")
+	}
+}
+
+// TestSSHKeygenInterop checks signatures against OpenSSH's own verifier.
+func TestSSHKeygenInterop(t *testing.T) {
+	keygen, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	td := t.TempDir()
+	msg := []byte("signed payload\n")
+	msgPath := filepath.Join(td, "msg")
+	if err := os.WriteFile(msgPath, msg, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, sv := range testSigners(t) {
+		t.Run(name, func(t *testing.T) {
+			sig, err := SignSSH(sv, Namespace, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pub, _ := sv.PublicKey()
+			sshPub, err := ssh.NewPublicKey(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			allowed := filepath.Join(td, name+".allowed")
+			sigPath := filepath.Join(td, name+".sig")
+			if err := os.WriteFile(allowed, append([]byte("signer@example.com "), ssh.MarshalAuthorizedKey(sshPub)...), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(sigPath, sig, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Open(msgPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			cmd := exec.Command(keygen, "-Y", "verify", "-f", allowed, "-I", "signer@example.com", "-n", Namespace, "-s", sigPath)
+			cmd.Stdin = f
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("ssh-keygen -Y verify: %v: %s", err, out)
+			}
+		})
+	}
+}
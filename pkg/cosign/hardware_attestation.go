@@ -0,0 +1,166 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	// HardwareAttestationAnnotationKey is the signature annotation holding
+	// the PEM encoded hardware attestation chain of the signing key.
+	HardwareAttestationAnnotationKey = "dev.sigstore.cosign/hardware-attestation"
+	// HardwareAttestationPredicateField is the field of the cosign sign
+	// predicate holding the PEM encoded hardware attestation chain of the
+	// signing key in Sigstore bundles.
+	HardwareAttestationPredicateField = "hardwareAttestation"
+
+	// maxHardwareAttestationChain caps the number of certificates of a
+	// hardware attestation chain: the key attestation, the device
+	// attestation and the vendor intermediates.
+	maxHardwareAttestationChain = 5
+)
+
+// ParseHardwareAttestation parses a PEM encoded hardware attestation chain.
+// The first certificate attests the signing key, and is followed by the
+// intermediates up to, but not necessarily including, the vendor root.
+func ParseHardwareAttestation(chainPEM []byte) ([]*x509.Certificate, error) {
+	chain, err := cryptoutils.UnmarshalCertificatesFromPEM(chainPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing hardware attestation: %w", err)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("hardware attestation contains no certificates")
+	}
+	return chain, nil
+}
+
+// VerifyHardwareAttestation verifies that the PEM encoded attestation chain
+// chains up to one of roots and attests pub, proving that the private key
+// of pub was generated on, and cannot be exported from, a hardware token.
+func VerifyHardwareAttestation(chainPEM []byte, roots *x509.CertPool, pub crypto.PublicKey) error {
+	chain, err := ParseHardwareAttestation(chainPEM)
+	if err != nil {
+		return err
+	}
+	if err := cryptoutils.EqualKeys(chain[0].PublicKey, pub); err != nil {
+		return errors.New("hardware attestation does not attest the signing key")
+	}
+	if len(chain) > maxHardwareAttestationChain {
+		return fmt.Errorf("hardware attestation chain has %d certificates, at most %d are allowed", len(chain), maxHardwareAttestationChain)
+	}
+	intermediates := x509.NewCertPool()
+	for i, c := range chain[1:] {
+		// The device attestation certificates of older YubiKeys, which sign
+		// the key attestation, lack the basic constraints extension Go
+		// requires of CA certificates. Only the issuer of the key
+		// attestation is treated as a CA without it, and only one that
+		// cannot issue further CAs; every other certificate of the chain
+		// must be a proper CA.
+		if i == 0 && !c.BasicConstraintsValid {
+			ca := *c
+			ca.BasicConstraintsValid = true
+			ca.IsCA = true
+			ca.MaxPathLen = 0
+			ca.MaxPathLenZero = true
+			c = &ca
+		}
+		intermediates.AddCert(c)
+	}
+	if _, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		// Attestation certificates carry vendor specific extended key usages,
+		// if any.
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("verifying hardware attestation chain: %w", err)
+	}
+	return nil
+}
+
+// checkHardwareAttestation checks the hardware attestation annotation of sig
+// against the public key of the verifier that verified it.
+func checkHardwareAttestation(co *CheckOpts, sig oci.Signature, pub crypto.PublicKey) error {
+	annotations, err := sig.Annotations()
+	if err != nil {
+		return err
+	}
+	chain, ok := annotations[HardwareAttestationAnnotationKey]
+	if !ok {
+		return &VerificationFailure{errors.New("signature carries no hardware attestation")}
+	}
+	if err := VerifyHardwareAttestation([]byte(chain), co.HardwareAttestationRoots, pub); err != nil {
+		return &VerificationFailure{err}
+	}
+	return nil
+}
+
+// checkBundleHardwareAttestation checks the hardware attestation held in the
+// cosign sign predicate of a verified bundle against the key it was signed
+// with.
+func checkBundleHardwareAttestation(co *CheckOpts, bundle verify.SignedEntity) error {
+	pub, err := bundleSigningKey(co, bundle)
+	if err != nil {
+		return err
+	}
+	sc, err := bundle.SignatureContent()
+	if err != nil {
+		return err
+	}
+	envelope := sc.EnvelopeContent()
+	if envelope == nil {
+		return &VerificationFailure{errors.New("bundle carries no hardware attestation")}
+	}
+	statement, err := envelope.Statement()
+	if err != nil {
+		return err
+	}
+	chain := statement.GetPredicate().GetFields()[HardwareAttestationPredicateField].GetStringValue()
+	if chain == "" {
+		return &VerificationFailure{errors.New("bundle carries no hardware attestation")}
+	}
+	if err := VerifyHardwareAttestation([]byte(chain), co.HardwareAttestationRoots, pub); err != nil {
+		return &VerificationFailure{err}
+	}
+	return nil
+}
+
+// bundleSigningKey returns the public key a verified bundle was signed with.
+func bundleSigningKey(co *CheckOpts, bundle verify.SignedEntity) (crypto.PublicKey, error) {
+	vc, err := bundle.VerificationContent()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case vc.Certificate() != nil:
+		return vc.Certificate().PublicKey, nil
+	case co.SigVerifier != nil:
+		return co.SigVerifier.PublicKey(co.PKOpts...)
+	case co.KeySet != nil && vc.PublicKey() != nil:
+		if k := co.KeySet.key(vc.PublicKey().Hint()); k != nil {
+			return cryptoutils.UnmarshalPEMToPublicKey([]byte(k.PublicKey))
+		}
+	}
+	return nil, errors.New("unable to determine the signing key of the bundle")
+}
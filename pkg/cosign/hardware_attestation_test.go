@@ -0,0 +1,271 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	intotov1 "github.com/in-toto/attestation/go/v1"
+	ssldsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/cosign/v3/internal/test"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	"github.com/sigstore/cosign/v3/pkg/types"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	sgbundle "github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/testing/ca"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// hardwareAttestationChain returns a vendor root, and an attestation chain
+// of a freshly generated key in the style of a YubiKey: the key attestation
+// is signed by a per-device intermediate lacking basic constraints.
+func hardwareAttestationChain(t *testing.T) (*x509.CertPool, []byte, *ecdsa.PrivateKey) {
+	t.Helper()
+	root, rootKey, err := test.GenerateRootCa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deviceKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Yubico PIV Attestation"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, root, &deviceKey.PublicKey, rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	device, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, key := attestKey(t, device, deviceKey)
+	chain, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{leaf, device})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	return roots, chain, key
+}
+
+// attestKey returns a freshly generated key and its attestation signed by
+// parent. Like those of a YubiKey, the attestation lacks the basic
+// constraints and key usage extensions.
+func attestKey(t *testing.T, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "YubiKey PIV Attestation 9c"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestVerifyHardwareAttestation(t *testing.T) {
+	roots, chain, key := hardwareAttestationChain(t)
+	otherRoots, _, otherKey := hardwareAttestationChain(t)
+
+	if err := VerifyHardwareAttestation(chain, roots, key.Public()); err != nil {
+		t.Fatalf("VerifyHardwareAttestation() error = %v", err)
+	}
+	if err := VerifyHardwareAttestation(chain, roots, otherKey.Public()); err == nil {
+		t.Error("VerifyHardwareAttestation() of another key should fail")
+	}
+	if err := VerifyHardwareAttestation(chain, otherRoots, key.Public()); err == nil {
+		t.Error("VerifyHardwareAttestation() with another vendor root should fail")
+	}
+	leafOnly, _, _ := bytes.Cut(chain, []byte("-----END CERTIFICATE-----\n"))
+	leafOnly = append(leafOnly, []byte("-----END CERTIFICATE-----\n")...)
+	if err := VerifyHardwareAttestation(leafOnly, roots, key.Public()); err == nil {
+		t.Error("VerifyHardwareAttestation() without the device certificate should fail")
+	}
+	if err := VerifyHardwareAttestation([]byte("not a certificate"), roots, key.Public()); err == nil {
+		t.Error("VerifyHardwareAttestation() of garbage should fail")
+	}
+
+	// A key attestation lacks basic constraints as well, but must not act
+	// as a CA attesting a key of the holder's choosing.
+	certs, err := ParseHardwareAttestation(chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, forgedKey := attestKey(t, certs[0], key)
+	forgedChain, err := cryptoutils.MarshalCertificatesToPEM(append([]*x509.Certificate{forged}, certs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyHardwareAttestation(forgedChain, roots, forgedKey.Public()); err == nil {
+		t.Error("VerifyHardwareAttestation() of a key attested by another key attestation should fail")
+	}
+
+	var long []byte
+	for range maxHardwareAttestationChain {
+		long = append(long, chain...)
+	}
+	if err := VerifyHardwareAttestation(long, roots, key.Public()); err == nil {
+		t.Error("VerifyHardwareAttestation() of an overlong chain should fail")
+	}
+}
+
+func TestVerifyImageSignatureHardwareAttestation(t *testing.T) {
+	roots, chain, key := hardwareAttestationChain(t)
+	sv, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte(`{"critical":{}}`)
+	sigBytes, err := sv.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64sig := base64.StdEncoding.EncodeToString(sigBytes)
+	co := &CheckOpts{
+		SigVerifier:              sv,
+		IgnoreTlog:               true,
+		HardwareAttestationRoots: roots,
+	}
+
+	attested, err := static.NewSignature(payload, b64sig, static.WithAnnotations(map[string]string{
+		HardwareAttestationAnnotationKey: string(chain),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyImageSignature(context.Background(), attested, v1.Hash{}, co); err != nil {
+		t.Errorf("VerifyImageSignature() of an attested signature error = %v", err)
+	}
+
+	unattested, err := static.NewSignature(payload, b64sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyImageSignature(context.Background(), unattested, v1.Hash{}, co); err == nil {
+		t.Error("VerifyImageSignature() of a signature without hardware attestation should fail")
+	}
+}
+
+func TestVerifyNewBundleHardwareAttestation(t *testing.T) {
+	virtualSigstore, err := ca.NewVirtualSigstore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots, chain, key := hardwareAttestationChain(t)
+	sv, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("image"))
+
+	signBundle := func(predicate map[string]any) *sgbundle.Bundle {
+		t.Helper()
+		p, err := structpb.NewStruct(predicate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload, err := protojson.Marshal(&intotov1.Statement{
+			Type:          intotov1.StatementTypeUri,
+			Subject:       []*intotov1.ResourceDescriptor{{Digest: map[string]string{"sha256": hex.EncodeToString(digest[:])}}},
+			PredicateType: types.CosignSignPredicateType,
+			Predicate:     p,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		const payloadType = "application/vnd.in-toto+json"
+		sig, err := sv.SignMessage(bytes.NewReader(ssldsse.PAE(payloadType, payload)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts, err := virtualSigstore.TimestampResponse(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := sgbundle.NewBundle(&protobundle.Bundle{
+			MediaType: "application/vnd.dev.sigstore.bundle+json;version=0.3",
+			VerificationMaterial: &protobundle.VerificationMaterial{
+				Content: &protobundle.VerificationMaterial_PublicKey{
+					PublicKey: &protocommon.PublicKeyIdentifier{},
+				},
+				TimestampVerificationData: &protobundle.TimestampVerificationData{
+					Rfc3161Timestamps: []*protocommon.RFC3161SignedTimestamp{{SignedTimestamp: ts}},
+				},
+			},
+			Content: &protobundle.Bundle_DsseEnvelope{
+				DsseEnvelope: &protodsse.Envelope{
+					Payload:     payload,
+					PayloadType: payloadType,
+					Signatures:  []*protodsse.Signature{{Sig: sig}},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	co := &CheckOpts{
+		UseSignedTimestamps:      true,
+		IgnoreTlog:               true,
+		TrustedMaterial:          virtualSigstore,
+		SigVerifier:              sv,
+		HardwareAttestationRoots: roots,
+	}
+	policy := verify.WithArtifactDigest("sha256", digest[:])
+
+	attested := signBundle(map[string]any{HardwareAttestationPredicateField: string(chain)})
+	if _, err := VerifyNewBundle(context.Background(), co, policy, attested); err != nil {
+		t.Errorf("VerifyNewBundle() of an attested bundle error = %v", err)
+	}
+	if _, err := VerifyNewBundle(context.Background(), co, policy, signBundle(map[string]any{})); err == nil {
+		t.Error("VerifyNewBundle() of a bundle without hardware attestation should fail")
+	}
+}
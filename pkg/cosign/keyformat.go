@@ -0,0 +1,445 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/secure-systems-lab/go-securesystemslib/encrypted"
+	"github.com/sigstore/sigstore/pkg/cryptoutils/goodkey"
+	"github.com/youmark/pkcs8"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// PEM-encoded PKCS #8 private key encrypted with PBES2
+	EncryptedPrivateKeyPemType = "ENCRYPTED PRIVATE KEY"
+	// PEM-encoded OpenSSH private key, as written by ssh-keygen
+	OpenSSHPrivateKeyPemType = "OPENSSH PRIVATE KEY"
+)
+
+// PrivateKeyFormat is an encoding of a password protected private key.
+type PrivateKeyFormat string
+
+const (
+	// PrivateKeyFormatCosign is the ENCRYPTED SIGSTORE PRIVATE KEY format
+	// written by generate-key-pair, using scrypt and nacl/secretbox.
+	PrivateKeyFormatCosign PrivateKeyFormat = "cosign"
+	// PrivateKeyFormatPKCS8 is an encrypted PKCS #8 private key using
+	// PBKDF2-HMAC-SHA256 and AES-256-CBC, as understood by OpenSSL.
+	PrivateKeyFormatPKCS8 PrivateKeyFormat = "pkcs8"
+	// PrivateKeyFormatOpenSSH is an OpenSSH private key using bcrypt and
+	// AES-256-CTR, as understood by ssh-keygen.
+	PrivateKeyFormatOpenSSH PrivateKeyFormat = "openssh"
+)
+
+// PrivateKeyFormats returns the names of the supported private key formats.
+func PrivateKeyFormats() []string {
+	return []string{string(PrivateKeyFormatCosign), string(PrivateKeyFormatPKCS8), string(PrivateKeyFormatOpenSSH)}
+}
+
+// KDFStrength selects how expensive it is to derive the encryption key of a
+// private key from its password.
+type KDFStrength string
+
+const (
+	// KDFStrengthLegacy is scrypt N=2^15, or 10,000 PBKDF2 iterations.
+	KDFStrengthLegacy KDFStrength = "legacy"
+	// KDFStrengthStandard is scrypt N=2^16, or 100,000 PBKDF2 iterations.
+	// It is used for newly generated keys.
+	KDFStrengthStandard KDFStrength = "standard"
+	// KDFStrengthOWASP is scrypt N=2^17, or 600,000 PBKDF2 iterations, as
+	// recommended by OWASP.
+	KDFStrengthOWASP KDFStrength = "owasp"
+)
+
+// KDFStrengths returns the names of the supported KDF strengths.
+func KDFStrengths() []string {
+	return []string{string(KDFStrengthLegacy), string(KDFStrengthStandard), string(KDFStrengthOWASP)}
+}
+
+var kdfStrengths = map[KDFStrength]struct {
+	scrypt     encrypted.KDFParameterStrength
+	scryptN    int
+	iterations int
+}{
+	KDFStrengthLegacy:   {encrypted.Legacy, 1 << 15, 10_000},
+	KDFStrengthStandard: {encrypted.Standard, 1 << 16, 100_000},
+	KDFStrengthOWASP:    {encrypted.OWASP, 1 << 17, 600_000},
+}
+
+// DecryptPrivateKey decrypts a PEM-encoded private key in any of the
+// supported formats. Unencrypted PKCS #8 and OpenSSH keys are accepted as
+// well, in which case the password is ignored.
+func DecryptPrivateKey(key []byte, pass []byte) (crypto.Signer, error) {
+	p, _ := pem.Decode(key)
+	if p == nil {
+		return nil, errors.New("invalid pem block")
+	}
+
+	var pk any
+	var err error
+	switch p.Type {
+	case CosignPrivateKeyPemType, SigstorePrivateKeyPemType:
+		x509Encoded, err := encrypted.Decrypt(p.Bytes, pass)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt: %w", err)
+		}
+		if pk, err = x509.ParsePKCS8PrivateKey(x509Encoded); err != nil {
+			return nil, fmt.Errorf("parsing private key: %w", err)
+		}
+	case EncryptedPrivateKeyPemType:
+		if pk, err = pkcs8.ParsePKCS8PrivateKey(p.Bytes, pass); err != nil {
+			return nil, fmt.Errorf("decrypt: %w", err)
+		}
+	case PrivateKeyPemType:
+		if pk, err = x509.ParsePKCS8PrivateKey(p.Bytes); err != nil {
+			return nil, fmt.Errorf("parsing pkcs #8 private key: %w", err)
+		}
+	case OpenSSHPrivateKeyPemType:
+		pk, err = ssh.ParseRawPrivateKey(key)
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			pk, err = ssh.ParseRawPrivateKeyWithPassphrase(key, pass)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decrypt: %w", err)
+		}
+		// ssh returns a pointer to ed25519 keys, unlike crypto/x509
+		if k, ok := pk.(*ed25519.PrivateKey); ok {
+			pk = *k
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pem type: %s", p.Type)
+	}
+	return validatePrivateKey(pk)
+}
+
+func validatePrivateKey(pk any) (crypto.Signer, error) {
+	switch k := pk.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		signer := k.(crypto.Signer)
+		if err := goodkey.ValidatePubKey(signer.Public()); err != nil {
+			return nil, fmt.Errorf("error validating key: %w", err)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unexpected private key type %T", pk)
+	}
+}
+
+// EncryptPrivateKey encodes the private key in the given format, encrypted
+// with the password. An empty KDF strength selects KDFStrengthStandard.
+// OpenSSH keys always use bcrypt with 16 rounds, so their KDF strength cannot
+// be chosen. The password must not be empty, use MarshalUnencryptedPrivateKey
+// to write a key without one.
+func EncryptPrivateKey(pk crypto.Signer, format PrivateKeyFormat, strength KDFStrength, pass []byte) ([]byte, error) {
+	if len(pass) == 0 {
+		return nil, errors.New("refusing to encrypt a private key with an empty password")
+	}
+	return encodePrivateKey(pk, format, strength, pass)
+}
+
+// MarshalUnencryptedPrivateKey encodes the private key in the given format
+// without a password. Keys in the cosign format are always encrypted, and
+// are encrypted with an empty password instead.
+func MarshalUnencryptedPrivateKey(pk crypto.Signer, format PrivateKeyFormat) ([]byte, error) {
+	return encodePrivateKey(pk, format, KDFStrengthStandard, nil)
+}
+
+func encodePrivateKey(pk crypto.Signer, format PrivateKeyFormat, strength KDFStrength, pass []byte) ([]byte, error) {
+	if strength == "" {
+		strength = KDFStrengthStandard
+	}
+	kdf, ok := kdfStrengths[strength]
+	if !ok {
+		return nil, fmt.Errorf("unsupported KDF strength %q, must be one of %s", strength, strings.Join(KDFStrengths(), ", "))
+	}
+
+	switch format {
+	case PrivateKeyFormatCosign:
+		x509Encoded, err := x509.MarshalPKCS8PrivateKey(pk)
+		if err != nil {
+			return nil, fmt.Errorf("x509 encoding private key: %w", err)
+		}
+		encBytes, err := encrypted.EncryptWithCustomKDFParameters(x509Encoded, pass, kdf.scrypt)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: SigstorePrivateKeyPemType, Bytes: encBytes}), nil
+	case PrivateKeyFormatPKCS8:
+		der, err := pkcs8.MarshalPrivateKey(pk, pass, &pkcs8.Opts{
+			Cipher: pkcs8.AES256CBC,
+			KDFOpts: pkcs8.PBKDF2Opts{
+				SaltSize:       16,
+				IterationCount: kdf.iterations,
+				HMACHash:       crypto.SHA256,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pkcs #8 encoding private key: %w", err)
+		}
+		ptype := EncryptedPrivateKeyPemType
+		if len(pass) == 0 {
+			ptype = PrivateKeyPemType
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: ptype, Bytes: der}), nil
+	case PrivateKeyFormatOpenSSH:
+		if strength != KDFStrengthStandard {
+			return nil, errors.New("the KDF strength of OpenSSH keys cannot be changed")
+		}
+		var block *pem.Block
+		var err error
+		if len(pass) == 0 {
+			block, err = ssh.MarshalPrivateKey(pk, "")
+		} else {
+			block, err = ssh.MarshalPrivateKeyWithPassphrase(pk, "", pass)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("openssh encoding private key: %w", err)
+		}
+		return pem.EncodeToMemory(block), nil
+	default:
+		return nil, fmt.Errorf("unsupported private key format %q, must be one of %s", format, strings.Join(PrivateKeyFormats(), ", "))
+	}
+}
+
+// PrivateKeyInfo describes an encoded private key.
+type PrivateKeyInfo struct {
+	Format  PrivateKeyFormat
+	PEMType string
+	// Algorithm is the key type and size, e.g. "ECDSA P-256".
+	Algorithm string
+	// KDF is the name of the password based key derivation function, and
+	// KDFParams its parameters. Both are empty for unencrypted keys.
+	KDF       string
+	KDFParams string
+	// KDFStrength is set if the KDF parameters match one of the strengths
+	// EncryptPrivateKey can produce for this format.
+	KDFStrength KDFStrength
+	Cipher      string
+	// KeyID is the public key ID, see PublicKeyID.
+	KeyID string
+	// SSHFingerprint is the SHA256 fingerprint of the public key, as shown
+	// by ssh-keygen -l.
+	SSHFingerprint string
+}
+
+// InspectPrivateKey decrypts a PEM-encoded private key in any of the
+// supported formats and describes its encoding and public key.
+func InspectPrivateKey(key []byte, pass []byte) (*PrivateKeyInfo, error) {
+	pk, err := DecryptPrivateKey(key, pass)
+	if err != nil {
+		return nil, err
+	}
+	p, _ := pem.Decode(key)
+
+	info := &PrivateKeyInfo{PEMType: p.Type}
+	switch p.Type {
+	case CosignPrivateKeyPemType, SigstorePrivateKeyPemType:
+		info.Format = PrivateKeyFormatCosign
+		err = info.cosignKDF(p.Bytes)
+	case EncryptedPrivateKeyPemType:
+		info.Format = PrivateKeyFormatPKCS8
+		err = info.pkcs8KDF(p.Bytes)
+	case PrivateKeyPemType:
+		info.Format = PrivateKeyFormatPKCS8
+	case OpenSSHPrivateKeyPemType:
+		info.Format = PrivateKeyFormatOpenSSH
+		err = info.openSSHKDF(p.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", p.Type, err)
+	}
+
+	switch pub := pk.Public().(type) {
+	case *ecdsa.PublicKey:
+		info.Algorithm = "ECDSA " + pub.Curve.Params().Name
+	case *rsa.PublicKey:
+		info.Algorithm = fmt.Sprintf("RSA %d", pub.N.BitLen())
+	case ed25519.PublicKey:
+		info.Algorithm = "Ed25519"
+	}
+	if info.KeyID, err = PublicKeyID(pk.Public()); err != nil {
+		return nil, err
+	}
+	sshPub, err := ssh.NewPublicKey(pk.Public())
+	if err != nil {
+		return nil, err
+	}
+	info.SSHFingerprint = ssh.FingerprintSHA256(sshPub)
+	return info, nil
+}
+
+func (info *PrivateKeyInfo) cosignKDF(b []byte) error {
+	var data struct {
+		KDF struct {
+			Name   string `json:"name"`
+			Params struct {
+				N int `json:"N"`
+				R int `json:"r"`
+				P int `json:"p"`
+			} `json:"params"`
+		} `json:"kdf"`
+		Cipher struct {
+			Name string `json:"name"`
+		} `json:"cipher"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	params := data.KDF.Params
+	info.KDF = data.KDF.Name
+	info.KDFParams = fmt.Sprintf("N=%d r=%d p=%d", params.N, params.R, params.P)
+	info.Cipher = data.Cipher.Name
+	for strength, kdf := range kdfStrengths {
+		if params.N == kdf.scryptN && params.R == 8 && params.P == 1 {
+			info.KDFStrength = strength
+		}
+	}
+	return nil
+}
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+
+	pkcs8Ciphers = map[string]string{
+		"2.16.840.1.101.3.4.1.2":  "aes-128-cbc",
+		"2.16.840.1.101.3.4.1.6":  "aes-128-gcm",
+		"2.16.840.1.101.3.4.1.22": "aes-192-cbc",
+		"2.16.840.1.101.3.4.1.26": "aes-192-gcm",
+		"2.16.840.1.101.3.4.1.42": "aes-256-cbc",
+		"2.16.840.1.101.3.4.1.46": "aes-256-gcm",
+		"1.2.840.113549.3.7":      "des-ede3-cbc",
+	}
+)
+
+func (info *PrivateKeyInfo) pkcs8KDF(der []byte) error {
+	var epki struct {
+		EncryptionAlgorithm pkix.AlgorithmIdentifier
+		EncryptedData       []byte
+	}
+	if _, err := asn1.Unmarshal(der, &epki); err != nil {
+		return err
+	}
+	if !epki.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return fmt.Errorf("unsupported encryption algorithm %s", epki.EncryptionAlgorithm.Algorithm)
+	}
+	var pbes2 struct {
+		KeyDerivationFunc pkix.AlgorithmIdentifier
+		EncryptionScheme  pkix.AlgorithmIdentifier
+	}
+	if _, err := asn1.Unmarshal(epki.EncryptionAlgorithm.Parameters.FullBytes, &pbes2); err != nil {
+		return err
+	}
+	info.Cipher = pkcs8Ciphers[pbes2.EncryptionScheme.Algorithm.String()]
+	if info.Cipher == "" {
+		info.Cipher = pbes2.EncryptionScheme.Algorithm.String()
+	}
+
+	kdfParams := pbes2.KeyDerivationFunc.Parameters.FullBytes
+	switch kdf := pbes2.KeyDerivationFunc.Algorithm; {
+	case kdf.Equal(oidPBKDF2):
+		var params struct {
+			Salt           []byte
+			IterationCount int
+			KeyLength      int                      `asn1:"optional"`
+			PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+		}
+		if _, err := asn1.Unmarshal(kdfParams, &params); err != nil {
+			return err
+		}
+		switch {
+		case len(params.PRF.Algorithm) == 0 || params.PRF.Algorithm.Equal(oidHMACWithSHA1):
+			info.KDF = "pbkdf2-hmac-sha1"
+		case params.PRF.Algorithm.Equal(oidHMACWithSHA256):
+			info.KDF = "pbkdf2-hmac-sha256"
+		default:
+			info.KDF = "pbkdf2-" + params.PRF.Algorithm.String()
+		}
+		info.KDFParams = fmt.Sprintf("iterations=%d", params.IterationCount)
+		for strength, kdf := range kdfStrengths {
+			if info.KDF == "pbkdf2-hmac-sha256" && params.IterationCount == kdf.iterations {
+				info.KDFStrength = strength
+			}
+		}
+	case kdf.Equal(oidScrypt):
+		var params struct {
+			Salt                     []byte
+			CostParameter            int
+			BlockSize                int
+			ParallelizationParameter int
+		}
+		if _, err := asn1.Unmarshal(kdfParams, &params); err != nil {
+			return err
+		}
+		info.KDF = "scrypt"
+		info.KDFParams = fmt.Sprintf("N=%d r=%d p=%d", params.CostParameter, params.BlockSize, params.ParallelizationParameter)
+	default:
+		info.KDF = kdf.String()
+	}
+	return nil
+}
+
+func (info *PrivateKeyInfo) openSSHKDF(b []byte) error {
+	const magic = "openssh-key-v1\x00"
+	if !strings.HasPrefix(string(b), magic) {
+		return errors.New("invalid openssh private key")
+	}
+	var header struct {
+		CipherName string
+		KdfName    string
+		KdfOpts    string
+		Rest       []byte `ssh:"rest"`
+	}
+	if err := ssh.Unmarshal(b[len(magic):], &header); err != nil {
+		return err
+	}
+	if header.KdfName == "none" {
+		return nil
+	}
+	info.KDF = header.KdfName
+	info.Cipher = header.CipherName
+	if header.KdfName == "bcrypt" {
+		var opts struct {
+			Salt   string
+			Rounds uint32
+		}
+		if err := ssh.Unmarshal([]byte(header.KdfOpts), &opts); err != nil {
+			return err
+		}
+		info.KDFParams = fmt.Sprintf("rounds=%d", opts.Rounds)
+		if opts.Rounds == 16 {
+			info.KDFStrength = KDFStrengthStandard
+		}
+	}
+	return nil
+}
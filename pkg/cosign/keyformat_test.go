@@ -0,0 +1,182 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+func TestEncryptPrivateKeyRoundTrip(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		key       crypto.Signer
+		algorithm string
+	}{
+		{"ecdsa", ecKey, "ECDSA P-256"},
+		{"rsa", rsaKey, "RSA 2048"},
+		{"ed25519", edKey, "Ed25519"},
+	}
+	formats := []struct {
+		format  PrivateKeyFormat
+		pemType string
+		kdf     string
+	}{
+		{PrivateKeyFormatCosign, SigstorePrivateKeyPemType, "scrypt"},
+		{PrivateKeyFormatPKCS8, EncryptedPrivateKeyPemType, "pbkdf2-hmac-sha256"},
+		{PrivateKeyFormatOpenSSH, OpenSSHPrivateKeyPemType, "bcrypt"},
+	}
+	for _, tt := range tests {
+		for _, f := range formats {
+			t.Run(tt.name+"/"+string(f.format), func(t *testing.T) {
+				b, err := EncryptPrivateKey(tt.key, f.format, "", []byte("hunter2"))
+				if err != nil {
+					t.Fatalf("EncryptPrivateKey() error = %v", err)
+				}
+				if _, err := DecryptPrivateKey(b, []byte("wrong")); err == nil {
+					t.Error("DecryptPrivateKey() with the wrong password succeeded")
+				}
+				pk, err := DecryptPrivateKey(b, []byte("hunter2"))
+				if err != nil {
+					t.Fatalf("DecryptPrivateKey() error = %v", err)
+				}
+				if !pk.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(tt.key.Public()) {
+					t.Error("decrypted key does not match")
+				}
+
+				info, err := InspectPrivateKey(b, []byte("hunter2"))
+				if err != nil {
+					t.Fatalf("InspectPrivateKey() error = %v", err)
+				}
+				if info.Format != f.format || info.PEMType != f.pemType || info.KDF != f.kdf ||
+					info.KDFStrength != KDFStrengthStandard || info.Algorithm != tt.algorithm {
+					t.Errorf("unexpected key info %+v", info)
+				}
+				if want, _ := PublicKeyID(tt.key.Public()); info.KeyID != want {
+					t.Errorf("KeyID = %q, want %q", info.KeyID, want)
+				}
+				if !strings.HasPrefix(info.SSHFingerprint, "SHA256:") {
+					t.Errorf("SSHFingerprint = %q", info.SSHFingerprint)
+				}
+			})
+		}
+	}
+}
+
+func TestEncryptPrivateKeyStrength(t *testing.T) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		format PrivateKeyFormat
+		params string
+	}{
+		{PrivateKeyFormatCosign, "N=32768 r=8 p=1"},
+		{PrivateKeyFormatPKCS8, "iterations=10000"},
+	} {
+		b, err := EncryptPrivateKey(pk, tt.format, KDFStrengthLegacy, []byte("pass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := InspectPrivateKey(b, []byte("pass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.KDFStrength != KDFStrengthLegacy || info.KDFParams != tt.params {
+			t.Errorf("%s: KDF = %s %s, want legacy %s", tt.format, info.KDFStrength, info.KDFParams, tt.params)
+		}
+	}
+
+	if _, err := EncryptPrivateKey(pk, PrivateKeyFormatOpenSSH, KDFStrengthOWASP, []byte("pass")); err == nil {
+		t.Error("EncryptPrivateKey() changed the KDF strength of an OpenSSH key")
+	}
+	if _, err := EncryptPrivateKey(pk, PrivateKeyFormatCosign, "extreme", []byte("pass")); err == nil {
+		t.Error("EncryptPrivateKey() accepted an unknown KDF strength")
+	}
+	if _, err := EncryptPrivateKey(pk, "jks", "", []byte("pass")); err == nil {
+		t.Error("EncryptPrivateKey() accepted an unknown format")
+	}
+}
+
+func TestMarshalUnencryptedPrivateKey(t *testing.T) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for format, pemType := range map[PrivateKeyFormat]string{
+		PrivateKeyFormatCosign:  SigstorePrivateKeyPemType,
+		PrivateKeyFormatPKCS8:   PrivateKeyPemType,
+		PrivateKeyFormatOpenSSH: OpenSSHPrivateKeyPemType,
+	} {
+		if _, err := EncryptPrivateKey(pk, format, "", nil); err == nil {
+			t.Errorf("%s: EncryptPrivateKey() with an empty password succeeded", format)
+		}
+		b, err := MarshalUnencryptedPrivateKey(pk, format)
+		if err != nil {
+			t.Fatalf("%s: MarshalUnencryptedPrivateKey() error = %v", format, err)
+		}
+		if p, _ := pem.Decode(b); p.Type != pemType {
+			t.Errorf("%s: pem type = %s, want %s", format, p.Type, pemType)
+		}
+		info, err := InspectPrivateKey(b, nil)
+		if err != nil {
+			t.Fatalf("%s: InspectPrivateKey() error = %v", format, err)
+		}
+		if format != PrivateKeyFormatCosign && info.KDF != "" {
+			t.Errorf("%s: unencrypted key reports KDF %q", format, info.KDF)
+		}
+	}
+}
+
+func TestDecryptPrivateKeyGenerated(t *testing.T) {
+	keys, err := GenerateKeyPair(pass("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := DecryptPrivateKey(keys.PrivateBytes, []byte("hello"))
+	if err != nil {
+		t.Fatalf("DecryptPrivateKey() error = %v", err)
+	}
+	b, err := EncryptPrivateKey(pk, PrivateKeyFormatCosign, KDFStrengthOWASP, []byte("bye"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPrivateKey(b, []byte("bye"), nil); err != nil {
+		t.Errorf("LoadPrivateKey() of re-encrypted key error = %v", err)
+	}
+}
@@ -276,24 +276,33 @@ func PemToECDSAKey(pemBytes []byte) (*ecdsa.PublicKey, error) {
 }
 
 // LoadPrivateKey loads a cosign PEM private key encrypted with the given passphrase,
-// and returns a SignerVerifier instance. The private key must be in the PKCS #8 format.
+// and returns a SignerVerifier instance. The private key must be in the PKCS #8 format,
+// or be an OpenSSH private key as written by ssh-keygen.
 func LoadPrivateKey(key []byte, pass []byte, defaultLoadOptions *[]signature.LoadOption) (signature.SignerVerifier, error) {
 	// Decrypt first
 	p, _ := pem.Decode(key)
 	if p == nil {
 		return nil, errors.New("invalid pem block")
 	}
-	if p.Type != CosignPrivateKeyPemType && p.Type != SigstorePrivateKeyPemType {
-		return nil, fmt.Errorf("unsupported pem type: %s", p.Type)
-	}
 
-	x509Encoded, err := encrypted.Decrypt(p.Bytes, pass)
-	if err != nil {
-		return nil, fmt.Errorf("decrypt: %w", err)
-	}
-	pk, err := x509.ParsePKCS8PrivateKey(x509Encoded)
-	if err != nil {
-		return nil, fmt.Errorf("parsing private key: %w", err)
+	var pk crypto.PrivateKey
+	switch p.Type {
+	case CosignPrivateKeyPemType, SigstorePrivateKeyPemType:
+		x509Encoded, err := encrypted.Decrypt(p.Bytes, pass)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt: %w", err)
+		}
+		pk, err = x509.ParsePKCS8PrivateKey(x509Encoded)
+		if err != nil {
+			return nil, fmt.Errorf("parsing private key: %w", err)
+		}
+	case OpenSSHPrivateKeyPemType:
+		var err error
+		if pk, err = DecryptPrivateKey(key, pass); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pem type: %s", p.Type)
 	}
 	defaultLoadOptions = GetDefaultLoadOptions(defaultLoadOptions)
 	return signature.LoadDefaultSignerVerifier(pk, *defaultLoadOptions...)
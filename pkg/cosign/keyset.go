@@ -0,0 +1,348 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+)
+
+// KeyTransitionPayloadType is the DSSE payload type of key transition statements.
+const KeyTransitionPayloadType = "application/vnd.dev.sigstore.cosign.key-transition+json"
+
+// KeyTransition records the rotation from one signing key to another. It is
+// published as a DSSE envelope signed by both keys: the previous key vouches
+// for its successor, and the new key proves possession of its private half.
+type KeyTransition struct {
+	// PreviousKey is the PEM encoded public key being retired.
+	PreviousKey string `json:"previousKey"`
+	// NewKey is the PEM encoded public key replacing it.
+	NewKey string `json:"newKey"`
+	// Time is when the new key becomes valid.
+	Time time.Time `json:"time"`
+	// PreviousKeyNotAfter is when the previous key stops being valid. Both
+	// keys are valid between Time and PreviousKeyNotAfter.
+	PreviousKeyNotAfter time.Time `json:"previousKeyNotAfter"`
+}
+
+// SignKeyTransition returns a DSSE envelope of t signed by the previous and
+// the new key.
+func SignKeyTransition(t *KeyTransition, previous, next signature.Signer) ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	for _, s := range []struct {
+		name, pem string
+		signer    signature.Signer
+	}{{"previous", t.PreviousKey, previous}, {"new", t.NewKey, next}} {
+		pub, err := s.signer.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		want, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(s.pem))
+		if err != nil {
+			return nil, err
+		}
+		if err := cryptoutils.EqualKeys(want, pub); err != nil {
+			return nil, fmt.Errorf("%s key does not match the key transition: %w", s.name, err)
+		}
+	}
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return dsse.WrapMultiSigner(KeyTransitionPayloadType, previous, next).SignMessage(bytes.NewReader(payload))
+}
+
+// VerifyKeyTransition verifies that envelope is a key transition signed by
+// both the previous and the new key it names, and returns the transition.
+func VerifyKeyTransition(envelope []byte) (*KeyTransition, error) {
+	var env struct {
+		Payload string `json:"payload"`
+	}
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return nil, fmt.Errorf("parsing key transition envelope: %w", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding key transition payload: %w", err)
+	}
+	t := &KeyTransition{}
+	if err := json.Unmarshal(payload, t); err != nil {
+		return nil, fmt.Errorf("parsing key transition: %w", err)
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	for _, k := range []struct{ name, pem string }{{"previous", t.PreviousKey}, {"new", t.NewKey}} {
+		v, err := loadKeySetVerifier(k.pem)
+		if err != nil {
+			return nil, err
+		}
+		verifier := dsse.WrapMultiVerifierWithOpts(KeyTransitionPayloadType, 1, []signature.Verifier{v},
+			dsse.WithExpectedPayloadType(KeyTransitionPayloadType))
+		if err := verifier.VerifySignature(bytes.NewReader(envelope), nil); err != nil {
+			return nil, fmt.Errorf("key transition is not signed by the %s key: %w", k.name, err)
+		}
+	}
+	return t, nil
+}
+
+func (t *KeyTransition) validate() error {
+	if t.PreviousKey == "" || t.NewKey == "" {
+		return errors.New("key transition must name a previous and a new key")
+	}
+	if t.PreviousKeyNotAfter.Before(t.Time) {
+		return fmt.Errorf("previous key validity ends at %s, before the new key becomes valid at %s",
+			t.PreviousKeyNotAfter.Format(time.RFC3339), t.Time.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// KeySet is a set of trusted public keys, each valid within a window of
+// time. It allows signatures made with a retired key to keep verifying as
+// long as they were logged or timestamped while that key was valid.
+type KeySet struct {
+	Keys []KeySetKey `json:"keys"`
+}
+
+// KeySetKey is a public key of a KeySet. A missing bound leaves the validity
+// window open on that side.
+type KeySetKey struct {
+	// KeyID is the base64 encoded SHA-256 digest of the DER encoded public
+	// key, matching the public key hint of Sigstore bundles.
+	KeyID     string     `json:"keyId"`
+	PublicKey string     `json:"publicKey"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+}
+
+// NewKeySet returns a key set trusting the PEM encoded public key pem for all
+// time.
+func NewKeySet(pem []byte) (*KeySet, error) {
+	k, err := newKeySetKey(string(pem))
+	if err != nil {
+		return nil, err
+	}
+	return &KeySet{Keys: []KeySetKey{k}}, nil
+}
+
+// ParseKeySet parses a JSON encoded key set.
+func ParseKeySet(b []byte) (*KeySet, error) {
+	ks := &KeySet{}
+	if err := json.Unmarshal(b, ks); err != nil {
+		return nil, fmt.Errorf("parsing key set: %w", err)
+	}
+	if len(ks.Keys) == 0 {
+		return nil, errors.New("key set has no keys")
+	}
+	for _, k := range ks.Keys {
+		want, err := keySetKeyID(k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if k.KeyID != want {
+			return nil, fmt.Errorf("key set key ID %q does not match its public key %q", k.KeyID, want)
+		}
+	}
+	return ks, nil
+}
+
+// LoadKeySet reads a JSON encoded key set from path.
+func LoadKeySet(path string) (*KeySet, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeySet(b)
+}
+
+// Apply verifies the key transition envelope and records it in the key set:
+// the previous key, which must already be trusted, is retired at the end of
+// the overlap, and the new key is trusted from the transition time on.
+func (ks *KeySet) Apply(envelope []byte) (*KeyTransition, error) {
+	t, err := VerifyKeyTransition(envelope)
+	if err != nil {
+		return nil, err
+	}
+	previousID, err := keySetKeyID(t.PreviousKey)
+	if err != nil {
+		return nil, err
+	}
+	next, err := newKeySetKey(t.NewKey)
+	if err != nil {
+		return nil, err
+	}
+	previous := ks.key(previousID)
+	if previous == nil {
+		return nil, fmt.Errorf("previous key %s is not in the key set", previousID)
+	}
+	if previous.NotAfter != nil && previous.NotAfter.Before(t.Time) {
+		return nil, fmt.Errorf("previous key %s was retired at %s, before the transition", previousID, previous.NotAfter.Format(time.RFC3339))
+	}
+	if ks.key(next.KeyID) != nil {
+		return nil, fmt.Errorf("new key %s is already in the key set", next.KeyID)
+	}
+	if previous.NotAfter == nil || t.PreviousKeyNotAfter.Before(*previous.NotAfter) {
+		notAfter := t.PreviousKeyNotAfter.UTC()
+		previous.NotAfter = &notAfter
+	}
+	notBefore := t.Time.UTC()
+	next.NotBefore = &notBefore
+	ks.Keys = append(ks.Keys, next)
+	return t, nil
+}
+
+func (ks *KeySet) key(id string) *KeySetKey {
+	for i := range ks.Keys {
+		if ks.Keys[i].KeyID == id {
+			return &ks.Keys[i]
+		}
+	}
+	return nil
+}
+
+// ValidAt reports whether t falls inside the key's validity window.
+func (k *KeySetKey) ValidAt(t time.Time) bool {
+	if k.NotBefore != nil && t.Before(*k.NotBefore) {
+		return false
+	}
+	if k.NotAfter != nil && t.After(*k.NotAfter) {
+		return false
+	}
+	return true
+}
+
+func (k *KeySetKey) window() string {
+	bound := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("[%s, %s]", bound(k.NotBefore), bound(k.NotAfter))
+}
+
+// verify verifies sig with the key of the set that made it, and checks that
+// each of the times the signature was observed at falls inside that key's
+// validity window.
+func (ks *KeySet) verify(ctx context.Context, sig oci.Signature, verifyFn signatureVerificationFn, times []time.Time) (signature.Verifier, error) {
+	if len(times) == 0 {
+		return nil, &VerificationFailure{
+			errors.New("a transparency log entry or signed timestamp is required to verify with a key set"),
+		}
+	}
+	for i := range ks.Keys {
+		k := &ks.Keys[i]
+		verifier, err := loadKeySetVerifier(k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyFn(ctx, verifier, sig); err != nil {
+			continue
+		}
+		for _, t := range times {
+			if !k.ValidAt(t) {
+				return nil, &VerificationFailure{
+					fmt.Errorf("signature time %s is outside the validity window %s of key %s", t.UTC().Format(time.RFC3339), k.window(), k.KeyID),
+				}
+			}
+		}
+		return verifier, nil
+	}
+	return nil, &VerificationFailure{errors.New("no key in the key set verified the signature")}
+}
+
+// publicKeysPEM returns the PEM encoded public keys of the set.
+func (ks *KeySet) publicKeysPEM() [][]byte {
+	keys := make([][]byte, 0, len(ks.Keys))
+	for _, k := range ks.Keys {
+		keys = append(keys, []byte(k.PublicKey))
+	}
+	return keys
+}
+
+// trustedMaterial returns the key set as sigstore-go trusted material, with
+// keys looked up by the public key hint of a bundle.
+func (ks *KeySet) trustedMaterial() (*root.TrustedPublicKeyMaterial, error) {
+	keys := make(map[string]*root.ExpiringKey, len(ks.Keys))
+	for _, k := range ks.Keys {
+		verifier, err := loadKeySetVerifier(k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		var notBefore, notAfter time.Time
+		if k.NotBefore != nil {
+			notBefore = *k.NotBefore
+		}
+		if k.NotAfter != nil {
+			notAfter = *k.NotAfter
+		}
+		keys[k.KeyID] = root.NewExpiringKey(verifier, notBefore, notAfter)
+	}
+	return root.NewTrustedPublicKeyMaterialFromMapping(keys), nil
+}
+
+func newKeySetKey(pem string) (KeySetKey, error) {
+	id, err := keySetKeyID(pem)
+	if err != nil {
+		return KeySetKey{}, err
+	}
+	return KeySetKey{KeyID: id, PublicKey: pem}, nil
+}
+
+func keySetKeyID(pem string) (string, error) {
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(pem))
+	if err != nil {
+		return "", fmt.Errorf("parsing public key: %w", err)
+	}
+	return PublicKeyID(pub)
+}
+
+// PublicKeyID returns the base64 encoded SHA-256 digest of the DER encoded
+// public key, as used for the public key hint of Sigstore bundles.
+func PublicKeyID(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(der)
+	return base64.StdEncoding.EncodeToString(digest[:]), nil
+}
+
+func loadKeySetVerifier(pem string) (signature.Verifier, error) {
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(pem))
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	return signature.LoadDefaultVerifier(pub, *GetDefaultLoadOptions(nil)...)
+}
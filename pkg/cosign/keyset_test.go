@@ -0,0 +1,200 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+)
+
+func keySetSigner(t *testing.T) (signature.SignerVerifier, string) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sv, err := signature.LoadECDSASignerVerifier(priv, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pem, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sv, string(pem)
+}
+
+func TestKeySetApply(t *testing.T) {
+	previous, previousPEM := keySetSigner(t)
+	next, nextPEM := keySetSigner(t)
+	rotated := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	transition := &KeyTransition{
+		PreviousKey:         previousPEM,
+		NewKey:              nextPEM,
+		Time:                rotated,
+		PreviousKeyNotAfter: rotated.Add(30 * 24 * time.Hour),
+	}
+
+	if _, err := SignKeyTransition(transition, next, previous); err == nil {
+		t.Error("expected error signing a transition with swapped keys")
+	}
+	envelope, err := SignKeyTransition(transition, previous, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks, err := NewKeySet([]byte(previousPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Apply(envelope); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if _, err := ks.Apply(envelope); err == nil {
+		t.Error("expected error applying a transition twice")
+	}
+	b, err := json.Marshal(ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err = ParseKeySet(b)
+	if err != nil {
+		t.Fatalf("ParseKeySet() error = %v", err)
+	}
+	if len(ks.Keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(ks.Keys))
+	}
+	for _, tt := range []struct {
+		name      string
+		key       int
+		at        time.Time
+		wantValid bool
+	}{
+		{"previous before rotation", 0, rotated.Add(-time.Hour), true},
+		{"previous during overlap", 0, rotated.Add(time.Hour), true},
+		{"previous after retirement", 0, rotated.Add(31 * 24 * time.Hour), false},
+		{"new before rotation", 1, rotated.Add(-time.Hour), false},
+		{"new after rotation", 1, rotated.Add(31 * 24 * time.Hour), true},
+	} {
+		if got := ks.Keys[tt.key].ValidAt(tt.at); got != tt.wantValid {
+			t.Errorf("%s: ValidAt() = %t, want %t", tt.name, got, tt.wantValid)
+		}
+	}
+
+	// A transition must also be signed by the new key.
+	payload, err := json.Marshal(transition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := dsse.WrapSigner(previous, KeyTransitionPayloadType).SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyKeyTransition(unsigned); err == nil {
+		t.Error("expected error verifying a transition signed only by the previous key")
+	}
+
+	// A transition from an untrusted key is rejected.
+	_, otherPEM := keySetSigner(t)
+	ks, err = NewKeySet([]byte(otherPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Apply(envelope); err == nil {
+		t.Error("expected error applying a transition from an untrusted key")
+	}
+
+	b, err = json.Marshal(KeySet{Keys: []KeySetKey{{KeyID: "bogus", PublicKey: previousPEM}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseKeySet(b); err == nil {
+		t.Error("expected error parsing a key set with a mismatched key ID")
+	}
+}
+
+func TestKeySetVerify(t *testing.T) {
+	ctx := context.Background()
+	previous, previousPEM := keySetSigner(t)
+	next, nextPEM := keySetSigner(t)
+	rotated := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	retired := rotated.Add(24 * time.Hour)
+	ks := &KeySet{Keys: []KeySetKey{
+		{PublicKey: previousPEM, NotAfter: &retired},
+		{PublicKey: nextPEM, NotBefore: &rotated},
+	}}
+	for i := range ks.Keys {
+		id, err := keySetKeyID(ks.Keys[i].PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ks.Keys[i].KeyID = id
+	}
+
+	sign := func(sv signature.SignerVerifier) oci.Signature {
+		payload := []byte(`{"critical":{}}`)
+		sig, err := sv.SignMessage(bytes.NewReader(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := static.NewSignature(payload, base64.StdEncoding.EncodeToString(sig))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	stranger, _ := keySetSigner(t)
+
+	for _, tt := range []struct {
+		name    string
+		signer  signature.SignerVerifier
+		times   []time.Time
+		wantErr bool
+	}{
+		{"previous key before retirement", previous, []time.Time{rotated.Add(time.Hour)}, false},
+		{"previous key after retirement", previous, []time.Time{retired.Add(time.Hour)}, true},
+		{"previous key with one late time", previous, []time.Time{rotated, retired.Add(time.Hour)}, true},
+		{"new key after rotation", next, []time.Time{retired.Add(time.Hour)}, false},
+		{"new key before rotation", next, []time.Time{rotated.Add(-time.Hour)}, true},
+		{"no signature time", next, nil, true},
+		{"unknown key", stranger, []time.Time{rotated}, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ks.verify(ctx, sign(tt.signer), verifyOCISignature, tt.times)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verify() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			var vf *VerificationFailure
+			if err != nil && !errors.As(err, &vf) {
+				t.Errorf("verify() error = %v, want a VerificationFailure", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/pkg/blob"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets/gcpsm"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"sigs.k8s.io/yaml"
+)
+
+type objectMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// sealedSecretManifest is a bitnami.com/v1alpha1 SealedSecret.
+type sealedSecretManifest struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Metadata   objectMeta `json:"metadata"`
+	Spec       struct {
+		EncryptedData map[string]string `json:"encryptedData"`
+		Template      struct {
+			Metadata  objectMeta `json:"metadata"`
+			Immutable bool       `json:"immutable"`
+		} `json:"template"`
+	} `json:"spec"`
+}
+
+// sealedSecret returns a strict scoped SealedSecret manifest of the key pair,
+// sealed with the sealed-secrets controller certificate at certRef.
+func sealedSecret(keys *cosign.KeysBytes, ref *Reference, certRef string) ([]byte, error) {
+	certPEM, err := blob.LoadFileOrURL(certRef)
+	if err != nil {
+		return nil, fmt.Errorf("loading sealed-secrets certificate: %w", err)
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(certPEM)
+	if err != nil || len(certs) == 0 {
+		return nil, fmt.Errorf("parsing sealed-secrets certificate %s: %w", certRef, err)
+	}
+	pub, ok := certs[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("sealed-secrets certificate must hold an RSA public key")
+	}
+
+	meta := objectMeta{Name: ref.Name, Namespace: ref.Namespace}
+	m := sealedSecretManifest{APIVersion: "bitnami.com/v1alpha1", Kind: "SealedSecret", Metadata: meta}
+	m.Spec.Template.Metadata = meta
+	m.Spec.Template.Immutable = true
+	m.Spec.EncryptedData = map[string]string{}
+	// Strict scope binds the sealed values to the namespace and name.
+	label := []byte(ref.Namespace + "/" + ref.Name)
+	for k, v := range secret(keys, ref, nil, true).Data {
+		sealed, err := hybridEncrypt(pub, v, label)
+		if err != nil {
+			return nil, fmt.Errorf("sealing %s: %w", k, err)
+		}
+		m.Spec.EncryptedData[k] = base64.StdEncoding.EncodeToString(sealed)
+	}
+	return yaml.Marshal(m)
+}
+
+// hybridEncrypt encrypts plaintext the way the sealed-secrets controller
+// expects: a random AES-256-GCM session key encrypted with RSA-OAEP, its
+// two byte big endian length, then the AES-GCM ciphertext. The session key
+// is used once, so the nonce is zero.
+func hybridEncrypt(pub *rsa.PublicKey, plaintext, label []byte) ([]byte, error) {
+	sessionKey := make([]byte, 32)
+	if _, err := rand.Read(sessionKey); err != nil {
+		return nil, err
+	}
+	rsaCiphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, sessionKey, label)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	out := binary.BigEndian.AppendUint16(nil, uint16(len(rsaCiphertext)))
+	out = append(out, rsaCiphertext...)
+	return aead.Seal(out, make([]byte, aead.NonceSize()), plaintext, nil), nil
+}
+
+type externalSecretData struct {
+	SecretKey string `json:"secretKey"`
+	RemoteRef struct {
+		Key      string `json:"key"`
+		Property string `json:"property"`
+	} `json:"remoteRef"`
+}
+
+// externalSecretManifest is an external-secrets.io/v1 ExternalSecret.
+type externalSecretManifest struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Metadata   objectMeta `json:"metadata"`
+	Spec       struct {
+		RefreshInterval string `json:"refreshInterval"`
+		SecretStoreRef  struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"secretStoreRef"`
+		Target struct {
+			Name string `json:"name"`
+		} `json:"target"`
+		Data []externalSecretData `json:"data"`
+	} `json:"spec"`
+}
+
+// externalSecret generates a key pair into the secret manager at smRef and
+// returns an ExternalSecret manifest that syncs it into the referenced
+// Secret through the store.
+func externalSecret(ctx context.Context, ref *Reference, store, smRef string, pf cosign.PassFunc) ([]byte, error) {
+	if !secrets.IsReference(smRef) {
+		return nil, fmt.Errorf("external secret reference %q must be a vault://, awssm:// or gcpsm:// reference", smRef)
+	}
+	kind, name := "SecretStore", store
+	if k, n, ok := strings.Cut(store, "/"); ok {
+		kind, name = k, n
+	}
+	if !slices.Contains([]string{"SecretStore", "ClusterSecretStore"}, kind) || name == "" {
+		return nil, fmt.Errorf("external secret store %q must be [SecretStore/|ClusterSecretStore/]<name>", store)
+	}
+
+	scheme, target, _ := strings.Cut(smRef, "://")
+	if err := secrets.GetProvider(scheme).PutSecret(ctx, target, pf); err != nil {
+		return nil, err
+	}
+	remoteKey := target
+	if scheme == gcpsm.ReferenceScheme {
+		// GCP stores are bound to a project and refer to secrets by ID.
+		remoteKey = target[strings.LastIndex(target, "/")+1:]
+	}
+
+	m := externalSecretManifest{
+		APIVersion: "external-secrets.io/v1",
+		Kind:       "ExternalSecret",
+		Metadata:   objectMeta{Name: ref.Name, Namespace: ref.Namespace},
+	}
+	m.Spec.RefreshInterval = "1h"
+	m.Spec.SecretStoreRef.Kind = kind
+	m.Spec.SecretStoreRef.Name = name
+	m.Spec.Target.Name = ref.Name
+	for _, d := range [][2]string{
+		{ref.PrivateKeyDataKey, secrets.PrivateKey},
+		{ref.PasswordDataKey, secrets.Password},
+		{ref.PublicKeyDataKey, secrets.PublicKey},
+	} {
+		data := externalSecretData{SecretKey: d[0]}
+		data.RemoteRef.Key = remoteKey
+		data.RemoteRef.Property = d[1]
+		m.Spec.Data = append(m.Spec.Data, data)
+	}
+	return yaml.Marshal(m)
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var passFunc = func(bool) ([]byte, error) { return []byte("hunter2"), nil }
+
+func TestKeyPairSecretDryRun(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var out bytes.Buffer
+	err := KeyPairSecretWithOptions(context.Background(), "k8s://ci/signing?key=signing.key", passFunc, KeyPairOptions{DryRun: true, Out: &out})
+	if err != nil {
+		t.Fatalf("KeyPairSecretWithOptions() error = %v", err)
+	}
+	var s v1.Secret
+	if err := yaml.UnmarshalStrict(out.Bytes(), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Kind != "Secret" || s.Namespace != "ci" || s.Name != "signing" || string(s.Data["cosign.password"]) != "hunter2" {
+		t.Errorf("unexpected secret %+v", s)
+	}
+	if _, err := cosign.LoadPrivateKey(s.Data["signing.key"], s.Data["cosign.password"], nil); err != nil {
+		t.Errorf("LoadPrivateKey() error = %v", err)
+	}
+	if pub, err := os.ReadFile("cosign.pub"); err != nil || !bytes.Equal(pub, s.Data["cosign.pub"]) {
+		t.Errorf("cosign.pub does not match the secret: %v", err)
+	}
+}
+
+func TestKeyPairSecretSealed(t *testing.T) {
+	td := t.TempDir()
+	t.Chdir(td)
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sealed-secret"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPath := filepath.Join(td, "sealed-secrets.pem")
+	if err := os.WriteFile(certPath, cryptoutils.PEMEncode(cryptoutils.CertificatePEMType, der), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err = KeyPairSecretWithOptions(context.Background(), "k8s://ci/signing", passFunc, KeyPairOptions{SealedSecretsCert: certPath, Out: &out})
+	if err != nil {
+		t.Fatalf("KeyPairSecretWithOptions() error = %v", err)
+	}
+	var m sealedSecretManifest
+	if err := yaml.UnmarshalStrict(out.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Kind != "SealedSecret" || m.Spec.Template.Metadata.Name != "signing" || len(m.Spec.EncryptedData) != 3 {
+		t.Fatalf("unexpected sealed secret %+v", m)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(m.Spec.EncryptedData["cosign.password"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := hybridDecrypt(priv, sealed, []byte("ci/signing")); err != nil || string(got) != "hunter2" {
+		t.Errorf("unsealed password = %q, %v", got, err)
+	}
+	if _, err := hybridDecrypt(priv, sealed, []byte("default/signing")); err == nil {
+		t.Error("expected error unsealing in another namespace")
+	}
+}
+
+// hybridDecrypt mirrors how the sealed-secrets controller unseals values.
+func hybridDecrypt(priv *rsa.PrivateKey, ciphertext, label []byte) ([]byte, error) {
+	n := binary.BigEndian.Uint16(ciphertext)
+	sessionKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, priv, ciphertext[2:2+n], label)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[2+n:], nil)
+}
+
+func TestKeyPairSecretOptions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	for _, tt := range []struct {
+		desc string
+		ref  string
+		opts KeyPairOptions
+	}{
+		{"configmap", "k8s://ci/keys?kind=configmap", KeyPairOptions{DryRun: true}},
+		{"two modes", "k8s://ci/signing", KeyPairOptions{DryRun: true, SealedSecretsCert: "cert.pem"}},
+		{"store without reference", "k8s://ci/signing", KeyPairOptions{ExternalSecretStore: "vault"}},
+		{"reference without store", "k8s://ci/signing", KeyPairOptions{ExternalSecretRef: "vault://secret/cosign"}},
+		{"unsupported reference", "k8s://ci/signing", KeyPairOptions{ExternalSecretStore: "vault", ExternalSecretRef: "gitlab://group"}},
+		{"unsupported store kind", "k8s://ci/signing", KeyPairOptions{ExternalSecretStore: "Vault/vault", ExternalSecretRef: "vault://secret/cosign"}},
+		{"missing certificate", "k8s://ci/signing", KeyPairOptions{SealedSecretsCert: "missing.pem"}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := KeyPairSecretWithOptions(ctx, tt.ref, passFunc, tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestKeyPairSecretExternal(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var written string
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		written = r.URL.Path
+		w.Write([]byte(`{"data":{"version":1,"created_time":"2026-03-01T00:00:00Z"}}`))
+	}))
+	defer vault.Close()
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "test-token")
+
+	var out bytes.Buffer
+	err := KeyPairSecretWithOptions(context.Background(), "k8s://ci/signing?pub=release.pub", passFunc, KeyPairOptions{
+		ExternalSecretStore: "ClusterSecretStore/vault",
+		ExternalSecretRef:   "vault://secret/cosign/team-a",
+		Out:                 &out,
+	})
+	if err != nil {
+		t.Fatalf("KeyPairSecretWithOptions() error = %v", err)
+	}
+	if written != "/v1/secret/data/cosign/team-a" {
+		t.Errorf("key pair written to %s", written)
+	}
+	var m externalSecretManifest
+	if err := yaml.UnmarshalStrict(out.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Spec.SecretStoreRef.Kind != "ClusterSecretStore" || m.Spec.SecretStoreRef.Name != "vault" || m.Spec.Target.Name != "signing" {
+		t.Errorf("unexpected external secret %+v", m)
+	}
+	if len(m.Spec.Data) != 3 || m.Spec.Data[2].SecretKey != "release.pub" ||
+		m.Spec.Data[2].RemoteRef.Key != "secret/cosign/team-a" || m.Spec.Data[2].RemoteRef.Property != "COSIGN_PUBLIC_KEY" {
+		t.Errorf("unexpected external secret data %+v", m.Spec.Data)
+	}
+}
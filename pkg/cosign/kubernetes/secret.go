@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"strings"
 
@@ -26,14 +28,122 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	KeyReference = "k8s://"
+
+	defaultPrivateKeyDataKey = "cosign.key"
+	defaultPasswordDataKey   = "cosign.password"
+	defaultPublicKeyDataKey  = "cosign.pub"
 )
 
+// Reference is a parsed k8s://<namespace>/<name> key reference. Query
+// parameters name the data keys holding the key pair, e.g.
+// k8s://ns/name?key=signing.key&password=signing.password&pub=signing.pub,
+// and kind=configmap reads a public key from a ConfigMap instead of a
+// Secret.
+type Reference struct {
+	Namespace string
+	Name      string
+	ConfigMap bool
+
+	PrivateKeyDataKey string
+	PasswordDataKey   string
+	PublicKeyDataKey  string
+}
+
+// ParseReference parses a k8s:// key reference.
+func ParseReference(k8sRef string) (*Reference, error) {
+	path, query, _ := strings.Cut(strings.TrimPrefix(k8sRef, KeyReference), "?")
+	s := strings.Split(path, "/")
+	if len(s) != 2 {
+		return nil, errors.New("kubernetes specification should be in the format k8s://<namespace>/<secret>")
+	}
+	ref := &Reference{
+		Namespace:         s[0],
+		Name:              s[1],
+		PrivateKeyDataKey: defaultPrivateKeyDataKey,
+		PasswordDataKey:   defaultPasswordDataKey,
+		PublicKeyDataKey:  defaultPublicKeyDataKey,
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("parsing query of %s: %w", k8sRef, err)
+	}
+	for param, values := range params {
+		if len(values) != 1 || values[0] == "" {
+			return nil, fmt.Errorf("query parameter %q of %s must have a single value", param, k8sRef)
+		}
+		switch v := values[0]; param {
+		case "key":
+			ref.PrivateKeyDataKey = v
+		case "password":
+			ref.PasswordDataKey = v
+		case "pub":
+			ref.PublicKeyDataKey = v
+		case "kind":
+			switch strings.ToLower(v) {
+			case "secret":
+			case "configmap":
+				ref.ConfigMap = true
+			default:
+				return nil, fmt.Errorf("unsupported kind %q, use secret or configmap", v)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported query parameter %q, use key, password, pub or kind", param)
+		}
+	}
+	return ref, nil
+}
+
+// KeyPair holds the key material read from a k8s:// reference. Fields the
+// referenced object does not hold are empty; a ConfigMap only holds a
+// public key.
+type KeyPair struct {
+	PrivateKey []byte
+	Password   []byte
+	PublicKey  []byte
+}
+
+// GetKeyPair reads the key pair, or the public key of a ConfigMap, that
+// k8sRef refers to.
+func GetKeyPair(ctx context.Context, k8sRef string) (*KeyPair, error) {
+	ref, err := ParseReference(k8sRef)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := client()
+	if err != nil {
+		return nil, fmt.Errorf("new for config: %w", err)
+	}
+
+	if ref.ConfigMap {
+		cm, err := client.CoreV1().ConfigMaps(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("getting configmap: %w", err)
+		}
+		if pub, ok := cm.Data[ref.PublicKeyDataKey]; ok {
+			return &KeyPair{PublicKey: []byte(pub)}, nil
+		}
+		return &KeyPair{PublicKey: cm.BinaryData[ref.PublicKeyDataKey]}, nil
+	}
+
+	s, err := client.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("checking if secret exists: %w", err)
+	}
+	return &KeyPair{
+		PrivateKey: s.Data[ref.PrivateKeyDataKey],
+		Password:   s.Data[ref.PasswordDataKey],
+		PublicKey:  s.Data[ref.PublicKeyDataKey],
+	}, nil
+}
+
 func GetKeyPairSecret(ctx context.Context, k8sRef string) (*v1.Secret, error) {
-	namespace, name, err := parseRef(k8sRef)
+	ref, err := ParseReference(k8sRef)
 	if err != nil {
 		return nil, err
 	}
@@ -44,24 +154,109 @@ func GetKeyPairSecret(ctx context.Context, k8sRef string) (*v1.Secret, error) {
 	}
 
 	var s *v1.Secret
-	if s, err = client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+	if s, err = client.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err != nil {
 		return nil, fmt.Errorf("checking if secret exists: %w", err)
 	}
 
 	return s, nil
 }
 
+// KeyPairOptions selects how KeyPairSecretWithOptions stores a generated key pair. By
+// default it creates or updates the Secret in the cluster.
+type KeyPairOptions struct {
+	// DryRun prints the Secret manifest instead of creating the Secret.
+	DryRun bool
+	// SealedSecretsCert is the path or URL of a sealed-secrets controller
+	// certificate. When set, a SealedSecret manifest is printed instead.
+	SealedSecretsCert string
+	// ExternalSecretStore is the [<kind>/]<name> of an External Secrets
+	// Operator SecretStore or ClusterSecretStore. When set, the key pair is
+	// written to the secret manager at ExternalSecretRef and an
+	// ExternalSecret manifest syncing it is printed instead.
+	ExternalSecretStore string
+	ExternalSecretRef   string
+	// Out receives printed manifests. It defaults to os.Stdout.
+	Out io.Writer
+}
+
 func KeyPairSecret(ctx context.Context, k8sRef string, pf cosign.PassFunc) error {
-	namespace, name, err := parseRef(k8sRef)
+	return KeyPairSecretWithOptions(ctx, k8sRef, pf, KeyPairOptions{})
+}
+
+// KeyPairSecretWithOptions generates a key pair for k8sRef and stores it as
+// selected by opts.
+func KeyPairSecretWithOptions(ctx context.Context, k8sRef string, pf cosign.PassFunc, opts KeyPairOptions) error {
+	ref, err := ParseReference(k8sRef)
 	if err != nil {
 		return err
 	}
+	if ref.ConfigMap {
+		return errors.New("a ConfigMap can only hold a public key, store generated key pairs in a Secret")
+	}
+	var modes int
+	for _, set := range []bool{opts.DryRun, opts.SealedSecretsCert != "", opts.ExternalSecretStore != ""} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return errors.New("only one of dry run, a sealed-secrets certificate or an external secret store may be used")
+	}
+	if (opts.ExternalSecretStore == "") != (opts.ExternalSecretRef == "") {
+		return errors.New("an external secret store and an external secret reference must be used together")
+	}
+	out := opts.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	if opts.ExternalSecretStore != "" {
+		// The secret manager generates the key pair and writes cosign.pub.
+		manifest, err := externalSecret(ctx, ref, opts.ExternalSecretStore, opts.ExternalSecretRef, pf)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(manifest)
+		return err
+	}
+
 	// now, generate the key in memory
 	keys, err := cosign.GenerateKeyPair(pf)
 	if err != nil {
 		return fmt.Errorf("generating key pair: %w", err)
 	}
 
+	var manifest []byte
+	switch {
+	case opts.SealedSecretsCert != "":
+		if manifest, err = sealedSecret(keys, ref, opts.SealedSecretsCert); err != nil {
+			return err
+		}
+	case opts.DryRun:
+		s := secret(keys, ref, nil, true)
+		s.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+		if manifest, err = yaml.Marshal(s); err != nil {
+			return err
+		}
+	default:
+		if err := applySecret(ctx, keys, ref); err != nil {
+			return err
+		}
+	}
+	if manifest != nil {
+		if _, err := out.Write(manifest); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile("cosign.pub", keys.PublicBytes, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Public key written to cosign.pub")
+	return nil
+}
+
+func applySecret(ctx context.Context, keys *cosign.KeysBytes, ref *Reference) error {
 	// create the k8s client
 	client, err := client()
 	if err != nil {
@@ -71,10 +266,11 @@ func KeyPairSecret(ctx context.Context, k8sRef string, pf cosign.PassFunc) error
 	if err != nil {
 		return fmt.Errorf("check immutable: %w", err)
 	}
+	namespace, name := ref.Namespace, ref.Name
 	var s *v1.Secret
 	if s, err = client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
 		if k8serrors.IsNotFound(err) {
-			s, err = client.CoreV1().Secrets(namespace).Create(ctx, secret(keys, namespace, name, nil, immutable), metav1.CreateOptions{})
+			s, err = client.CoreV1().Secrets(namespace).Create(ctx, secret(keys, ref, nil, immutable), metav1.CreateOptions{})
 			if err != nil {
 				return fmt.Errorf("creating secret %s in ns %s: %w", name, namespace, err)
 			}
@@ -82,35 +278,30 @@ func KeyPairSecret(ctx context.Context, k8sRef string, pf cosign.PassFunc) error
 			return fmt.Errorf("checking if secret exists: %w", err)
 		}
 	} else { // Update the existing secret
-		s, err = client.CoreV1().Secrets(namespace).Update(ctx, secret(keys, namespace, name, s.Data, immutable), metav1.UpdateOptions{})
+		s, err = client.CoreV1().Secrets(namespace).Update(ctx, secret(keys, ref, s.Data, immutable), metav1.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("updating secret %s in ns %s: %w", name, namespace, err)
 		}
 	}
 
 	fmt.Fprintf(os.Stderr, "Successfully created secret %s in namespace %s\n", s.Name, s.Namespace)
-	if err := os.WriteFile("cosign.pub", keys.PublicBytes, 0600); err != nil {
-		return err
-	}
-	fmt.Fprintln(os.Stderr, "Public key written to cosign.pub")
 	return nil
 }
 
-// creates a secret with the following data:
-// * cosign.key
-// * cosign.pub
-// * cosign.password
-func secret(keys *cosign.KeysBytes, namespace, name string, data map[string][]byte, immutable bool) *v1.Secret {
+// creates a secret with the private key, public key and password under the
+// data keys of the reference, cosign.key, cosign.pub and cosign.password by
+// default.
+func secret(keys *cosign.KeysBytes, ref *Reference, data map[string][]byte, immutable bool) *v1.Secret {
 	if data == nil {
 		data = map[string][]byte{}
 	}
-	data["cosign.key"] = keys.PrivateBytes
-	data["cosign.pub"] = keys.PublicBytes
-	data["cosign.password"] = keys.Password()
+	data[ref.PrivateKeyDataKey] = keys.PrivateBytes
+	data[ref.PublicKeyDataKey] = keys.PublicBytes
+	data[ref.PasswordDataKey] = keys.Password()
 
 	obj := metav1.ObjectMeta{
-		Name:      name,
-		Namespace: namespace,
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
 	}
 
 	// For Kubernetes >= 1.21, set Immutable by default
@@ -127,12 +318,3 @@ func secret(keys *cosign.KeysBytes, namespace, name string, data map[string][]by
 		Data:       data,
 	}
 }
-
-// the reference should be formatted as <namespace>/<secret name>
-func parseRef(k8sRef string) (string, string, error) {
-	s := strings.Split(strings.TrimPrefix(k8sRef, KeyReference), "/")
-	if len(s) != 2 {
-		return "", "", errors.New("kubernetes specification should be in the format k8s://<namespace>/<secret>")
-	}
-	return s[0], s[1], nil
-}
@@ -29,8 +29,13 @@ func TestSecret(t *testing.T) {
 		PrivateBytes: []byte("private"),
 		PublicBytes:  []byte("public"),
 	}
-	name := "secret"
-	namespace := "default"
+	ref := &Reference{
+		Namespace:         "default",
+		Name:              "secret",
+		PrivateKeyDataKey: "cosign.key",
+		PasswordDataKey:   "cosign.password",
+		PublicKeyDataKey:  "cosign.pub",
+	}
 	expect := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "secret",
@@ -43,7 +48,7 @@ func TestSecret(t *testing.T) {
 		},
 		Immutable: ptr.To[bool](true),
 	}
-	actual := secret(keys, namespace, name, nil, true)
+	actual := secret(keys, ref, nil, true)
 	if !reflect.DeepEqual(actual, expect) {
 		t.Errorf("secret: %v, want %v", expect, actual)
 	}
@@ -54,8 +59,10 @@ func TestSecretUpdate(t *testing.T) {
 		PrivateBytes: []byte("private"),
 		PublicBytes:  []byte("public"),
 	}
-	name := "secret"
-	namespace := "default"
+	ref, err := ParseReference("k8s://default/secret")
+	if err != nil {
+		t.Fatal(err)
+	}
 	existing := map[string][]byte{
 		"foobar":     []byte("hi"),
 		"cosign.key": []byte("myoldkey"),
@@ -74,7 +81,7 @@ func TestSecretUpdate(t *testing.T) {
 			"cosign.password": nil,
 		},
 	}
-	actual := secret(keys, namespace, name, existing, false)
+	actual := secret(keys, ref, existing, false)
 	if !reflect.DeepEqual(actual, expect) {
 		t.Errorf("secret: %v, want %v", expect, actual)
 	}
@@ -84,15 +91,40 @@ func TestParseRef(t *testing.T) {
 	tests := []struct {
 		desc      string
 		ref       string
-		name      string
-		namespace string
+		want      *Reference
 		shouldErr bool
 	}{
 		{
-			desc:      "valid",
-			ref:       "k8s://default/cosign-secret",
-			name:      "cosign-secret",
-			namespace: "default",
+			desc: "valid",
+			ref:  "k8s://default/cosign-secret",
+			want: &Reference{
+				Namespace:         "default",
+				Name:              "cosign-secret",
+				PrivateKeyDataKey: "cosign.key",
+				PasswordDataKey:   "cosign.password",
+				PublicKeyDataKey:  "cosign.pub",
+			},
+		}, {
+			desc: "custom data keys",
+			ref:  "k8s://ci/signing?key=signing.key&password=signing.password&pub=signing.pub",
+			want: &Reference{
+				Namespace:         "ci",
+				Name:              "signing",
+				PrivateKeyDataKey: "signing.key",
+				PasswordDataKey:   "signing.password",
+				PublicKeyDataKey:  "signing.pub",
+			},
+		}, {
+			desc: "configmap",
+			ref:  "k8s://ci/keys?kind=ConfigMap&pub=release.pub",
+			want: &Reference{
+				Namespace:         "ci",
+				Name:              "keys",
+				ConfigMap:         true,
+				PrivateKeyDataKey: "cosign.key",
+				PasswordDataKey:   "cosign.password",
+				PublicKeyDataKey:  "release.pub",
+			},
 		}, {
 			desc:      "invalid, 1 field",
 			ref:       "k8s://something",
@@ -101,22 +133,31 @@ func TestParseRef(t *testing.T) {
 			desc:      "invalid, more than 2 fields",
 			ref:       "k8s://yet/another/arg",
 			shouldErr: true,
+		}, {
+			desc:      "invalid, unknown query parameter",
+			ref:       "k8s://default/cosign-secret?name=other",
+			shouldErr: true,
+		}, {
+			desc:      "invalid, empty data key",
+			ref:       "k8s://default/cosign-secret?key=",
+			shouldErr: true,
+		}, {
+			desc:      "invalid, unknown kind",
+			ref:       "k8s://default/cosign-secret?kind=pod",
+			shouldErr: true,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			ns, name, err := parseRef(test.ref)
+			ref, err := ParseReference(test.ref)
 			if (err == nil) == test.shouldErr {
 				t.Fatal("unexpected error")
 			}
 			if test.shouldErr {
 				return
 			}
-			if name != test.name {
-				t.Fatalf("unexpected name: got %v expected %v", name, test.name)
-			}
-			if ns != test.namespace {
-				t.Fatalf("unexpected name: got %v expected %v", ns, test.namespace)
+			if !reflect.DeepEqual(ref, test.want) {
+				t.Fatalf("unexpected reference: got %+v expected %+v", ref, test.want)
 			}
 		})
 	}
@@ -0,0 +1,151 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+// MultiKeyVerifier verifies signatures made by any one of several public
+// keys, such as the keys of an authorized_keys file or the keys a GitHub user
+// publishes at https://github.com/<user>.keys.
+//
+// When used as CheckOpts.SigVerifier, verification first narrows it down to
+// the key that made the signature, so that transparency log lookups,
+// revocation and hardware attestation checks see that key alone.
+type MultiKeyVerifier struct {
+	verifiers []signature.Verifier
+}
+
+var _ signature.Verifier = (*MultiKeyVerifier)(nil)
+
+// NewMultiKeyVerifier returns a verifier accepting signatures made by any of
+// the given verifiers.
+func NewMultiKeyVerifier(verifiers ...signature.Verifier) (*MultiKeyVerifier, error) {
+	if len(verifiers) == 0 {
+		return nil, errors.New("no public keys")
+	}
+	return &MultiKeyVerifier{verifiers: verifiers}, nil
+}
+
+// Verifiers returns the verifier of each key.
+func (m *MultiKeyVerifier) Verifiers() []signature.Verifier {
+	return m.verifiers
+}
+
+// PublicKey returns the public key of the first key.
+func (m *MultiKeyVerifier) PublicKey(opts ...signature.PublicKeyOption) (crypto.PublicKey, error) {
+	return m.verifiers[0].PublicKey(opts...)
+}
+
+// VerifySignature verifies the signature of the digest passed with
+// options.WithDigest with each key in turn, and succeeds if any of them does.
+// The message is not read, so that it can be streamed to the matching key
+// afterwards. Pure Ed25519 keys sign the message itself and never match a
+// digest.
+func (m *MultiKeyVerifier) VerifySignature(sig, _ io.Reader, opts ...signature.VerifyOption) error {
+	var digest []byte
+	for _, opt := range opts {
+		opt.ApplyDigest(&digest)
+	}
+	if len(digest) == 0 {
+		return errors.New("verifying with several keys requires the digest of the message")
+	}
+	sigBytes, err := io.ReadAll(sig)
+	if err != nil {
+		return err
+	}
+	for _, v := range m.verifiers {
+		if err = v.VerifySignature(bytes.NewReader(sigBytes), nil, opts...); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// verifierFor returns the verifier of the key that made sig.
+func (m *MultiKeyVerifier) verifierFor(ctx context.Context, sig payloader, verifyFn signatureVerificationFn) (signature.Verifier, error) {
+	var err error
+	for _, v := range m.verifiers {
+		if err = verifyFn(ctx, v, sig); err == nil {
+			return v, nil
+		}
+	}
+	return nil, err
+}
+
+// verifierForBundle returns the verifier of the key that made the signature
+// of bundle. It checks the DSSE envelope, or the message signature against
+// the digest recorded in the bundle, so that the artifact does not have to be
+// read. The recorded digest is only used to pick the key: the bundle is then
+// verified against the artifact with that key.
+func (m *MultiKeyVerifier) verifierForBundle(bundle verify.SignedEntity) (signature.Verifier, error) {
+	sc, err := bundle.SignatureContent()
+	if err != nil {
+		return nil, err
+	}
+	if env := sc.EnvelopeContent(); env != nil {
+		raw, err := json.Marshal(env.RawEnvelope())
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range m.verifiers {
+			if err = dsse.WrapVerifier(v).VerifySignature(bytes.NewReader(raw), nil); err == nil {
+				return v, nil
+			}
+		}
+		return nil, err
+	}
+
+	msg := sc.MessageSignatureContent()
+	if msg == nil {
+		return nil, errors.New("bundle has neither a DSSE envelope nor a message signature")
+	}
+	// Pure Ed25519 signatures can only be checked against the artifact.
+	var ed25519Verifiers []signature.Verifier
+	err = errors.New("bundle has no message digest")
+	for _, v := range m.verifiers {
+		if _, ok := v.(*signature.ED25519Verifier); ok {
+			ed25519Verifiers = append(ed25519Verifiers, v)
+			continue
+		}
+		if len(msg.Digest()) == 0 {
+			continue
+		}
+		if err = v.VerifySignature(bytes.NewReader(msg.Signature()), nil, options.WithDigest(msg.Digest())); err == nil {
+			return v, nil
+		}
+	}
+	switch len(ed25519Verifiers) {
+	case 0:
+		return nil, err
+	case 1:
+		return ed25519Verifiers[0], nil
+	default:
+		return nil, fmt.Errorf("the bundle signature can only be checked against the artifact with one of the %d Ed25519 keys, verify with a single key", len(ed25519Verifiers))
+	}
+}
@@ -0,0 +1,97 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+func TestMultiKeyVerifier(t *testing.T) {
+	if _, err := NewMultiKeyVerifier(); err == nil {
+		t.Error("NewMultiKeyVerifier() accepted no keys")
+	}
+
+	var verifiers []signature.Verifier
+	var signers []signature.SignerVerifier
+	for range 2 {
+		pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sv, err := signature.LoadECDSASignerVerifier(pk, crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers = append(signers, sv)
+		verifiers = append(verifiers, sv)
+	}
+	mv, err := NewMultiKeyVerifier(verifiers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte(`{"critical":{}}`)
+	sig, err := signers[1].SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(payload)
+	if err := mv.VerifySignature(bytes.NewReader(sig), nil, options.WithDigest(digest[:])); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+	if err := mv.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload)); err == nil {
+		t.Error("VerifySignature() verified without a digest")
+	}
+
+	ociSig, err := static.NewSignature(payload, base64.StdEncoding.EncodeToString(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := mv.verifierFor(context.Background(), ociSig, verifyOCISignature)
+	if err != nil {
+		t.Fatalf("verifierFor() error = %v", err)
+	}
+	if v != verifiers[1] {
+		t.Error("verifierFor() returned the wrong key")
+	}
+
+	if _, err := VerifyImageSignature(context.Background(), ociSig, v1.Hash{},
+		&CheckOpts{SigVerifier: mv, IgnoreTlog: true}); err != nil {
+		t.Errorf("VerifyImageSignature() error = %v", err)
+	}
+
+	other, err := static.NewSignature([]byte("other"), base64.StdEncoding.EncodeToString(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyImageSignature(context.Background(), other, v1.Hash{},
+		&CheckOpts{SigVerifier: mv, IgnoreTlog: true}); err == nil {
+		t.Error("VerifyImageSignature() accepted a signature no key made")
+	}
+}
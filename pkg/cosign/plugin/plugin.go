@@ -0,0 +1,246 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin implements signing with external signer executables.
+//
+// A key reference of the form plugin://<name>/<key> is resolved to an
+// executable called cosign-signer-<name> found on $PATH. Each operation
+// runs the executable once, writing a single JSON Request to its stdin and
+// reading a single JSON Response from its stdout. Anything the plugin
+// writes to stderr is passed through to the user.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+const (
+	// ReferenceScheme is the key reference prefix handled by this package.
+	ReferenceScheme = "plugin://"
+	// BinaryPrefix is prepended to the plugin name to find its executable.
+	BinaryPrefix = "cosign-signer-"
+	// ProtocolVersion is sent with every request so plugins can reject
+	// versions they do not understand.
+	ProtocolVersion = "v1"
+
+	// MethodPublicKey asks the plugin for the PEM-encoded public key.
+	MethodPublicKey = "publicKey"
+	// MethodSign asks the plugin to sign a message.
+	MethodSign = "sign"
+)
+
+// Request is written as JSON to the plugin's stdin.
+type Request struct {
+	ProtocolVersion string `json:"protocolVersion"`
+	Method          string `json:"method"`
+	KeyID           string `json:"keyID"`
+	// HashAlgorithm is the name of the hash used to compute Digest, e.g. "sha256".
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+	// Digest is the hash of Message, set for MethodSign. Plugins backed by
+	// services that sign digests should sign this value.
+	Digest []byte `json:"digest,omitempty"`
+	// Message is the raw message, set for MethodSign unless cosign was only
+	// given a digest. Plugins using algorithms without prehashing (e.g.
+	// Ed25519) should sign this value.
+	Message []byte `json:"message,omitempty"`
+}
+
+// Response is read as JSON from the plugin's stdout.
+type Response struct {
+	Error        string `json:"error,omitempty"`
+	PublicKeyPEM []byte `json:"publicKeyPEM,omitempty"`
+	Signature    []byte `json:"signature,omitempty"`
+}
+
+// runner executes the plugin binary with the given stdin and returns its stdout.
+type runner func(ctx context.Context, path string, stdin []byte) ([]byte, error)
+
+func execRunner(ctx context.Context, path string, stdin []byte) ([]byte, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running %s: %w", path, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// Signer is a signature.SignerVerifier backed by a plugin executable.
+type Signer struct {
+	ctx      context.Context
+	path     string
+	keyID    string
+	hashFunc crypto.Hash
+	run      runner
+
+	verifier signature.Verifier
+}
+
+var _ signature.SignerVerifier = (*Signer)(nil)
+
+// IsReference returns true if keyRef should be handled by a signer plugin.
+func IsReference(keyRef string) bool {
+	return strings.HasPrefix(keyRef, ReferenceScheme)
+}
+
+// ParseReference splits a plugin://<name>/<key> reference into the plugin
+// name and the key identifier passed to it. The key identifier may contain
+// further slashes and is opaque to cosign.
+func ParseReference(keyRef string) (name, keyID string, err error) {
+	if !IsReference(keyRef) {
+		return "", "", fmt.Errorf("not a plugin reference: %q", keyRef)
+	}
+	name, keyID, _ = strings.Cut(strings.TrimPrefix(keyRef, ReferenceScheme), "/")
+	if name == "" || keyID == "" {
+		return "", "", errors.New("plugin reference should be in the format plugin://<name>/<key>")
+	}
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", "", fmt.Errorf("invalid plugin name %q", name)
+	}
+	return name, keyID, nil
+}
+
+// LookPath returns the path of the executable implementing the named plugin.
+func LookPath(name string) (string, error) {
+	path, err := exec.LookPath(BinaryPrefix + name)
+	if err != nil {
+		return "", fmt.Errorf("finding signer plugin %q: %w", name, err)
+	}
+	return path, nil
+}
+
+// NewSigner resolves keyRef to a plugin executable and fetches its public
+// key. hashFunc is used to compute the digests passed to the plugin; if it
+// is zero, SHA256 is used.
+func NewSigner(ctx context.Context, keyRef string, hashFunc crypto.Hash) (*Signer, error) {
+	name, keyID, err := ParseReference(keyRef)
+	if err != nil {
+		return nil, err
+	}
+	path, err := LookPath(name)
+	if err != nil {
+		return nil, err
+	}
+	return newSigner(ctx, path, keyID, hashFunc, execRunner)
+}
+
+func newSigner(ctx context.Context, path, keyID string, hashFunc crypto.Hash, run runner) (*Signer, error) {
+	if hashFunc == 0 {
+		hashFunc = crypto.SHA256
+	}
+	s := &Signer{
+		ctx:      ctx,
+		path:     path,
+		keyID:    keyID,
+		hashFunc: hashFunc,
+		run:      run,
+	}
+	resp, err := s.call(ctx, &Request{Method: MethodPublicKey})
+	if err != nil {
+		return nil, err
+	}
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(resp.PublicKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing plugin public key: %w", err)
+	}
+	s.verifier, err = signature.LoadVerifier(pub, hashFunc)
+	if err != nil {
+		return nil, fmt.Errorf("loading plugin verifier: %w", err)
+	}
+	return s, nil
+}
+
+func (s *Signer) call(ctx context.Context, req *Request) (*Response, error) {
+	req.ProtocolVersion = ProtocolVersion
+	req.KeyID = s.keyID
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.run(ctx, s.path, in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Response{}
+	if err := json.Unmarshal(out, resp); err != nil {
+		return nil, fmt.Errorf("decoding %s response from plugin: %w", req.Method, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", req.Method, resp.Error)
+	}
+	return resp, nil
+}
+
+// PublicKey returns the public key reported by the plugin.
+func (s *Signer) PublicKey(opts ...signature.PublicKeyOption) (crypto.PublicKey, error) {
+	return s.verifier.PublicKey(opts...)
+}
+
+// SignMessage sends the message and its digest to the plugin and returns
+// the signature it produces. If a digest is passed with options.WithDigest,
+// only the digest is sent. The signature is checked against the plugin's
+// public key before being returned.
+func (s *Signer) SignMessage(message io.Reader, opts ...signature.SignOption) ([]byte, error) {
+	ctx := s.ctx
+	var digest []byte
+	for _, o := range opts {
+		o.ApplyContext(&ctx)
+		o.ApplyDigest(&digest)
+	}
+	req := &Request{
+		Method:        MethodSign,
+		HashAlgorithm: strings.ToLower(strings.ReplaceAll(s.hashFunc.String(), "-", "")),
+		Digest:        digest,
+	}
+	if digest == nil {
+		msg, err := io.ReadAll(message)
+		if err != nil {
+			return nil, fmt.Errorf("reading message: %w", err)
+		}
+		h := s.hashFunc.New()
+		h.Write(msg)
+		req.Digest = h.Sum(nil)
+		req.Message = msg
+	}
+
+	resp, err := s.call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifier.VerifySignature(bytes.NewReader(resp.Signature), bytes.NewReader(req.Message), options.WithDigest(req.Digest)); err != nil {
+		return nil, fmt.Errorf("plugin returned an invalid signature: %w", err)
+	}
+	return resp.Signature, nil
+}
+
+// VerifySignature verifies the signature locally using the plugin's public key.
+func (s *Signer) VerifySignature(sig, message io.Reader, opts ...signature.VerifyOption) error {
+	return s.verifier.VerifySignature(sig, message, opts...)
+}
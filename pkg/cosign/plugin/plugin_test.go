@@ -0,0 +1,141 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		name    string
+		keyID   string
+		wantErr bool
+	}{
+		{ref: "plugin://vault-transit/key1", name: "vault-transit", keyID: "key1"},
+		{ref: "plugin://hsm/slot/3", name: "hsm", keyID: "slot/3"},
+		{ref: "plugin://vault-transit", wantErr: true},
+		{ref: "plugin:///key1", wantErr: true},
+		{ref: "plugin://../key1", wantErr: true},
+		{ref: "k8s://ns/name", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			name, keyID, err := ParseReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if name != tt.name || keyID != tt.keyID {
+				t.Errorf("ParseReference() = (%q, %q), want (%q, %q)", name, keyID, tt.name, tt.keyID)
+			}
+		})
+	}
+}
+
+func fakePlugin(t *testing.T, priv *ecdsa.PrivateKey) runner {
+	t.Helper()
+	pemBytes, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(_ context.Context, _ string, stdin []byte) ([]byte, error) {
+		req := Request{}
+		if err := json.Unmarshal(stdin, &req); err != nil {
+			return nil, err
+		}
+		resp := Response{}
+		switch {
+		case req.KeyID != "key1":
+			resp.Error = "unknown key"
+		case req.Method == MethodPublicKey:
+			resp.PublicKeyPEM = pemBytes
+		case req.Method == MethodSign:
+			digest := sha256.Sum256(req.Message)
+			if req.HashAlgorithm != "sha256" || (req.Message != nil && !bytes.Equal(digest[:], req.Digest)) {
+				resp.Error = "bad digest"
+				break
+			}
+			resp.Signature, err = ecdsa.SignASN1(rand.Reader, priv, req.Digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(resp)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	run := fakePlugin(t, priv)
+
+	s, err := newSigner(ctx, "cosign-signer-fake", "key1", crypto.SHA256, run)
+	if err != nil {
+		t.Fatalf("newSigner() error = %v", err)
+	}
+	pub, err := s.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cryptoutils.EqualKeys(pub, priv.Public()); err != nil {
+		t.Errorf("PublicKey() mismatch: %v", err)
+	}
+
+	msg := []byte("payload")
+	sig, err := s.SignMessage(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatalf("SignMessage() error = %v", err)
+	}
+	if err := s.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+
+	// Only the digest is sent when one is provided.
+	digest := sha256.Sum256(msg)
+	sig, err = s.SignMessage(bytes.NewReader(nil), options.WithDigest(digest[:]))
+	if err != nil {
+		t.Fatalf("SignMessage() with digest error = %v", err)
+	}
+	if err := s.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)); err != nil {
+		t.Errorf("VerifySignature() of digest signature error = %v", err)
+	}
+
+	if _, err := newSigner(ctx, "cosign-signer-fake", "missing", crypto.SHA256, run); err == nil {
+		t.Error("expected error for unknown key")
+	}
+}
+
+func TestNewSignerMissingPlugin(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if _, err := NewSigner(context.Background(), "plugin://does-not-exist/key1", 0); err == nil {
+		t.Error("expected error for missing plugin executable")
+	}
+}
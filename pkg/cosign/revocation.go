@@ -0,0 +1,214 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+)
+
+// RevocationListPayloadType is the DSSE payload type of revocation lists.
+const RevocationListPayloadType = "application/vnd.dev.sigstore.cosign.revocation-list+json"
+
+// RevocationList lists signing keys, certificates and identities that are no
+// longer trusted. It is distributed as a DSSE envelope signed by a revocation
+// authority key.
+type RevocationList struct {
+	// Updated is when the list was last signed.
+	Updated     time.Time    `json:"updated"`
+	Revocations []Revocation `json:"revocations"`
+}
+
+// Revocation revokes exactly one of a key, a certificate or an identity.
+// Signatures whose trusted time is at or after EffectiveTime are rejected;
+// earlier signatures remain valid.
+type Revocation struct {
+	// KeyID is the base64 encoded SHA-256 digest of the DER encoded public
+	// key, as returned by PublicKeyID. It matches signatures made with the key
+	// directly or through a certificate.
+	KeyID string `json:"keyId,omitempty"`
+	// CertificateSerial is the lowercase hex encoded certificate serial number.
+	CertificateSerial string `json:"certificateSerial,omitempty"`
+	// Identity is a certificate subject alternative name, optionally
+	// restricted to certificates issued for OIDCIssuer.
+	Identity   string `json:"identity,omitempty"`
+	OIDCIssuer string `json:"oidcIssuer,omitempty"`
+
+	EffectiveTime time.Time `json:"effectiveTime"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+// NormalizeCertificateSerial returns serial as lowercase hex. It accepts hex
+// serials with an optional 0x prefix and colon separators, as printed by
+// openssl.
+func NormalizeCertificateSerial(serial string) (string, error) {
+	s := strings.ReplaceAll(strings.TrimPrefix(strings.ToLower(serial), "0x"), ":", "")
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok || n.Sign() < 0 {
+		return "", fmt.Errorf("invalid certificate serial %q", serial)
+	}
+	return n.Text(16), nil
+}
+
+func (r *Revocation) validate() error {
+	var set int
+	for _, v := range []string{r.KeyID, r.CertificateSerial, r.Identity} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("a revocation must name exactly one of a key ID, a certificate serial or an identity")
+	}
+	if r.OIDCIssuer != "" && r.Identity == "" {
+		return errors.New("an OIDC issuer may only restrict a revoked identity")
+	}
+	if r.CertificateSerial != "" {
+		serial, err := NormalizeCertificateSerial(r.CertificateSerial)
+		if err != nil {
+			return err
+		}
+		if serial != r.CertificateSerial {
+			return fmt.Errorf("certificate serial %q must be lowercase hex, %q", r.CertificateSerial, serial)
+		}
+	}
+	return nil
+}
+
+func (r *Revocation) String() string {
+	var s string
+	switch {
+	case r.KeyID != "":
+		s = "key " + r.KeyID
+	case r.CertificateSerial != "":
+		s = "certificate " + r.CertificateSerial
+	case r.OIDCIssuer != "":
+		s = fmt.Sprintf("identity %s issued by %s", r.Identity, r.OIDCIssuer)
+	default:
+		s = "identity " + r.Identity
+	}
+	if r.Reason != "" {
+		s += " (" + r.Reason + ")"
+	}
+	return s
+}
+
+func (r *Revocation) matches(keyID, serial, issuer string, sans []string) bool {
+	switch {
+	case r.KeyID != "":
+		return r.KeyID == keyID
+	case r.CertificateSerial != "":
+		return r.CertificateSerial == serial
+	default:
+		if r.OIDCIssuer != "" && r.OIDCIssuer != issuer {
+			return false
+		}
+		return slices.Contains(sans, r.Identity)
+	}
+}
+
+// SignRevocationList returns a DSSE envelope of rl signed by signer.
+func SignRevocationList(rl *RevocationList, signer signature.Signer) ([]byte, error) {
+	for i := range rl.Revocations {
+		if err := rl.Revocations[i].validate(); err != nil {
+			return nil, fmt.Errorf("revocation %d: %w", i, err)
+		}
+	}
+	payload, err := json.Marshal(rl)
+	if err != nil {
+		return nil, err
+	}
+	return dsse.WrapSigner(signer, RevocationListPayloadType).SignMessage(bytes.NewReader(payload))
+}
+
+// VerifyRevocationList verifies that envelope is a revocation list signed by
+// verifier and returns the list.
+func VerifyRevocationList(envelope []byte, verifier signature.Verifier) (*RevocationList, error) {
+	var payload []byte
+	v := dsse.WrapVerifier(verifier, dsse.WithExpectedPayloadType(RevocationListPayloadType), dsse.WithDecodedPayload(&payload))
+	if err := v.VerifySignature(bytes.NewReader(envelope), nil); err != nil {
+		return nil, fmt.Errorf("verifying revocation list: %w", err)
+	}
+	rl := &RevocationList{}
+	if err := json.Unmarshal(payload, rl); err != nil {
+		return nil, fmt.Errorf("parsing revocation list: %w", err)
+	}
+	for i := range rl.Revocations {
+		if err := rl.Revocations[i].validate(); err != nil {
+			return nil, fmt.Errorf("revocation %d: %w", i, err)
+		}
+	}
+	return rl, nil
+}
+
+// LoadRevocationList reads a revocation list envelope from path and verifies
+// it with verifier.
+func LoadRevocationList(path string, verifier signature.Verifier) (*RevocationList, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return VerifyRevocationList(b, verifier)
+}
+
+// Check returns an error if the signing certificate, or the key with the
+// given ID when there is no certificate, was revoked at any of the trusted
+// times of a signature. Without a trusted time the current time is used,
+// so that any revocation already in effect applies.
+func (rl *RevocationList) Check(cert *x509.Certificate, keyID string, times []time.Time) error {
+	var serial, issuer string
+	var sans []string
+	if cert != nil {
+		var err error
+		if keyID, err = PublicKeyID(cert.PublicKey); err != nil {
+			return err
+		}
+		serial = cert.SerialNumber.Text(16)
+		sans = cryptoutils.GetSubjectAlternateNames(cert)
+		extensions, err := certificate.ParseExtensions(cert.Extensions)
+		if err != nil {
+			return err
+		}
+		issuer = extensions.Issuer
+	}
+	latest := time.Now()
+	if len(times) > 0 {
+		latest = slices.MaxFunc(times, time.Time.Compare)
+	}
+	for i := range rl.Revocations {
+		r := &rl.Revocations[i]
+		if r.matches(keyID, serial, issuer, sans) && !latest.Before(r.EffectiveTime) {
+			return &VerificationFailure{
+				fmt.Errorf("signer was revoked: %s, effective %s", r, r.EffectiveTime.UTC().Format(time.RFC3339)),
+			}
+		}
+	}
+	return nil
+}
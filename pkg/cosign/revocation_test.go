@@ -0,0 +1,136 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/internal/test"
+)
+
+func TestNormalizeCertificateSerial(t *testing.T) {
+	for in, want := range map[string]string{
+		"0x1A2b":      "1a2b",
+		"01:A2:FF":    "1a2ff",
+		"00":          "0",
+		"deadbeef":    "deadbeef",
+		"0XDEADBEEF":  "deadbeef",
+		"7f:00:00:01": "7f000001",
+	} {
+		got, err := NormalizeCertificateSerial(in)
+		if err != nil || got != want {
+			t.Errorf("NormalizeCertificateSerial(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := NormalizeCertificateSerial("xyz"); err == nil {
+		t.Error("expected error for an invalid serial")
+	}
+}
+
+func TestSignRevocationList(t *testing.T) {
+	authority, _ := keySetSigner(t)
+	other, _ := keySetSigner(t)
+	rl := &RevocationList{
+		Updated:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Revocations: []Revocation{{KeyID: "abc", Reason: "leaked"}},
+	}
+	envelope, err := SignRevocationList(rl, authority)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := VerifyRevocationList(envelope, authority)
+	if err != nil {
+		t.Fatalf("VerifyRevocationList() error = %v", err)
+	}
+	if len(got.Revocations) != 1 || got.Revocations[0].Reason != "leaked" {
+		t.Errorf("VerifyRevocationList() = %+v", got)
+	}
+	if _, err := VerifyRevocationList(envelope, other); err == nil {
+		t.Error("expected error verifying with another key")
+	}
+
+	for _, r := range []Revocation{
+		{},
+		{KeyID: "abc", Identity: "ci@example.com"},
+		{KeyID: "abc", OIDCIssuer: "https://issuer"},
+		{CertificateSerial: "0x1A"},
+	} {
+		if _, err := SignRevocationList(&RevocationList{Revocations: []Revocation{r}}, authority); err == nil {
+			t.Errorf("expected error signing revocation %+v", r)
+		}
+	}
+}
+
+func TestRevocationListCheck(t *testing.T) {
+	rootCert, rootKey, _ := test.GenerateRootCa()
+	leafCert, _, _ := test.GenerateLeafCert("ci@example.com", "https://token.actions.githubusercontent.com", rootCert, rootKey)
+	_, pem := keySetSigner(t)
+	keyID, err := keySetKeyID(pem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafKeyID, err := PublicKeyID(leafCert.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signatures without a trusted time are checked at the current time.
+	revoked := time.Now().Add(-24 * time.Hour)
+	before := []time.Time{revoked.Add(-time.Hour)}
+	after := []time.Time{revoked.Add(time.Hour)}
+
+	for _, tt := range []struct {
+		name       string
+		revocation Revocation
+		keyOnly    bool
+		times      []time.Time
+		wantErr    bool
+	}{
+		{"key before revocation", Revocation{KeyID: keyID}, true, before, false},
+		{"key after revocation", Revocation{KeyID: keyID}, true, after, true},
+		{"key at revocation", Revocation{KeyID: keyID}, true, []time.Time{revoked}, true},
+		{"key with one late time", Revocation{KeyID: keyID}, true, []time.Time{before[0], after[0]}, true},
+		{"key without trusted time", Revocation{KeyID: keyID}, true, nil, true},
+		{"other key", Revocation{KeyID: leafKeyID}, true, after, false},
+		{"certificate key", Revocation{KeyID: leafKeyID}, false, after, true},
+		{"certificate serial", Revocation{CertificateSerial: "1"}, false, after, true},
+		{"other certificate serial", Revocation{CertificateSerial: "2"}, false, after, false},
+		{"identity", Revocation{Identity: "ci@example.com"}, false, after, true},
+		{"identity before revocation", Revocation{Identity: "ci@example.com"}, false, before, false},
+		{"identity and issuer", Revocation{Identity: "ci@example.com", OIDCIssuer: "https://token.actions.githubusercontent.com"}, false, after, true},
+		{"identity from another issuer", Revocation{Identity: "ci@example.com", OIDCIssuer: "https://accounts.google.com"}, false, after, false},
+		{"identity of a key", Revocation{Identity: "ci@example.com"}, true, after, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.revocation.EffectiveTime = revoked
+			rl := &RevocationList{Revocations: []Revocation{tt.revocation}}
+			var err error
+			if tt.keyOnly {
+				err = rl.Check(nil, keyID, tt.times)
+			} else {
+				err = rl.Check(leafCert, "", tt.times)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			var vf *VerificationFailure
+			if err != nil && !errors.As(err, &vf) {
+				t.Errorf("Check() error = %v, want a VerificationFailure", err)
+			}
+		})
+	}
+}
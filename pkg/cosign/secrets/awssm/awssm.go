@@ -0,0 +1,98 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awssm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+const (
+	ReferenceScheme = "awssm"
+)
+
+// SecretsManager stores secrets in AWS Secrets Manager as a JSON object in
+// the secret string. References are secret names or ARNs. Credentials,
+// region and endpoint (AWS_ENDPOINT_URL) come from the default AWS
+// configuration chain.
+type SecretsManager struct{}
+
+func New() *SecretsManager {
+	return &SecretsManager{}
+}
+
+func (s *SecretsManager) Write(ctx context.Context, ref string, data map[string]string) error {
+	if ref == "" {
+		return errors.New("could not parse reference, use awssm://<secret name or ARN> format")
+	}
+	secret, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(ref),
+		SecretString: aws.String(string(secret)),
+	})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		_, err = client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+			Name:         aws.String(ref),
+			SecretString: aws.String(string(secret)),
+		})
+	}
+	return err
+}
+
+func (s *SecretsManager) Read(ctx context.Context, ref string) (map[string]string, error) {
+	if ref == "" {
+		return nil, errors.New("could not parse reference, use awssm://<secret name or ARN> format")
+	}
+	client, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(ref)})
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]string{}
+	if err := json.Unmarshal([]byte(aws.ToString(out.SecretString)), &data); err != nil {
+		return nil, fmt.Errorf("secret is not a JSON object of strings: %w", err)
+	}
+	return data, nil
+}
+
+func newClient(ctx context.Context) (*secretsmanager.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("no AWS region configured")
+	}
+	return secretsmanager.NewFromConfig(cfg), nil
+}
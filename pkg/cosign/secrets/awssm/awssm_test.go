@@ -0,0 +1,108 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awssm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// secretsManagerServer is a stand-in for the AWS Secrets Manager JSON API.
+func secretsManagerServer(t *testing.T) (*httptest.Server, *[]string) {
+	secrets := map[string]string{}
+	var actions []string
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"ResourceNotFoundException","Message":"Secrets Manager can't find the specified secret."}`))
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIDTEST/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-east-1/secretsmanager/aws4_request") {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		var in struct{ Name, SecretId, SecretString string }
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Error(err)
+		}
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "secretsmanager.")
+		actions = append(actions, action)
+		switch action {
+		case "CreateSecret":
+			secrets[in.Name] = in.SecretString
+			w.Write([]byte(`{}`))
+		case "PutSecretValue":
+			if _, ok := secrets[in.SecretId]; !ok {
+				notFound(w)
+				return
+			}
+			secrets[in.SecretId] = in.SecretString
+			w.Write([]byte(`{}`))
+		case "GetSecretValue":
+			s, ok := secrets[in.SecretId]
+			if !ok {
+				notFound(w)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"SecretString": s})
+		default:
+			t.Errorf("unexpected action %q", action)
+		}
+	})), &actions
+}
+
+func TestSecretsManager(t *testing.T) {
+	server, actions := secretsManagerServer(t)
+	defer server.Close()
+	td := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(td, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(td, "credentials"))
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	ctx := context.Background()
+
+	s := New()
+	for _, password := range []string{"first", "second"} {
+		if err := s.Write(ctx, "cosign/team-a", map[string]string{"COSIGN_PASSWORD": password}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	got, err := s.Read(ctx, "cosign/team-a")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got["COSIGN_PASSWORD"] != "second" {
+		t.Errorf("Read() = %v", got)
+	}
+	want := []string{"PutSecretValue", "CreateSecret", "PutSecretValue", "GetSecretValue"}
+	if strings.Join(*actions, ",") != strings.Join(want, ",") {
+		t.Errorf("actions = %v, want %v", *actions, want)
+	}
+
+	_, err = s.Read(ctx, "cosign/team-b")
+	var notFound *types.ResourceNotFoundException
+	if !errors.As(err, &notFound) {
+		t.Errorf("Read() error = %v, want ResourceNotFoundException", err)
+	}
+}
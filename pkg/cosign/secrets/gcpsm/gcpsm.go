@@ -0,0 +1,118 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2/apierror"
+	"google.golang.org/api/option"
+)
+
+const (
+	ReferenceScheme = "gcpsm"
+)
+
+// SecretManager stores secrets in GCP Secret Manager as a JSON object in
+// the payload of a secret version. References take the form
+// projects/<project>/secrets/<secret>. Requests are authenticated with
+// Application Default Credentials.
+type SecretManager struct {
+	opts []option.ClientOption
+}
+
+func New() *SecretManager {
+	return &SecretManager{}
+}
+
+func (s *SecretManager) Write(ctx context.Context, ref string, data map[string]string) error {
+	parent, secretID, err := parseRef(ref)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	client, err := secretmanager.NewRESTClient(ctx, s.opts...)
+	if err != nil {
+		return fmt.Errorf("creating GCP client: %w", err)
+	}
+	defer client.Close()
+
+	version := &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  ref,
+		Payload: &secretmanagerpb.SecretPayload{Data: b},
+	}
+	_, err = client.AddSecretVersion(ctx, version)
+	if !isNotFound(err) {
+		return err
+	}
+	if _, err := client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   parent,
+		SecretId: secretID,
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{Automatic: &secretmanagerpb.Replication_Automatic{}},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("creating secret: %w", err)
+	}
+	_, err = client.AddSecretVersion(ctx, version)
+	return err
+}
+
+func (s *SecretManager) Read(ctx context.Context, ref string) (map[string]string, error) {
+	if _, _, err := parseRef(ref); err != nil {
+		return nil, err
+	}
+	client, err := secretmanager.NewRESTClient(ctx, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCP client: %w", err)
+	}
+	defer client.Close()
+
+	resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: ref + "/versions/latest"})
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]string{}
+	if err := json.Unmarshal(resp.GetPayload().GetData(), &data); err != nil {
+		return nil, fmt.Errorf("secret is not a JSON object of strings: %w", err)
+	}
+	return data, nil
+}
+
+func parseRef(ref string) (string, string, error) {
+	split := strings.Split(ref, "/")
+	if len(split) != 4 || split[0] != "projects" || split[1] == "" || split[2] != "secrets" || split[3] == "" {
+		return "", "", errors.New("could not parse reference, use gcpsm://projects/<project>/secrets/<secret> format")
+	}
+	return strings.Join(split[:2], "/"), split[3], nil
+}
+
+func isNotFound(err error) bool {
+	var apiErr *apierror.APIError
+	return errors.As(err, &apiErr) && apiErr.HTTPCode() == http.StatusNotFound
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcpsm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+)
+
+// secretManagerServer is a stand-in for the GCP Secret Manager REST API.
+func secretManagerServer(t *testing.T) *httptest.Server {
+	type payload struct {
+		Data []byte `json:"data"`
+	}
+	secrets := map[string][]payload{}
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":404,"message":"Secret not found","status":"NOT_FOUND"}}`))
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/secrets"):
+			secrets[path+"/"+r.URL.Query().Get("secretId")] = nil
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(path, ":addVersion"):
+			name := strings.TrimSuffix(path, ":addVersion")
+			if _, ok := secrets[name]; !ok {
+				notFound(w)
+				return
+			}
+			var in struct {
+				Payload payload `json:"payload"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Error(err)
+			}
+			secrets[name] = append(secrets[name], in.Payload)
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/versions/latest:access"):
+			versions := secrets[strings.TrimSuffix(path, "/versions/latest:access")]
+			if len(versions) == 0 {
+				notFound(w)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"payload": versions[len(versions)-1]})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+}
+
+func TestSecretManager(t *testing.T) {
+	server := secretManagerServer(t)
+	defer server.Close()
+	ctx := context.Background()
+
+	s := &SecretManager{opts: []option.ClientOption{
+		option.WithEndpoint(server.URL),
+		option.WithHTTPClient(server.Client()),
+	}}
+	ref := "projects/my-project/secrets/cosign"
+	for _, password := range []string{"first", "second"} {
+		if err := s.Write(ctx, ref, map[string]string{"COSIGN_PASSWORD": password}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	got, err := s.Read(ctx, ref)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got["COSIGN_PASSWORD"] != "second" {
+		t.Errorf("Read() = %v", got)
+	}
+
+	if _, err := s.Read(ctx, "projects/my-project/secrets/other"); !isNotFound(err) {
+		t.Errorf("Read() error = %v, want not found", err)
+	}
+	for _, ref := range []string{"my-project/cosign", "projects/my-project/secrets/", "projects/p/secrets/s/versions/1"} {
+		if err := s.Write(ctx, ref, nil); err == nil {
+			t.Errorf("expected error for reference %q", ref)
+		}
+	}
+}
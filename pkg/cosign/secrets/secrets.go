@@ -0,0 +1,117 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets stores cosign key pairs in secret managers such as
+// HashiCorp Vault and the AWS and GCP secret managers.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets/awssm"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets/gcpsm"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets/vault"
+)
+
+// Keys of the values stored in a secret, matching the names used for the
+// GitHub and GitLab secrets.
+const (
+	PrivateKey = "COSIGN_PRIVATE_KEY"
+	Password   = "COSIGN_PASSWORD" //nolint:gosec
+	PublicKey  = "COSIGN_PUBLIC_KEY"
+)
+
+// Backend reads and writes a single secret holding string values.
+type Backend interface {
+	Write(ctx context.Context, ref string, data map[string]string) error
+	Read(ctx context.Context, ref string) (map[string]string, error)
+}
+
+var providerMap = map[string]Backend{
+	vault.ReferenceScheme: vault.New(),
+	awssm.ReferenceScheme: awssm.New(),
+	gcpsm.ReferenceScheme: gcpsm.New(),
+}
+
+// SecretManager generates key pairs into, and reads them back from, a
+// secret manager.
+type SecretManager interface {
+	PutSecret(ctx context.Context, ref string, pf cosign.PassFunc) error
+	GetSecret(ctx context.Context, ref string, key string) (string, error)
+}
+
+// GetProvider returns the secret manager for the provider scheme, or nil if
+// the scheme is not a secret manager.
+func GetProvider(provider string) SecretManager {
+	b, ok := providerMap[provider]
+	if !ok {
+		return nil
+	}
+	return &secretManager{scheme: provider, backend: b}
+}
+
+// IsReference returns true if keyRef refers to a secret manager.
+func IsReference(keyRef string) bool {
+	provider, _, ok := strings.Cut(keyRef, "://")
+	if !ok {
+		return false
+	}
+	_, ok = providerMap[provider]
+	return ok
+}
+
+type secretManager struct {
+	scheme  string
+	backend Backend
+}
+
+func (s *secretManager) PutSecret(ctx context.Context, ref string, pf cosign.PassFunc) error {
+	keys, err := cosign.GenerateKeyPair(pf)
+	if err != nil {
+		return fmt.Errorf("generating key pair: %w", err)
+	}
+
+	if err := s.backend.Write(ctx, ref, map[string]string{
+		PrivateKey: string(keys.PrivateBytes),
+		Password:   string(keys.Password()),
+		PublicKey:  string(keys.PublicBytes),
+	}); err != nil {
+		return fmt.Errorf("writing %s://%s: %w", s.scheme, ref, err)
+	}
+	fmt.Fprintf(os.Stderr, "Private key, password and public key written to %s://%s\n", s.scheme, ref)
+
+	if err := os.WriteFile("cosign.pub", keys.PublicBytes, 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Public key also written to cosign.pub")
+
+	return nil
+}
+
+func (s *secretManager) GetSecret(ctx context.Context, ref string, key string) (string, error) {
+	data, err := s.backend.Read(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("reading %s://%s: %w", s.scheme, ref, err)
+	}
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("%s://%s has no %q value", s.scheme, ref, key)
+	}
+	return v, nil
+}
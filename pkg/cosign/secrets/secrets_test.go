@@ -0,0 +1,95 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+)
+
+type memoryBackend map[string]map[string]string
+
+func (m memoryBackend) Write(_ context.Context, ref string, data map[string]string) error {
+	m[ref] = data
+	return nil
+}
+
+func (m memoryBackend) Read(_ context.Context, ref string) (map[string]string, error) {
+	data, ok := m[ref]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func TestSecretManager(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	sm := &secretManager{scheme: "memory", backend: memoryBackend{}}
+
+	pf := func(bool) ([]byte, error) { return []byte("hunter2"), nil }
+	if err := sm.PutSecret(ctx, "team-a", pf); err != nil {
+		t.Fatalf("PutSecret() error = %v", err)
+	}
+
+	pk, err := sm.GetSecret(ctx, "team-a", PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pass, err := sm.GetSecret(ctx, "team-a", Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cosign.LoadPrivateKey([]byte(pk), []byte(pass), nil); err != nil {
+		t.Errorf("LoadPrivateKey() error = %v", err)
+	}
+	pub, err := sm.GetSecret(ctx, "team-a", PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile("cosign.pub"); err != nil || string(b) != pub {
+		t.Errorf("cosign.pub = %q, %v, want %q", b, err, pub)
+	}
+
+	if _, err := sm.GetSecret(ctx, "team-b", PrivateKey); err == nil {
+		t.Error("expected error reading a missing secret")
+	}
+	if _, err := sm.GetSecret(ctx, "team-a", "COSIGN_OTHER"); err == nil {
+		t.Error("expected error reading a missing value")
+	}
+}
+
+func TestIsReference(t *testing.T) {
+	for ref, want := range map[string]bool{
+		"vault://secret/cosign":                  true,
+		"awssm://cosign":                         true,
+		"gcpsm://projects/p/secrets/cosign":      true,
+		"hashivault://cosign":                    false,
+		"gitlab://group/project":                 false,
+		"cosign.key":                             false,
+		"https://example.com/vault://cosign.key": false,
+	} {
+		if got := IsReference(ref); got != want {
+			t.Errorf("IsReference(%q) = %t, want %t", ref, got, want)
+		}
+	}
+	if GetProvider("gitlab") != nil {
+		t.Error("GetProvider() returned a provider for an unknown scheme")
+	}
+}
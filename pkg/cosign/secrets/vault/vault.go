@@ -0,0 +1,84 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+)
+
+const (
+	ReferenceScheme = "vault"
+)
+
+// Vault stores secrets in a HashiCorp Vault KV version 2 secrets engine.
+// References take the form <mount>/<path>, e.g. secret/cosign/team-a. The
+// client is configured from the standard VAULT_ADDR, VAULT_TOKEN and
+// VAULT_NAMESPACE environment variables.
+type Vault struct{}
+
+func New() *Vault {
+	return &Vault{}
+}
+
+func (v *Vault) Write(ctx context.Context, ref string, data map[string]string) error {
+	kv, path, err := kvClient(ref)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		values[k] = v
+	}
+	_, err = kv.Put(ctx, path, values)
+	return err
+}
+
+func (v *Vault) Read(ctx context.Context, ref string) (map[string]string, error) {
+	kv, path, err := kvClient(ref)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := kv.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value %q is not a string", k)
+		}
+		data[k] = s
+	}
+	return data, nil
+}
+
+func kvClient(ref string) (*api.KVv2, string, error) {
+	mount, path, _ := strings.Cut(strings.Trim(ref, "/"), "/")
+	if mount == "" || path == "" {
+		return nil, "", errors.New("could not parse reference, use vault://<mount>/<path> format")
+	}
+	client, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, "", fmt.Errorf("creating vault client: %w", err)
+	}
+	return client.KVv2(mount), path, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// kvServer is a stand-in for a Vault KV version 2 engine mounted at secret/.
+func kvServer(t *testing.T) *httptest.Server {
+	secrets := map[string]map[string]any{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		path := r.URL.Path
+		if !strings.HasPrefix(path, "/v1/secret/data/") {
+			t.Errorf("unexpected path %s", path)
+		}
+		metadata := map[string]any{"version": 1, "created_time": "2026-03-01T00:00:00Z"}
+		switch r.Method {
+		case http.MethodPut, http.MethodPost:
+			var body struct {
+				Data map[string]any `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			secrets[path] = body.Data
+			json.NewEncoder(w).Encode(map[string]any{"data": metadata})
+		case http.MethodGet:
+			data, ok := secrets[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data, "metadata": metadata}})
+		}
+	}))
+}
+
+func TestVault(t *testing.T) {
+	server := kvServer(t)
+	defer server.Close()
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "test-token")
+	ctx := context.Background()
+
+	v := New()
+	want := map[string]string{"COSIGN_PASSWORD": "hunter2", "COSIGN_PUBLIC_KEY": "pem"}
+	if err := v.Write(ctx, "secret/cosign/team-a", want); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	got, err := v.Read(ctx, "secret/cosign/team-a")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(got) != 2 || got["COSIGN_PASSWORD"] != "hunter2" {
+		t.Errorf("Read() = %v, want %v", got, want)
+	}
+
+	if _, err := v.Read(ctx, "secret/cosign/team-b"); err == nil {
+		t.Error("expected error reading a missing secret")
+	}
+	if err := v.Write(ctx, "secret", want); err == nil {
+		t.Error("expected error for a reference without a path")
+	}
+}
@@ -0,0 +1,200 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shamir implements Shamir's secret sharing over GF(2^8), splitting
+// a secret into shares of which any threshold number reconstruct it.
+package shamir
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxShares is the largest number of shares a secret can be split into,
+	// one per non-zero element of GF(2^8).
+	MaxShares = 255
+
+	sharePrefix = "cosign-share-v1"
+	setIDSize   = 8
+)
+
+// Share is one share of a split secret.
+type Share struct {
+	// SetID is shared by all the shares of one split, so that shares of
+	// different secrets are not combined by mistake.
+	SetID string
+	// Threshold is the number of shares needed to reconstruct the secret.
+	Threshold int
+	// Index is the x coordinate of the share, from 1 to MaxShares.
+	Index int
+	// Value holds one byte of the share per byte of the secret.
+	Value []byte
+}
+
+// String encodes the share on a single line, so that it can be stored in a
+// file or typed at a prompt.
+func (s Share) String() string {
+	return strings.Join([]string{
+		sharePrefix,
+		s.SetID,
+		strconv.Itoa(s.Threshold),
+		strconv.Itoa(s.Index),
+		base64.RawStdEncoding.EncodeToString(s.Value),
+	}, ":")
+}
+
+// ParseShare decodes a share encoded by Share.String.
+func ParseShare(s string) (Share, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 5 || parts[0] != sharePrefix {
+		return Share{}, errors.New("not a cosign secret share")
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil || len(parts[1]) != 2*setIDSize {
+		return Share{}, fmt.Errorf("invalid share set ID %q", parts[1])
+	}
+	threshold, err := strconv.Atoi(parts[2])
+	if err != nil || threshold < 2 || threshold > MaxShares {
+		return Share{}, fmt.Errorf("invalid share threshold %q", parts[2])
+	}
+	index, err := strconv.Atoi(parts[3])
+	if err != nil || index < 1 || index > MaxShares {
+		return Share{}, fmt.Errorf("invalid share index %q", parts[3])
+	}
+	value, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(value) == 0 {
+		return Share{}, errors.New("invalid share value")
+	}
+	return Share{SetID: parts[1], Threshold: threshold, Index: index, Value: value}, nil
+}
+
+// Split splits secret into n shares, any threshold of which reconstruct it.
+func Split(secret []byte, n, threshold int) ([]Share, error) {
+	switch {
+	case len(secret) == 0:
+		return nil, errors.New("cannot split an empty secret")
+	case threshold < 2:
+		return nil, errors.New("threshold must be at least 2")
+	case n < threshold:
+		return nil, errors.New("number of shares must be at least the threshold")
+	case n > MaxShares:
+		return nil, fmt.Errorf("number of shares must be at most %d", MaxShares)
+	}
+
+	setID := make([]byte, setIDSize)
+	if _, err := rand.Read(setID); err != nil {
+		return nil, err
+	}
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{SetID: hex.EncodeToString(setID), Threshold: threshold, Index: i + 1, Value: make([]byte, len(secret))}
+	}
+
+	// Each byte of the secret is the constant term of a random polynomial of
+	// degree threshold-1, and share i holds its value at x = i.
+	coefficients := make([]byte, threshold)
+	defer clear(coefficients)
+	for b, s := range secret {
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, err
+		}
+		coefficients[0] = s
+		for i := range shares {
+			shares[i].Value[b] = evaluate(coefficients, byte(shares[i].Index))
+		}
+	}
+	return shares, nil
+}
+
+// Combine reconstructs the secret from at least threshold shares of the
+// same split. Shares beyond the threshold are ignored.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares to combine")
+	}
+	first := shares[0]
+	if len(shares) < first.Threshold {
+		return nil, fmt.Errorf("%d shares are needed, got %d", first.Threshold, len(shares))
+	}
+	shares = shares[:first.Threshold]
+	seen := map[int]bool{}
+	for _, s := range shares {
+		switch {
+		case s.SetID != first.SetID || s.Threshold != first.Threshold:
+			return nil, errors.New("shares are from different splits")
+		case len(s.Value) != len(first.Value):
+			return nil, errors.New("shares have different lengths")
+		case s.Index < 1 || s.Index > MaxShares:
+			return nil, fmt.Errorf("invalid share index %d", s.Index)
+		case seen[s.Index]:
+			return nil, fmt.Errorf("share %d was given more than once", s.Index)
+		}
+		seen[s.Index] = true
+	}
+
+	// Lagrange interpolation at x = 0. Addition and subtraction are both
+	// XOR in GF(2^8).
+	secret := make([]byte, len(first.Value))
+	for i, si := range shares {
+		basis := byte(1)
+		for j, sj := range shares {
+			if i == j {
+				continue
+			}
+			xj := byte(sj.Index)
+			basis = mul(basis, div(xj, xj^byte(si.Index)))
+		}
+		for b := range secret {
+			secret[b] ^= mul(si.Value[b], basis)
+		}
+	}
+	return secret, nil
+}
+
+// evaluate returns the polynomial with the given coefficients, constant term
+// first, at x using Horner's method.
+func evaluate(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = mul(y, x) ^ coefficients[i]
+	}
+	return y
+}
+
+// mul multiplies in GF(2^8) with the AES reduction polynomial
+// x^8 + x^4 + x^3 + x + 1, without branching on the operands.
+func mul(a, b byte) byte {
+	var p byte
+	for range 8 {
+		p ^= a & -(b & 1)
+		a = (a << 1) ^ (0x1b & -(a >> 7))
+		b >>= 1
+	}
+	return p
+}
+
+// div divides a by b, which must not be zero. The inverse of b is b^254.
+func div(a, b byte) byte {
+	inv := b
+	for range 6 {
+		inv = mul(mul(inv, inv), b)
+	}
+	return mul(a, mul(inv, inv))
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shamir
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMulDiv(t *testing.T) {
+	// From FIPS 197 section 4.2.
+	if got := mul(0x57, 0x83); got != 0xc1 {
+		t.Errorf("mul(0x57, 0x83) = %#x, want 0xc1", got)
+	}
+	for a := 0; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			if got := mul(div(byte(a), byte(b)), byte(b)); got != byte(a) {
+				t.Fatalf("div(%#x, %#x) * %#x = %#x", a, b, b, got)
+			}
+		}
+	}
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("correct horse battery staple")
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("got %d shares, want 5", len(shares))
+	}
+
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		var picked []Share
+		for _, i := range subset {
+			picked = append(picked, shares[i])
+		}
+		got, err := Combine(picked)
+		if err != nil {
+			t.Fatalf("Combine(%v): %v", subset, err)
+		}
+		if !bytes.Equal(got, secret) {
+			t.Errorf("Combine(%v) = %q, want %q", subset, got, secret)
+		}
+	}
+
+	if _, err := Combine(shares[:2]); err == nil {
+		t.Error("Combine() with fewer shares than the threshold should fail")
+	}
+	if _, err := Combine([]Share{shares[0], shares[0], shares[1]}); err == nil {
+		t.Error("Combine() with a repeated share should fail")
+	}
+
+	other, err := Split(secret, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Combine([]Share{shares[0], shares[1], other[2]}); err == nil {
+		t.Error("Combine() with shares of different splits should fail")
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		secret       []byte
+		n, threshold int
+	}{
+		{"empty secret", nil, 3, 2},
+		{"threshold of one", []byte("s"), 3, 1},
+		{"fewer shares than threshold", []byte("s"), 2, 3},
+		{"too many shares", []byte("s"), MaxShares + 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Split(tt.secret, tt.n, tt.threshold); err == nil {
+				t.Error("Split() should fail")
+			}
+		})
+	}
+}
+
+func TestParseShare(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range shares {
+		got, err := ParseShare(s.String() + "\n")
+		if err != nil {
+			t.Fatalf("ParseShare(%q): %v", s.String(), err)
+		}
+		if got.SetID != s.SetID || got.Threshold != s.Threshold || got.Index != s.Index || !bytes.Equal(got.Value, s.Value) {
+			t.Errorf("ParseShare(%q) = %+v, want %+v", s.String(), got, s)
+		}
+	}
+
+	for _, in := range []string{
+		"",
+		"password",
+		"cosign-share-v1:0011223344556677:2:1",
+		"cosign-share-v2:0011223344556677:2:1:AAAA",
+		"cosign-share-v1:00112233:2:1:AAAA",
+		"cosign-share-v1:0011223344556677:1:1:AAAA",
+		"cosign-share-v1:0011223344556677:2:0:AAAA",
+		"cosign-share-v1:0011223344556677:2:256:AAAA",
+		"cosign-share-v1:0011223344556677:2:1:",
+		"cosign-share-v1:0011223344556677:2:1:!!",
+	} {
+		if _, err := ParseShare(in); err == nil {
+			t.Errorf("ParseShare(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sshagent implements signing with keys held by an ssh-agent.
+//
+// A key reference of the form ssh-agent://<key> selects the agent key whose
+// SHA256 fingerprint (as shown by ssh-add -l) or comment is <key>. The
+// reference ssh-agent:// on its own selects the agent's only key. The agent
+// is reached through $SSH_AUTH_SOCK.
+//
+// ECDSA P-256, RSA and Ed25519 agent keys can be used. The agent hashes the
+// message itself, so it has to be given the whole message rather than a
+// precomputed digest. Ed25519 keys produce pure Ed25519 signatures, not the
+// Ed25519ph signatures cosign records in the transparency log, so they can
+// only be used without uploading to it.
+package sshagent
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign/env"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// ReferenceScheme is the key reference prefix handled by this package.
+const ReferenceScheme = "ssh-agent://"
+
+// keyTypes are the agent key types that produce signatures cosign can verify.
+var keyTypes = []string{ssh.KeyAlgoECDSA256, ssh.KeyAlgoRSA, ssh.KeyAlgoED25519}
+
+// Signer is a signature.SignerVerifier backed by a key held by an ssh-agent.
+type Signer struct {
+	agent  agent.ExtendedAgent
+	closer io.Closer
+	key    ssh.PublicKey
+
+	verifier signature.Verifier
+}
+
+var _ signature.SignerVerifier = (*Signer)(nil)
+
+// IsReference returns true if keyRef refers to an ssh-agent key.
+func IsReference(keyRef string) bool {
+	return strings.HasPrefix(keyRef, ReferenceScheme)
+}
+
+// NewSigner connects to the ssh-agent at $SSH_AUTH_SOCK and selects the key
+// keyRef refers to.
+func NewSigner(keyRef string) (*Signer, error) {
+	sock := env.Getenv(env.VariableSSHAuthSock)
+	if sock == "" {
+		return nil, fmt.Errorf("%s is not set, is ssh-agent running?", env.VariableSSHAuthSock)
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to ssh-agent: %w", err)
+	}
+	s, err := newSigner(agent.NewClient(conn), keyRef)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.closer = conn
+	return s, nil
+}
+
+// NewVerifier returns a verifier for the public key of the agent key keyRef
+// refers to. The connection to the agent is closed before it returns, as
+// verification does not need the agent.
+func NewVerifier(keyRef string) (signature.Verifier, error) {
+	s, err := NewSigner(keyRef)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return s.verifier, nil
+}
+
+func newSigner(a agent.ExtendedAgent, keyRef string) (*Signer, error) {
+	if !IsReference(keyRef) {
+		return nil, fmt.Errorf("not an ssh-agent reference: %q", keyRef)
+	}
+	selector := strings.TrimPrefix(keyRef, ReferenceScheme)
+
+	keys, err := a.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing ssh-agent keys: %w", err)
+	}
+	var matches []ssh.PublicKey
+	for _, k := range keys {
+		if !slices.Contains(keyTypes, k.Type()) {
+			continue
+		}
+		if selector == "" || selector == ssh.FingerprintSHA256(k) || selector == k.Comment {
+			pub, err := ssh.ParsePublicKey(k.Blob)
+			if err != nil {
+				return nil, fmt.Errorf("parsing ssh-agent key %s: %w", ssh.FingerprintSHA256(k), err)
+			}
+			matches = append(matches, pub)
+		}
+	}
+	switch {
+	case len(matches) == 0 && selector == "":
+		return nil, fmt.Errorf("ssh-agent holds no %s keys", strings.Join(keyTypes, ", "))
+	case len(matches) == 0:
+		return nil, fmt.Errorf("ssh-agent holds no %s key matching %q", strings.Join(keyTypes, ", "), selector)
+	case len(matches) > 1:
+		return nil, fmt.Errorf("%d ssh-agent keys match, select one with %s<fingerprint>", len(matches), ReferenceScheme)
+	}
+
+	pub := matches[0].(ssh.CryptoPublicKey).CryptoPublicKey()
+	verifier, err := signature.LoadVerifier(pub, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("loading ssh-agent key verifier: %w", err)
+	}
+	return &Signer{agent: a, key: matches[0], verifier: verifier}, nil
+}
+
+// PublicKey returns the public key of the agent key.
+func (s *Signer) PublicKey(opts ...signature.PublicKeyOption) (crypto.PublicKey, error) {
+	return s.verifier.PublicKey(opts...)
+}
+
+// SignsDigest returns false: the agent has to be given the message itself.
+func (s *Signer) SignsDigest() bool {
+	return false
+}
+
+// SignMessage asks the agent to sign the message, and converts the SSH
+// signature to the encoding cosign uses for the key type. ECDSA and RSA
+// signatures are made over the SHA-256 digest of the message, Ed25519
+// signatures over the message itself. The agent hashes the message itself,
+// so a digest passed with options.WithDigest must be the SHA-256 digest of
+// the message, and cannot be passed for Ed25519 keys.
+func (s *Signer) SignMessage(message io.Reader, opts ...signature.SignOption) ([]byte, error) {
+	var digest []byte
+	for _, o := range opts {
+		o.ApplyDigest(&digest)
+	}
+	msg, err := io.ReadAll(message)
+	if err != nil {
+		return nil, fmt.Errorf("reading message: %w", err)
+	}
+	if digest != nil && s.key.Type() == ssh.KeyAlgoED25519 {
+		return nil, errors.New("ssh-agent Ed25519 keys only produce pure Ed25519 signatures over the message itself")
+	}
+	if sum := sha256.Sum256(msg); digest != nil && !bytes.Equal(digest, sum[:]) {
+		return nil, errors.New("ssh-agent keys cannot sign a precomputed digest")
+	}
+
+	var flags agent.SignatureFlags
+	if s.key.Type() == ssh.KeyAlgoRSA {
+		flags = agent.SignatureFlagRsaSha256
+	}
+	sshSig, err := s.agent.SignWithFlags(s.key, msg, flags)
+	if err != nil {
+		return nil, fmt.Errorf("signing with ssh-agent: %w", err)
+	}
+
+	var sig []byte
+	switch sshSig.Format {
+	case ssh.KeyAlgoRSASHA256, ssh.KeyAlgoED25519:
+		sig = sshSig.Blob
+	case ssh.KeyAlgoECDSA256:
+		var rs struct {
+			R *big.Int
+			S *big.Int
+		}
+		if err := ssh.Unmarshal(sshSig.Blob, &rs); err != nil {
+			return nil, fmt.Errorf("decoding ssh-agent signature: %w", err)
+		}
+		if sig, err = asn1.Marshal(rs); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unexpected ssh-agent signature format %q", sshSig.Format)
+	}
+
+	if err := s.verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)); err != nil {
+		return nil, fmt.Errorf("ssh-agent returned an invalid signature: %w", err)
+	}
+	return sig, nil
+}
+
+// VerifySignature verifies the signature locally using the agent key.
+func (s *Signer) VerifySignature(sig, message io.Reader, opts ...signature.VerifyOption) error {
+	return s.verifier.VerifySignature(sig, message, opts...)
+}
+
+// Close closes the connection to the agent.
+func (s *Signer) Close() {
+	if s.closer != nil {
+		s.closer.Close()
+	}
+}
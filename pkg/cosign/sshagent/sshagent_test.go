@@ -0,0 +1,225 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshagent
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func testAgent(t *testing.T, keys map[string]crypto.Signer) agent.ExtendedAgent {
+	t.Helper()
+	a := agent.NewKeyring().(agent.ExtendedAgent)
+	for comment, k := range keys {
+		if err := a.Add(agent.AddedKey{PrivateKey: k, Comment: comment}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return a
+}
+
+func fingerprint(t *testing.T, pub crypto.PublicKey) string {
+	t.Helper()
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssh.FingerprintSHA256(sshPub)
+}
+
+func TestNewSignerSelection(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := testAgent(t, map[string]crypto.Signer{"ec@host": ecKey, "rsa@host": rsaKey, "ed@host": edKey})
+
+	tests := []struct {
+		name    string
+		keyRef  string
+		want    crypto.PublicKey
+		wantErr string
+	}{
+		{"fingerprint", ReferenceScheme + fingerprint(t, ecKey.Public()), ecKey.Public(), ""},
+		{"comment", ReferenceScheme + "rsa@host", rsaKey.Public(), ""},
+		{"ambiguous", ReferenceScheme, nil, "3 ssh-agent keys match"},
+		{"ed25519", ReferenceScheme + "ed@host", edKey.Public(), ""},
+		{"unknown", ReferenceScheme + "nobody", nil, "no ecdsa-sha2-nistp256, ssh-rsa, ssh-ed25519 key matching"},
+		{"not a reference", "cosign.key", nil, "not an ssh-agent reference"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSigner(a, tt.keyRef)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("newSigner() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newSigner() error = %v", err)
+			}
+			pub, err := s.PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !pub.(interface{ Equal(crypto.PublicKey) bool }).Equal(tt.want) {
+				t.Error("newSigner() selected the wrong key")
+			}
+		})
+	}
+}
+
+func TestSignMessage(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("payload")
+	digest := sha256.Sum256(msg)
+	for name, key := range map[string]crypto.Signer{"ecdsa": ecKey, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			s, err := newSigner(testAgent(t, map[string]crypto.Signer{name: key}), ReferenceScheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := s.SignMessage(bytes.NewReader(msg), options.WithDigest(digest[:]))
+			if err != nil {
+				t.Fatalf("SignMessage() error = %v", err)
+			}
+
+			// The signature must verify with an ordinary verifier for the key.
+			v, err := signature.LoadVerifier(key.Public(), crypto.SHA256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)); err != nil {
+				t.Errorf("VerifySignature() error = %v", err)
+			}
+
+			other := sha256.Sum256([]byte("other"))
+			if _, err := s.SignMessage(bytes.NewReader(msg), options.WithDigest(other[:])); err == nil {
+				t.Error("SignMessage() signed a digest of a different message")
+			}
+		})
+	}
+
+	t.Run("ed25519", func(t *testing.T) {
+		pub, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := newSigner(testAgent(t, map[string]crypto.Signer{"ed25519": edKey}), ReferenceScheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := s.SignMessage(bytes.NewReader(msg))
+		if err != nil {
+			t.Fatalf("SignMessage() error = %v", err)
+		}
+		if !ed25519.Verify(pub, msg, sig) {
+			t.Error("SignMessage() returned an invalid pure Ed25519 signature")
+		}
+		sum := sha512.Sum512(msg)
+		if _, err := s.SignMessage(bytes.NewReader(msg), options.WithDigest(sum[:])); err == nil {
+			t.Error("SignMessage() signed an Ed25519ph digest")
+		}
+	})
+}
+
+func TestNewVerifier(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Unix socket paths are limited to about 100 bytes, which t.TempDir()
+	// can exceed.
+	dir, err := os.MkdirTemp("", "ssh-agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	sock := filepath.Join(dir, "agent.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	closed := make(chan struct{})
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		_ = agent.ServeAgent(testAgent(t, map[string]crypto.Signer{"ecdsa": ecKey}), conn)
+		conn.Close()
+		close(closed)
+	}()
+	t.Setenv("SSH_AUTH_SOCK", sock)
+
+	v, err := NewVerifier(ReferenceScheme)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	if _, ok := v.(*Signer); ok {
+		t.Error("NewVerifier() returned a Signer holding the agent connection")
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("NewVerifier() left the agent connection open")
+	}
+
+	msg := []byte("payload")
+	digest := sha256.Sum256(msg)
+	sig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+}
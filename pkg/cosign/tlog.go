@@ -271,25 +271,53 @@ func proposedEntries(b64Sig string, payload, pubKey []byte) ([]models.ProposedEn
 		if _, err := sha256CheckSum.Write(payload); err != nil {
 			return nil, err
 		}
-		re := rekorEntry(sha256CheckSum, signature, pubKey)
-		entry := &models.Hashedrekord{
-			APIVersion: conv.Pointer(re.APIVersion()),
-			Spec:       re.HashedRekordObj,
-		}
-		proposedEntry = []models.ProposedEntry{entry}
+		proposedEntry = []models.ProposedEntry{hashedRekordEntry(sha256CheckSum, signature, pubKey)}
 	}
 	return proposedEntry, nil
 }
 
+func hashedRekordEntry(checksum NamedHash, signature, pubKey []byte) *models.Hashedrekord {
+	re := rekorEntry(checksum, signature, pubKey)
+	return &models.Hashedrekord{
+		APIVersion: conv.Pointer(re.APIVersion()),
+		Spec:       re.HashedRekordObj,
+	}
+}
+
+// digestNamedHash is a NamedHash whose sum is a digest computed elsewhere.
+type digestNamedHash struct {
+	NamedHash
+	digest []byte
+}
+
+func (h digestNamedHash) Sum(b []byte) []byte {
+	return append(b, h.digest...)
+}
+
 func FindTlogEntry(ctx context.Context, rekorClient *client.Rekor,
 	b64Sig string, payload, pubKey []byte) ([]models.LogEntryAnon, error) {
-	searchParams := entries.NewSearchLogQueryParamsWithContext(ctx)
-	searchLogQuery := models.SearchLogQuery{}
 	proposedEntries, err := proposedEntries(b64Sig, payload, pubKey)
 	if err != nil {
 		return nil, err
 	}
+	return searchTlog(ctx, rekorClient, proposedEntries)
+}
 
+// findHashedRekordEntry searches the transparency log for hashedrekord
+// entries of the signature over a payload with the given SHA-256 digest.
+func findHashedRekordEntry(ctx context.Context, rekorClient *client.Rekor,
+	b64Sig string, sha256Digest, pubKey []byte) ([]models.LogEntryAnon, error) {
+	signature, err := base64.StdEncoding.DecodeString(b64Sig)
+	if err != nil {
+		return nil, fmt.Errorf("decoding base64 signature: %w", err)
+	}
+	checksum := digestNamedHash{NamedHash: NewCryptoNamedHash(crypto.SHA256), digest: sha256Digest}
+	return searchTlog(ctx, rekorClient, []models.ProposedEntry{hashedRekordEntry(checksum, signature, pubKey)})
+}
+
+func searchTlog(ctx context.Context, rekorClient *client.Rekor, proposedEntries []models.ProposedEntry) ([]models.LogEntryAnon, error) {
+	searchParams := entries.NewSearchLogQueryParamsWithContext(ctx)
+	searchLogQuery := models.SearchLogQuery{}
 	searchLogQuery.SetEntries(proposedEntries)
 
 	searchParams.SetEntry(&searchLogQuery)
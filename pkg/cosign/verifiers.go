@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/secure-systems-lab/go-securesystemslib/dsse"
@@ -80,12 +81,10 @@ func IntotoSubjectClaimVerifier(sig oci.Signature, imageDigest v1.Hash, annotati
 		if subj == nil {
 			return errors.New("invalid in-toto statement: null subject entry")
 		}
-		dgst, ok := subj.Digest["sha256"]
-		if !ok {
-			continue
-		}
-		subjDigest := "sha256:" + dgst
-		if subjDigest != imageDigest.String() {
+		// Statements may have several subjects, e.g. all files of a release;
+		// any of them may match.
+		dgst, ok := subj.Digest[imageDigest.Algorithm]
+		if !ok || !strings.EqualFold(dgst, imageDigest.Hex) {
 			continue
 		}
 		if !correctAnnotations(annotations, subj.Annotations.AsMap()) {
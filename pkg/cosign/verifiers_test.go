@@ -15,6 +15,9 @@
 package cosign
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
 	"testing"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -100,3 +103,28 @@ func Test_IntotoSubjectClaimVerifier(t *testing.T) {
 		}
 	}
 }
+
+func Test_IntotoSubjectClaimVerifierMultipleSubjects(t *testing.T) {
+	sha256Hex := strings.Repeat("a", 64)
+	sha512Hex := strings.Repeat("b", 128)
+	statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://example.com/release/v1",`+
+		`"subject":[{"name":"app.tar.gz","digest":{"sha256":%q}},{"name":"app.zip","digest":{"sha512":%q}}],"predicate":{}}`, sha256Hex, sha512Hex)
+	payload := fmt.Sprintf(`{"payloadType":"application/vnd.in-toto+json","payload":%q,"signatures":[]}`, base64.StdEncoding.EncodeToString([]byte(statement)))
+	ociSig, err := static.NewSignature([]byte(payload), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		digest     v1.Hash
+		shouldFail bool
+	}{
+		{v1.Hash{Algorithm: "sha256", Hex: sha256Hex}, false},
+		{v1.Hash{Algorithm: "sha512", Hex: sha512Hex}, false},
+		{v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("c", 64)}, true},
+		{v1.Hash{Algorithm: "sha512", Hex: sha256Hex}, true},
+	} {
+		if err := IntotoSubjectClaimVerifier(ociSig, tc.digest, nil); (err != nil) != tc.shouldFail {
+			t.Errorf("IntotoSubjectClaimVerifier(%s) = %v", tc.digest, err)
+		}
+	}
+}
@@ -116,6 +116,18 @@ type CheckOpts struct {
 	SigVerifier signature.Verifier
 	// PKOpts are the options provided to `SigVerifier.PublicKey()`.
 	PKOpts []signature.PublicKeyOption
+	// KeySet, if set, verifies signatures with whichever of its keys made
+	// them, accepting a signature only if its transparency log or signed
+	// timestamp time falls inside that key's validity window. It is
+	// exclusive with SigVerifier.
+	KeySet *KeySet
+	// RevocationList, if set, rejects signatures whose key, certificate or
+	// identity was revoked at the signature's trusted time.
+	RevocationList *RevocationList
+	// HardwareAttestationRoots, if set, requires signatures to carry a
+	// hardware attestation chain that verifies to one of these roots and
+	// attests the signing key.
+	HardwareAttestationRoots *x509.CertPool
 
 	// RootCerts are the root CA certs used to verify a signature's chained certificate.
 	RootCerts *x509.CertPool
@@ -178,6 +190,12 @@ type CheckOpts struct {
 	// NewBundleFormat enables the new bundle format (Cosign Bundle Spec) and the new verifier.
 	NewBundleFormat bool
 
+	// MergeAttestationFormats makes attestation verification with
+	// NewBundleFormat also verify the attestations of the legacy .att tag,
+	// returning the attestations of both stores with identical statements
+	// deduplicated.
+	MergeAttestationFormats bool
+
 	// AllowCertificateChain permits bundles with version >= v0.3 to contain
 	// X.509 certificate chains in the verification material.
 	AllowCertificateChain bool
@@ -206,7 +224,7 @@ func (v *verifyTrustedMaterial) PublicKeyVerifier(hint string) (root.TimeConstra
 
 // verificationOptions returns the verification options for verifying with sigstore-go.
 func (co *CheckOpts) verificationOptions() (trustedMaterial root.TrustedMaterial, verifierOptions []verify.VerifierOption, policyOptions []verify.PolicyOption, err error) {
-	if co.TrustedMaterial == nil && co.SigVerifier == nil {
+	if co.TrustedMaterial == nil && co.SigVerifier == nil && co.KeySet == nil {
 		return nil, nil, nil, fmt.Errorf("a trusted root is required for identity-based verification")
 	}
 
@@ -255,6 +273,17 @@ func (co *CheckOpts) verificationOptions() (trustedMaterial root.TrustedMaterial
 		vTrustedMaterial.keyTrustedMaterial = root.NewTrustedPublicKeyMaterial(func(_ string) (root.TimeConstrainedVerifier, error) {
 			return newExpiringKey, nil
 		})
+	} else if co.KeySet != nil {
+		// We are verifying with a set of keys, each valid for a window of
+		// time checked against the observer timestamps below.
+		if co.IgnoreTlog && !co.UseSignedTimestamps {
+			return nil, nil, nil, fmt.Errorf("a transparency log entry or signed timestamp is required to verify with a key set")
+		}
+		policyOptions = append(policyOptions, verify.WithKey())
+		vTrustedMaterial.keyTrustedMaterial, err = co.KeySet.trustedMaterial()
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	} else { //nolint:gocritic
 		// We are verifying with a certificate
 		if !co.IgnoreSCT {
@@ -600,11 +629,16 @@ func tlogValidateEntry(ctx context.Context, client *client.Rekor, rekorPubKeys *
 	if err != nil {
 		return nil, err
 	}
-	payload, err := sig.Payload()
-	if err != nil {
-		return nil, err
+	var tlogEntries []models.LogEntryAnon
+	if ds, ok := sig.(*digestedSignature); ok {
+		tlogEntries, err = findHashedRekordEntry(ctx, client, b64sig, ds.digests[crypto.SHA256], pem)
+	} else {
+		var payload []byte
+		if payload, err = sig.Payload(); err != nil {
+			return nil, err
+		}
+		tlogEntries, err = FindTlogEntry(ctx, client, b64sig, payload, pem)
 	}
-	tlogEntries, err := FindTlogEntry(ctx, client, b64sig, payload, pem)
 	if err != nil {
 		return nil, err
 	}
@@ -661,7 +695,7 @@ func VerifyImageSignatures(ctx context.Context, signedImgRef name.Reference, co
 	}
 
 	// Enforce this up front.
-	if co.RootCerts == nil && co.SigVerifier == nil && co.TrustedMaterial == nil {
+	if co.RootCerts == nil && co.SigVerifier == nil && co.KeySet == nil && co.TrustedMaterial == nil {
 		return nil, false, errors.New("one of verifier, root certs, or trusted root is required")
 	}
 
@@ -706,7 +740,7 @@ func VerifyImageSignatures(ctx context.Context, signedImgRef name.Reference, co
 // If there were no valid signatures, we return an error.
 func VerifyLocalImageSignatures(ctx context.Context, path string, co *CheckOpts) (checkedSignatures []oci.Signature, bundleVerified bool, err error) {
 	// Enforce this up front.
-	if co.RootCerts == nil && co.SigVerifier == nil && co.TrustedMaterial == nil {
+	if co.RootCerts == nil && co.SigVerifier == nil && co.KeySet == nil && co.TrustedMaterial == nil {
 		return nil, false, errors.New("one of verifier, root certs, or trusted root is required")
 	}
 
@@ -826,9 +860,20 @@ func verifySignatures(ctx context.Context, sigs oci.Signatures, h v1.Hash, co *C
 //     b. If we don't have a Rekor entry retrieved via cert, do an online lookup (assuming
 //     we are in experimental mode).
 //  3. If a certificate is provided, check its expiration using the transparency log timestamp.
+//  4. If a revocation list is provided, check the key or certificate was not revoked at that time.
 func verifyInternal(ctx context.Context, sig oci.Signature, h v1.Hash,
 	verifyFn signatureVerificationFn, co *CheckOpts) (
 	bundleVerified bool, err error) {
+	if mkv, ok := co.SigVerifier.(*MultiKeyVerifier); ok {
+		verifier, err := mkv.verifierFor(ctx, sig, verifyFn)
+		if err != nil {
+			return false, err
+		}
+		narrowed := *co
+		narrowed.SigVerifier = verifier
+		co = &narrowed
+	}
+
 	var acceptableRFC3161Time, acceptableRekorBundleTime *time.Time // Timestamps for the signature we accept, or nil if not applicable.
 
 	var acceptableRFC3161Timestamp *timestamp.Timestamp
@@ -867,12 +912,17 @@ func verifyInternal(ctx context.Context, sig oci.Signature, h v1.Hash,
 				return false, fmt.Errorf("rekor client not provided for online verification")
 			}
 
-			pemBytes, err := keyBytes(sig, co)
+			candidates, err := candidateKeyBytes(sig, co)
 			if err != nil {
 				return false, err
 			}
 
-			e, err := tlogValidateEntry(ctx, co.RekorClient, co.RekorPubKeys, co.TrustedMaterial, sig, pemBytes)
+			var e *models.LogEntryAnon
+			for _, pemBytes := range candidates {
+				if e, err = tlogValidateEntry(ctx, co.RekorClient, co.RekorPubKeys, co.TrustedMaterial, sig, pemBytes); err == nil {
+					break
+				}
+			}
 			if err != nil {
 				return false, err
 			}
@@ -882,9 +932,21 @@ func verifyInternal(ctx context.Context, sig oci.Signature, h v1.Hash,
 		}
 	}
 
+	var observedTimes []time.Time
+	for _, t := range []*time.Time{acceptableRFC3161Time, acceptableRekorBundleTime} {
+		if t != nil {
+			observedTimes = append(observedTimes, *t)
+		}
+	}
+
 	verifier := co.SigVerifier
 	var verifierChain []*x509.Certificate
-	if verifier == nil {
+	if verifier == nil && co.KeySet != nil {
+		verifier, err = co.KeySet.verify(ctx, sig, verifyFn, observedTimes)
+		if err != nil {
+			return false, err
+		}
+	} else if verifier == nil {
 		// If we don't have a public key to check against, we can try a root cert.
 		cert, err := sig.Cert()
 		if err != nil {
@@ -973,9 +1035,56 @@ func verifyInternal(ctx context.Context, sig oci.Signature, h v1.Hash,
 		}
 	}
 
+	// 3. check that the key or certificate was not revoked when the signature was made
+	if co.RevocationList != nil {
+		var keyID string
+		if cert == nil {
+			pub, err := verifier.PublicKey(co.PKOpts...)
+			if err != nil {
+				return false, err
+			}
+			if keyID, err = PublicKeyID(pub); err != nil {
+				return false, err
+			}
+		}
+		if err := co.RevocationList.Check(cert, keyID, observedTimes); err != nil {
+			return false, err
+		}
+	}
+
+	// 4. check that the signing key was generated on a hardware token
+	if co.HardwareAttestationRoots != nil {
+		pub, err := verifier.PublicKey(co.PKOpts...)
+		if err != nil {
+			return false, err
+		}
+		if err := checkHardwareAttestation(co, sig, pub); err != nil {
+			return false, err
+		}
+	}
+
 	return bundleVerified, nil
 }
 
+// candidateKeyBytes returns the PEM encoded keys that may have made sig,
+// used to look up its transparency log entry.
+func candidateKeyBytes(sig oci.Signature, co *CheckOpts) ([][]byte, error) {
+	if co.KeySet != nil && co.SigVerifier == nil {
+		cert, err := sig.Cert()
+		if err != nil {
+			return nil, err
+		}
+		if cert == nil {
+			return co.KeySet.publicKeysPEM(), nil
+		}
+	}
+	pemBytes, err := keyBytes(sig, co)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{pemBytes}, nil
+}
+
 func keyBytes(sig oci.Signature, co *CheckOpts) ([]byte, error) {
 	cert, err := sig.Cert()
 	if err != nil {
@@ -1085,13 +1194,26 @@ func loadSignatureFromFile(ctx context.Context, sigRef string, signedImgRef name
 // If there were no valid attestations, we return an error.
 func VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *CheckOpts, nameOpts ...name.Option) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
 	// Enforce this up front.
-	if co.RootCerts == nil && co.SigVerifier == nil && co.TrustedMaterial == nil {
+	if co.RootCerts == nil && co.SigVerifier == nil && co.KeySet == nil && co.TrustedMaterial == nil {
 		return nil, false, errors.New("one of verifier, root certs, or TrustedMaterial is required")
 	}
+	if co.NewBundleFormat && co.MergeAttestationFormats {
+		return mergeAttestationFormats(
+			func() ([]oci.Signature, bool, error) {
+				return verifyImageAttestationsSigstoreBundle(ctx, signedImgRef, co, nameOpts...)
+			},
+			func() ([]oci.Signature, bool, error) {
+				return verifyImageAttestationsLegacy(ctx, signedImgRef, co)
+			})
+	}
 	if co.NewBundleFormat {
 		return verifyImageAttestationsSigstoreBundle(ctx, signedImgRef, co, nameOpts...)
 	}
+	return verifyImageAttestationsLegacy(ctx, signedImgRef, co)
+}
 
+// verifyImageAttestationsLegacy verifies the attestations of the .att tag.
+func verifyImageAttestationsLegacy(ctx context.Context, signedImgRef name.Reference, co *CheckOpts) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
 	// This is a carefully optimized sequence for fetching the attestations of
 	// the entity that minimizes registry requests when supplied with a digest
 	// input.
@@ -1120,15 +1242,29 @@ func VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, c
 // If there were no valid signatures, we return an error.
 func VerifyLocalImageAttestations(ctx context.Context, path string, co *CheckOpts) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
 	// Enforce this up front.
-	if co.RootCerts == nil && co.SigVerifier == nil && co.TrustedMaterial == nil {
+	if co.RootCerts == nil && co.SigVerifier == nil && co.KeySet == nil && co.TrustedMaterial == nil {
 		return nil, false, errors.New("one of verifier, root certs, or trusted root is required")
 	}
 
 	// Check for v3 bundles first (if NewBundleFormat is enabled)
+	if co.NewBundleFormat && co.MergeAttestationFormats {
+		return mergeAttestationFormats(
+			func() ([]oci.Signature, bool, error) {
+				return verifyLocalImageAttestationsSigstoreBundle(ctx, path, co)
+			},
+			func() ([]oci.Signature, bool, error) {
+				return verifyLocalImageAttestationsLegacy(ctx, path, co)
+			})
+	}
 	if co.NewBundleFormat {
 		return verifyLocalImageAttestationsSigstoreBundle(ctx, path, co)
 	}
+	return verifyLocalImageAttestationsLegacy(ctx, path, co)
+}
 
+// verifyLocalImageAttestationsLegacy verifies the attestations of a saved
+// image stored in the legacy layout.
+func verifyLocalImageAttestationsLegacy(ctx context.Context, path string, co *CheckOpts) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
 	se, err := layout.SignedImageIndex(path)
 	if err != nil {
 		return nil, false, err
@@ -1166,6 +1302,87 @@ func VerifyLocalImageAttestations(ctx context.Context, path string, co *CheckOpt
 	return VerifyImageAttestation(ctx, atts, h, co)
 }
 
+// mergeAttestationFormats returns the attestations verified from the Sigstore
+// bundles and from the legacy store, bundles first, with identical statements
+// deduplicated. A store without verified attestations is skipped, but any
+// other error, such as failing to read a store, is returned so that a
+// transient failure does not verify only half of the attestations.
+func mergeAttestationFormats(bundles, legacy func() ([]oci.Signature, bool, error)) ([]oci.Signature, bool, error) {
+	bundleAtts, bundleVerified, bundleErr := bundles()
+	if bundleErr != nil && !isNoAttestationsError(bundleErr) {
+		return nil, false, fmt.Errorf("verifying bundle attestations: %w", bundleErr)
+	}
+	legacyAtts, legacyVerified, legacyErr := legacy()
+	if legacyErr != nil && !isNoAttestationsError(legacyErr) {
+		return nil, false, fmt.Errorf("verifying legacy attestations: %w", legacyErr)
+	}
+	if bundleErr != nil && legacyErr != nil {
+		return nil, false, &ErrNoMatchingAttestations{
+			fmt.Errorf("no matching attestations: bundles: %w; legacy attestations: %w", bundleErr, legacyErr),
+		}
+	}
+	merged, err := DedupeAttestations(append(bundleAtts, legacyAtts...))
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, bundleVerified || legacyVerified, nil
+}
+
+// isNoAttestationsError reports whether err means that a store holds no
+// attestation that verified, rather than that it could not be checked.
+func isNoAttestationsError(err error) bool {
+	var noMatch *ErrNoMatchingAttestations
+	var noSigs *ErrNoSignaturesFound
+	return errors.As(err, &noMatch) || errors.As(err, &noSigs)
+}
+
+// DedupeAttestations drops the attestations whose in-toto statement is
+// identical, ignoring formatting, to that of an earlier one.
+func DedupeAttestations(atts []oci.Signature) ([]oci.Signature, error) {
+	seen := map[string]bool{}
+	var deduped []oci.Signature
+	for _, att := range atts {
+		key, err := statementKey(att)
+		if err != nil {
+			return nil, err
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, att)
+	}
+	return deduped, nil
+}
+
+// statementKey returns the canonical JSON encoding of the statement of an
+// attestation.
+func statementKey(att oci.Signature) (string, error) {
+	p, err := att.Payload()
+	if err != nil {
+		return "", err
+	}
+	var env ssldsse.Envelope
+	if err := json.Unmarshal(p, &env); err != nil {
+		return "", fmt.Errorf("decoding attestation envelope: %w", err)
+	}
+	statement, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return "", fmt.Errorf("decoding attestation payload: %w", err)
+	}
+	var v any
+	if err := json.Unmarshal(statement, &v); err != nil {
+		// Not JSON; compare the bytes.
+		return string(statement), nil
+	}
+	// Maps are encoded with sorted keys.
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(canonical), nil
+}
+
 func VerifyBlobAttestation(ctx context.Context, att oci.Signature, h v1.Hash, co *CheckOpts) (
 	bool, error) {
 	return verifyInternal(ctx, att, h, verifyOCIAttestation, co)
@@ -1308,7 +1525,7 @@ func VerifyBundle(sig oci.Signature, co *CheckOpts) (bool, error) {
 		return false, err
 	}
 
-	payload, err := sig.Payload()
+	payloadDigest, err := payloadSHA256(sig)
 	if err != nil {
 		return false, fmt.Errorf("reading payload: %w", err)
 	}
@@ -1321,8 +1538,7 @@ func VerifyBundle(sig oci.Signature, co *CheckOpts) (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("computing bundle hash: %w", err)
 	}
-	h := sha256.Sum256(payload)
-	payloadHash := hex.EncodeToString(h[:])
+	payloadHash := hex.EncodeToString(payloadDigest)
 
 	if alg != "sha256" {
 		return false, fmt.Errorf("unexpected algorithm: %q", alg)
@@ -1498,8 +1714,10 @@ func compareSigs(bundleBody string, sig oci.Signature) error {
 	return nil
 }
 
+// comparePublicKey checks that the bundle logged the key that is expected
+// to have made sig, or, when verifying with a key set, one of its keys.
 func comparePublicKey(bundleBody string, sig oci.Signature, co *CheckOpts) error {
-	pemBytes, err := keyBytes(sig, co)
+	candidates, err := candidateKeyBytes(sig, co)
 	if err != nil {
 		return err
 	}
@@ -1513,23 +1731,24 @@ func comparePublicKey(bundleBody string, sig oci.Signature, co *CheckOpts) error
 	if err != nil {
 		return fmt.Errorf("decoding base64 string %s", bundleKey)
 	}
-
-	// Compare the PEM bytes, to ignore spurious newlines in the public key bytes.
-	pemFirst, rest := pem.Decode(pemBytes)
-	if len(rest) > 0 {
-		return fmt.Errorf("unexpected PEM block: %s", rest)
-	}
 	pemSecond, rest := pem.Decode(decodeSecond)
 	if len(rest) > 0 {
 		return fmt.Errorf("unexpected PEM block: %s", rest)
 	}
 
-	if !bytes.Equal(pemFirst.Bytes, pemSecond.Bytes) {
-		return fmt.Errorf("comparing public key PEMs, expected %s, got %s",
-			pemBytes, decodeSecond)
+	// Compare the PEM bytes, to ignore spurious newlines in the public key bytes.
+	for _, pemBytes := range candidates {
+		pemFirst, rest := pem.Decode(pemBytes)
+		if len(rest) > 0 {
+			return fmt.Errorf("unexpected PEM block: %s", rest)
+		}
+		if pemFirst != nil && pemSecond != nil && bytes.Equal(pemFirst.Bytes, pemSecond.Bytes) {
+			return nil
+		}
 	}
 
-	return nil
+	return fmt.Errorf("comparing public key PEMs, expected %s, got %s",
+		bytes.Join(candidates, nil), decodeSecond)
 }
 
 func extractEntryImpl(bundleBody string) (rekor_types.EntryImpl, error) {
@@ -1670,7 +1889,7 @@ func correctAnnotations(wanted, have map[string]interface{}) bool {
 // If there were no valid signatures, we return an error, using OCI 1.1+ behavior.
 func verifyImageSignaturesExperimentalOCI(ctx context.Context, signedImgRef name.Reference, co *CheckOpts) (checkedSignatures []oci.Signature, bundleVerified bool, err error) {
 	// Enforce this up front.
-	if co.RootCerts == nil && co.SigVerifier == nil && co.TrustedMaterial == nil {
+	if co.RootCerts == nil && co.SigVerifier == nil && co.KeySet == nil && co.TrustedMaterial == nil {
 		return nil, false, errors.New("one of verifier, root certs, or trusted root is required")
 	}
 
@@ -1757,13 +1976,13 @@ func GetBundles(_ context.Context, signedImgRef name.Reference, registryClientOp
 		if err != nil {
 			return nil, nil, err
 		}
-		bundle, err := ociremote.Bundle(st, registryClientOpts...)
+		referrerBundles, err := ociremote.Bundles(st, registryClientOpts...)
 		if err != nil {
 			// There may be non-Sigstore referrers in the index, so we can ignore them.
 			// TODO: Should we surface any errors here (e.g. if the bundle is invalid)?
 			continue
 		}
-		bundles = append(bundles, bundle)
+		bundles = append(bundles, referrerBundles...)
 	}
 
 	if len(bundles) == 0 {
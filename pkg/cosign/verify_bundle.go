@@ -17,12 +17,26 @@ package cosign
 
 import (
 	"context"
+	"time"
 
 	"github.com/sigstore/sigstore-go/pkg/verify"
 )
 
 // VerifyNewBundle verifies a Sigstore bundle with the given parameters
-func VerifyNewBundle(_ context.Context, co *CheckOpts, artifactPolicyOption verify.ArtifactPolicyOption, bundle verify.SignedEntity) (*verify.VerificationResult, error) {
+func VerifyNewBundle(ctx context.Context, co *CheckOpts, artifactPolicyOption verify.ArtifactPolicyOption, bundle verify.SignedEntity) (*verify.VerificationResult, error) {
+	// Narrow several keys down to the key that made the signature before the
+	// artifact is read, so that it is streamed once, and the revocation and
+	// hardware attestation checks, and their errors, are for that key.
+	if mkv, ok := co.SigVerifier.(*MultiKeyVerifier); ok {
+		v, err := mkv.verifierForBundle(bundle)
+		if err != nil {
+			return nil, err
+		}
+		narrowed := *co
+		narrowed.SigVerifier = v
+		co = &narrowed
+	}
+
 	// Copy co so rekorV2Bundle's UseSignedTimestamps write stays per-call and
 	// doesn't race a *CheckOpts shared across goroutines.
 	// TODO(cody)(cosign v4): Consider changing function signature to take a
@@ -39,7 +53,51 @@ func VerifyNewBundle(_ context.Context, co *CheckOpts, artifactPolicyOption veri
 	if err != nil {
 		return nil, err
 	}
-	return verifier.Verify(bundle, verify.NewPolicy(artifactPolicyOption, policyOptions...))
+	result, err := verifier.Verify(bundle, verify.NewPolicy(artifactPolicyOption, policyOptions...))
+	if err != nil {
+		return nil, err
+	}
+	if co.RevocationList != nil {
+		if err := checkBundleRevocation(co, bundle, result); err != nil {
+			return nil, err
+		}
+	}
+	if co.HardwareAttestationRoots != nil {
+		if err := checkBundleHardwareAttestation(co, bundle); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// checkBundleRevocation checks the revocation list against the certificate or
+// key a verified bundle was signed with, at the bundle's verified timestamps.
+func checkBundleRevocation(co *CheckOpts, bundle verify.SignedEntity, result *verify.VerificationResult) error {
+	vc, err := bundle.VerificationContent()
+	if err != nil {
+		return err
+	}
+	cert := vc.Certificate()
+	var keyID string
+	switch {
+	case cert != nil:
+	case co.SigVerifier != nil:
+		pub, err := co.SigVerifier.PublicKey(co.PKOpts...)
+		if err != nil {
+			return err
+		}
+		if keyID, err = PublicKeyID(pub); err != nil {
+			return err
+		}
+	case vc.PublicKey() != nil:
+		// Key set verification looks keys up by their hint, which is the key ID.
+		keyID = vc.PublicKey().Hint()
+	}
+	times := make([]time.Time, 0, len(result.VerifiedTimestamps))
+	for _, ts := range result.VerifiedTimestamps {
+		times = append(times, ts.Timestamp)
+	}
+	return co.RevocationList.Check(cert, keyID, times)
 }
 
 // rekorV2Bundle checks if a bundle contains only Rekor v2 entries, and if so, mandates that
@@ -28,6 +28,7 @@ import (
 	"io"
 	"sync"
 	"testing"
+	"time"
 
 	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
 	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
@@ -317,19 +318,12 @@ func TestVerifyBundle(t *testing.T) {
 	}
 }
 
-func TestVerifyBundleWithSigVerifier(t *testing.T) {
-	virtualSigstore, err := ca.NewVirtualSigstore()
-	assert.NoError(t, err)
-
-	artifact := []byte("artifact")
+// messageSignatureBundle returns a bundle of sv's signature of artifact with
+// a signed timestamp from virtualSigstore.
+func messageSignatureBundle(t *testing.T, virtualSigstore *ca.VirtualSigstore, sv signature.Signer, artifact []byte) *sgbundle.Bundle {
+	t.Helper()
 	digest := sha256.Sum256(artifact)
 
-	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	assert.NoError(t, err)
-
-	sv, err := signature.LoadECDSASignerVerifier(privKey, crypto.SHA256)
-	assert.NoError(t, err)
-
 	sig, err := sv.SignMessage(bytes.NewReader(artifact))
 	assert.NoError(t, err)
 	assert.NotNil(t, sig)
@@ -361,6 +355,22 @@ func TestVerifyBundleWithSigVerifier(t *testing.T) {
 	})
 	assert.NoError(t, err)
 	assert.NotNil(t, b)
+	return b
+}
+
+func TestVerifyBundleWithSigVerifier(t *testing.T) {
+	virtualSigstore, err := ca.NewVirtualSigstore()
+	assert.NoError(t, err)
+
+	artifact := []byte("artifact")
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	sv, err := signature.LoadECDSASignerVerifier(privKey, crypto.SHA256)
+	assert.NoError(t, err)
+
+	b := messageSignatureBundle(t, virtualSigstore, sv, artifact)
 
 	for _, tc := range []struct {
 		name                 string
@@ -416,6 +426,52 @@ func TestVerifyBundleWithSigVerifier(t *testing.T) {
 	}
 }
 
+func TestVerifyNewBundleMultiKey(t *testing.T) {
+	virtualSigstore, err := ca.NewVirtualSigstore()
+	assert.NoError(t, err)
+
+	artifact := []byte("artifact")
+	var verifiers []signature.Verifier
+	for range 3 {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		sv, err := signature.LoadECDSASignerVerifier(privKey, crypto.SHA256)
+		assert.NoError(t, err)
+		verifiers = append(verifiers, sv)
+	}
+	signer := verifiers[1].(signature.Signer)
+	b := messageSignatureBundle(t, virtualSigstore, signer, artifact)
+	pub, err := signer.PublicKey()
+	assert.NoError(t, err)
+	keyID, err := PublicKeyID(pub)
+	assert.NoError(t, err)
+
+	checkOpts := func(verifiers ...signature.Verifier) *CheckOpts {
+		mv, err := NewMultiKeyVerifier(verifiers...)
+		assert.NoError(t, err)
+		return &CheckOpts{
+			UseSignedTimestamps: true,
+			IgnoreTlog:          true,
+			TrustedMaterial:     virtualSigstore,
+			SigVerifier:         mv,
+		}
+	}
+
+	// The artifact is streamed to the key that made the signature alone.
+	_, err = VerifyNewBundle(context.Background(), checkOpts(verifiers...), verify.WithArtifact(bytes.NewReader(artifact)), b)
+	assert.NoError(t, err)
+
+	// The error is the one of the key that made the signature, not of the
+	// last key tried.
+	co := checkOpts(verifiers...)
+	co.RevocationList = &RevocationList{Revocations: []Revocation{{KeyID: keyID, EffectiveTime: time.Now().Add(-time.Hour)}}}
+	_, err = VerifyNewBundle(context.Background(), co, verify.WithArtifact(bytes.NewReader(artifact)), b)
+	assert.ErrorContains(t, err, "signer was revoked")
+
+	_, err = VerifyNewBundle(context.Background(), checkOpts(verifiers[0], verifiers[2]), verify.WithArtifact(bytes.NewReader(artifact)), b)
+	assert.Error(t, err)
+}
+
 func TestCheckOptsBundleOptions(t *testing.T) {
 	testCases := []struct {
 		name                  string
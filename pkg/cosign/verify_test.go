@@ -2592,3 +2592,74 @@ func getTimestampedSignature(sigBytes []byte, tsaClient *tsaMock.TSAClient) ([]b
 
 	return tsaClient.GetTimestampResponse(requestBytes)
 }
+
+func statementAttestation(t *testing.T, statement string) oci.Signature {
+	t.Helper()
+	env := fmt.Sprintf(`{"payloadType":"application/vnd.in-toto+json","payload":%q,"signatures":[{"keyid":"","sig":"c2ln"}]}`,
+		base64.StdEncoding.EncodeToString([]byte(statement)))
+	att, err := static.NewAttestation([]byte(env))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return att
+}
+
+func TestMergeAttestationFormats(t *testing.T) {
+	provenance := statementAttestation(t, `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://slsa.dev/provenance/v1","predicate":{"a":1,"b":2}}`)
+	// The same statement, formatted differently.
+	provenanceAgain := statementAttestation(t, `{"predicate": {"b": 2, "a": 1}, "predicateType": "https://slsa.dev/provenance/v1", "_type": "https://in-toto.io/Statement/v1"}`)
+	sbom := statementAttestation(t, `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://spdx.dev/Document","predicate":{}}`)
+	noAttestations := &ErrNoMatchingAttestations{errors.New("no attestations")}
+	registryErr := errors.New("registry unavailable")
+
+	for _, tc := range []struct {
+		name         string
+		bundles      []oci.Signature
+		bundleErr    error
+		legacy       []oci.Signature
+		legacyErr    error
+		want         []oci.Signature
+		wantVerified bool
+		wantErr      bool
+	}{
+		{name: "both", bundles: []oci.Signature{provenance}, legacy: []oci.Signature{provenanceAgain, sbom}, want: []oci.Signature{provenance, sbom}, wantVerified: true},
+		{name: "bundles only", bundles: []oci.Signature{provenance}, legacyErr: noAttestations, want: []oci.Signature{provenance}, wantVerified: true},
+		{name: "legacy only", bundleErr: noAttestations, legacy: []oci.Signature{sbom}, want: []oci.Signature{sbom}},
+		{name: "neither", bundleErr: noAttestations, legacyErr: noAttestations, wantErr: true},
+		{name: "bundle store fails", bundleErr: registryErr, legacy: []oci.Signature{sbom}, wantErr: true},
+		{name: "legacy store fails", bundles: []oci.Signature{provenance}, legacyErr: registryErr, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, verified, err := mergeAttestationFormats(
+				func() ([]oci.Signature, bool, error) { return tc.bundles, tc.bundleErr == nil, tc.bundleErr },
+				func() ([]oci.Signature, bool, error) { return tc.legacy, false, tc.legacyErr },
+			)
+			if tc.wantErr {
+				var noMatch *ErrNoMatchingAttestations
+				switch {
+				case tc.bundleErr == registryErr || tc.legacyErr == registryErr:
+					if !errors.Is(err, registryErr) {
+						t.Fatalf("mergeAttestationFormats() error = %v, want %v", err, registryErr)
+					}
+				case !errors.As(err, &noMatch):
+					t.Fatalf("mergeAttestationFormats() error = %v, want ErrNoMatchingAttestations", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if verified != tc.wantVerified {
+				t.Errorf("bundleVerified = %v, want %v", verified, tc.wantVerified)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d attestations, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("attestation %d differs", i)
+				}
+			}
+		})
+	}
+}
@@ -67,19 +67,53 @@ func Bundle(ref name.Reference, opts ...Option) (*sgbundle.Bundle, error) {
 	if len(layers) != 1 {
 		return nil, errors.New("expected exactly one layer")
 	}
-	mediaType, err := layers[0].MediaType()
+	return bundleFromLayer(layers[0], o)
+}
+
+// Bundles fetches the sigstore bundles of a referrer manifest that holds one
+// bundle per layer, as written by WriteAttestationsNewBundleFormat when
+// several signers sign at once.
+func Bundles(ref name.Reference, opts ...Option) ([]*sgbundle.Bundle, error) {
+	o := makeOptions(ref.Context(), opts...)
+	img, err := remoteImage(ref, o.ROpt...)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) == 0 {
+		return nil, errors.New("expected at least one layer")
+	}
+	if len(layers) > maxLayers {
+		return nil, errors.New("too many layers")
+	}
+	bundles := make([]*sgbundle.Bundle, 0, len(layers))
+	for _, layer := range layers {
+		b, err := bundleFromLayer(layer, o)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, b)
+	}
+	return bundles, nil
+}
+
+func bundleFromLayer(layer v1.Layer, o *options) (*sgbundle.Bundle, error) {
+	mediaType, err := layer.MediaType()
 	if err != nil {
 		return nil, err
 	}
 	if !strings.HasPrefix(string(mediaType), "application/vnd.dev.sigstore.bundle") {
 		return nil, errors.New("expected bundle layer")
 	}
-	layer0, err := layers[0].Uncompressed()
+	rc, err := layer.Uncompressed()
 	if err != nil {
 		return nil, err
 	}
-	defer layer0.Close()
-	bundleBytes, err := io.ReadAll(layer0)
+	defer rc.Close()
+	bundleBytes, err := io.ReadAll(rc)
 	if err != nil {
 		return nil, err
 	}
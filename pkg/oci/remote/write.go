@@ -18,6 +18,7 @@ package remote
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -396,14 +397,27 @@ func WriteReferrer(d name.Digest, artifactType string, layers []v1.Layer, annota
 }
 
 func WriteAttestationNewBundleFormat(d name.Digest, bundleBytes []byte, predicateType string, opts ...Option) error {
+	return WriteAttestationsNewBundleFormat(d, [][]byte{bundleBytes}, predicateType, opts...)
+}
+
+// WriteAttestationsNewBundleFormat publishes several bundles over the same
+// predicate type in a single referrer manifest, one bundle per layer, so
+// that they are published together or not at all.
+func WriteAttestationsNewBundleFormat(d name.Digest, bundles [][]byte, predicateType string, opts ...Option) error {
+	if len(bundles) == 0 {
+		return errors.New("no bundles to write")
+	}
 	// generate bundle media type string
 	bundleMediaType, err := sgbundle.MediaTypeString("0.3")
 	if err != nil {
 		return fmt.Errorf("failed to generate bundle media type string: %w", err)
 	}
 
-	// Write the bundle layer
-	layer := static.NewLayer(bundleBytes, types.MediaType(bundleMediaType))
+	// Write a bundle layer for each bundle
+	layers := make([]v1.Layer, len(bundles))
+	for i, bundleBytes := range bundles {
+		layers[i] = static.NewLayer(bundleBytes, types.MediaType(bundleMediaType))
+	}
 
 	annotations := map[string]string{
 		"org.opencontainers.image.created": time.Now().UTC().Format(time.RFC3339),
@@ -411,7 +425,7 @@ func WriteAttestationNewBundleFormat(d name.Digest, bundleBytes []byte, predicat
 		BundlePredicateType:                predicateType,
 	}
 
-	return WriteReferrer(d, bundleMediaType, []v1.Layer{layer}, annotations, opts...)
+	return WriteReferrer(d, bundleMediaType, layers, annotations, opts...)
 }
 
 // WriteAttestationsReferrer publishes the attestations attached to the given entity
@@ -59,11 +59,6 @@ func AttestationToPayloadJSON(_ context.Context, predicateType string, verifiedA
 	if predicateType == "" {
 		return nil, "", errors.New("missing predicate type")
 	}
-	predicateURI, ok := options.PredicateTypeMap[predicateType]
-	if !ok {
-		// Not a custom one, use it as is.
-		predicateURI = predicateType
-	}
 	var payloadData map[string]interface{}
 
 	p, err := verifiedAttestation.Payload()
@@ -95,7 +90,7 @@ func AttestationToPayloadJSON(_ context.Context, predicateType string, verifiedA
 	if err := statement.UnmarshalJSON(decodedPayload); err != nil {
 		return nil, "", fmt.Errorf("unmarshal in-toto statement: %w", err)
 	}
-	if statement.PredicateType != predicateURI {
+	if !attestation.MatchesPredicateType(predicateType, statement.PredicateType) {
 		// This is not the predicate we're looking for, so skip it.
 		return nil, statement.PredicateType, nil
 	}
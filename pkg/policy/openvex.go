@@ -0,0 +1,303 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+)
+
+// OpenVEX statuses that suppress a scanner finding.
+const (
+	VEXStatusNotAffected = "not_affected"
+	VEXStatusFixed       = "fixed"
+)
+
+// OpenVEX is the subset of an OpenVEX document needed to suppress scanner
+// findings. See https://github.com/openvex/spec.
+type OpenVEX struct {
+	Context     string         `json:"@context"`
+	ID          string         `json:"@id,omitempty"`
+	Timestamp   *time.Time     `json:"timestamp,omitempty"`
+	LastUpdated *time.Time     `json:"last_updated,omitempty"`
+	Statements  []VEXStatement `json:"statements"`
+}
+
+// VEXStatement is a statement of an OpenVEX document.
+type VEXStatement struct {
+	Vulnerability VEXVulnerability `json:"vulnerability"`
+	Products      []VEXComponent   `json:"products,omitempty"`
+	// Subcomponents are the statement-level subcomponents of early OpenVEX
+	// versions, which apply to every product.
+	Subcomponents []VEXComponent `json:"subcomponents,omitempty"`
+	Status        string         `json:"status"`
+	Justification string         `json:"justification,omitempty"`
+	Timestamp     *time.Time     `json:"timestamp,omitempty"`
+	LastUpdated   *time.Time     `json:"last_updated,omitempty"`
+}
+
+// VEXComponent is a product of a statement or one of its subcomponents.
+// Early OpenVEX versions used a plain string identifier.
+type VEXComponent struct {
+	ID            string            `json:"@id,omitempty"`
+	Identifiers   map[string]string `json:"identifiers,omitempty"`
+	Hashes        map[string]string `json:"hashes,omitempty"`
+	Subcomponents []VEXComponent    `json:"subcomponents,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string and the object form.
+func (c *VEXComponent) UnmarshalJSON(b []byte) error {
+	var id string
+	if err := json.Unmarshal(b, &id); err == nil {
+		c.ID = id
+		return nil
+	}
+	type component VEXComponent
+	return json.Unmarshal(b, (*component)(c))
+}
+
+// identifiers returns the @id and the identifiers of the component.
+func (c VEXComponent) identifiers() []string {
+	ids := []string{c.ID}
+	for _, k := range slices.Sorted(maps.Keys(c.Identifiers)) {
+		ids = append(ids, c.Identifiers[k])
+	}
+	return ids
+}
+
+// matchesDigest reports whether the component is the artifact with the
+// digest, given as algorithm:hex: an image reference or purl by that digest,
+// or a component with that hash.
+func (c VEXComponent) matchesDigest(digest string) bool {
+	digest = strings.ToLower(digest)
+	alg, hex, ok := strings.Cut(digest, ":")
+	if !ok || hex == "" {
+		return false
+	}
+	for k, v := range c.Hashes {
+		if strings.ReplaceAll(strings.ToLower(k), "-", "") == alg && strings.EqualFold(v, hex) {
+			return true
+		}
+	}
+	for _, id := range c.identifiers() {
+		// Digests are percent-encoded in purls, as in
+		// pkg:oci/app@sha256%3Aabc.
+		id, err := url.PathUnescape(id)
+		if err != nil {
+			continue
+		}
+		for rest := strings.ToLower(id); ; {
+			i := strings.Index(rest, digest)
+			if i < 0 {
+				break
+			}
+			rest = rest[i+len(digest):]
+			// The digest must not be the prefix of a longer one.
+			if rest == "" || !isHexDigit(rest[0]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f')
+}
+
+// matchesPackage reports whether the subcomponent is the package of a
+// finding: a purl of that name and, if it has one, version, or the bare
+// package name.
+func (c VEXComponent) matchesPackage(v Vulnerability) bool {
+	for _, id := range c.identifiers() {
+		if id == "" {
+			continue
+		}
+		if id == v.Package {
+			return true
+		}
+		purl, ok := strings.CutPrefix(id, "pkg:")
+		if !ok {
+			continue
+		}
+		purl, _, _ = strings.Cut(purl, "#")
+		purl, _, _ = strings.Cut(purl, "?")
+		path, version, _ := strings.Cut(purl, "@")
+		name := path[strings.LastIndex(path, "/")+1:]
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			name = unescaped
+		}
+		if unescaped, err := url.PathUnescape(version); err == nil {
+			version = unescaped
+		}
+		if name == v.Package && (version == "" || version == v.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// VEXVulnerability identifies the vulnerability of a statement. Early
+// OpenVEX versions used a plain string, later ones an object with aliases.
+type VEXVulnerability struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string and the object form.
+func (v *VEXVulnerability) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		v.Name = name
+		return nil
+	}
+	type vulnerability VEXVulnerability
+	return json.Unmarshal(b, (*vulnerability)(v))
+}
+
+// ParseOpenVEX parses an OpenVEX document.
+func ParseOpenVEX(b []byte) (*OpenVEX, error) {
+	doc := &OpenVEX{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("parsing OpenVEX document: %w", err)
+	}
+	if !strings.HasPrefix(doc.Context, attestation.OpenVexNamespace) {
+		return nil, fmt.Errorf("not an OpenVEX document: @context is %q", doc.Context)
+	}
+	return doc, nil
+}
+
+// LoadOpenVEX reads an OpenVEX document from path.
+func LoadOpenVEX(path string) (*OpenVEX, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenVEX(b)
+}
+
+// VEXIndex holds the OpenVEX statements about an image by vulnerability ID
+// and alias.
+type VEXIndex struct {
+	statements map[string][]indexedStatement
+}
+
+type indexedStatement struct {
+	VEXStatement
+	time time.Time
+	// subcomponents limit the statement to these packages of the image. It
+	// is nil when the statement is about the whole image.
+	subcomponents []VEXComponent
+}
+
+// IndexVEX returns the statements of the documents that are about the image
+// with the given digest, such as sha256:abc. A statement is about the image
+// when one of its products is the image, or when it has no products.
+// Statements limited to subcomponents of the image only apply to findings
+// in those packages.
+func IndexVEX(docs []*OpenVEX, digest string) *VEXIndex {
+	index := &VEXIndex{statements: map[string][]indexedStatement{}}
+	for _, doc := range docs {
+		for _, s := range doc.Statements {
+			subcomponents, ok := statementScope(s, digest)
+			if !ok {
+				continue
+			}
+			is := indexedStatement{VEXStatement: s, time: statementTime(doc, s), subcomponents: subcomponents}
+			for _, id := range append([]string{s.Vulnerability.Name}, s.Vulnerability.Aliases...) {
+				if id != "" {
+					index.statements[id] = append(index.statements[id], is)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// statementScope reports whether the statement is about the image with the
+// digest, and the subcomponents of the image it is limited to, if any.
+func statementScope(s VEXStatement, digest string) ([]VEXComponent, bool) {
+	if len(s.Products) == 0 {
+		return nilIfEmpty(s.Subcomponents), true
+	}
+	var subcomponents []VEXComponent
+	matched := false
+	for _, p := range s.Products {
+		if !p.matchesDigest(digest) {
+			continue
+		}
+		all := append(slices.Clone(s.Subcomponents), p.Subcomponents...)
+		if len(all) == 0 {
+			// The statement is about the whole image.
+			return nil, true
+		}
+		subcomponents = append(subcomponents, all...)
+		matched = true
+	}
+	return subcomponents, matched
+}
+
+func nilIfEmpty[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+// statementTime returns when the statement was last made or updated,
+// falling back to the times of its document.
+func statementTime(doc *OpenVEX, s VEXStatement) time.Time {
+	for _, t := range []*time.Time{s.LastUpdated, s.Timestamp, doc.LastUpdated, doc.Timestamp} {
+		if t != nil {
+			return *t
+		}
+	}
+	return time.Time{}
+}
+
+// Lookup returns the latest statement about the vulnerability of the
+// finding that applies to its package. Of statements made at the same
+// time, the last one indexed wins.
+func (x *VEXIndex) Lookup(v Vulnerability) (VEXStatement, bool) {
+	var latest *indexedStatement
+	for i, s := range x.statements[v.ID] {
+		if s.subcomponents != nil && !slices.ContainsFunc(s.subcomponents, func(c VEXComponent) bool { return c.matchesPackage(v) }) {
+			continue
+		}
+		if latest == nil || !s.time.Before(latest.time) {
+			latest = &x.statements[v.ID][i]
+		}
+	}
+	if latest == nil {
+		return VEXStatement{}, false
+	}
+	return latest.VEXStatement, true
+}
+
+// Suppressed reports whether a VEX status means the vulnerability does not
+// affect the product.
+func Suppressed(status string) bool {
+	return status == VEXStatusNotAffected || status == VEXStatusFixed
+}
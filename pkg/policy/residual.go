@@ -0,0 +1,174 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+)
+
+// ResidualReport is the result of applying OpenVEX statements to the
+// findings of vulnerability scans of an image. It is the input of policies
+// evaluated with `cosign verify-attestation --type vuln --residual`.
+type ResidualReport struct {
+	// Scans are the scans merged into the report: the latest of each
+	// scanner.
+	Scans []ScanSummary `json:"scans"`
+	// Vulnerabilities are the findings no VEX statement suppresses.
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	// Suppressed are the findings VEX statements mark as not_affected or
+	// fixed.
+	Suppressed []SuppressedVulnerability `json:"suppressed"`
+}
+
+// ScanSummary identifies a vulnerability scan.
+type ScanSummary struct {
+	Scanner        string    `json:"scanner"`
+	ScanFinishedOn time.Time `json:"scanFinishedOn"`
+}
+
+// SuppressedVulnerability is a finding with the VEX statement suppressing
+// it.
+type SuppressedVulnerability struct {
+	Vulnerability
+	Status        string `json:"status"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// LatestScan returns when the latest merged scan finished.
+func (r *ResidualReport) LatestScan() time.Time {
+	var latest time.Time
+	for _, s := range r.Scans {
+		if s.ScanFinishedOn.After(latest) {
+			latest = s.ScanFinishedOn
+		}
+	}
+	return latest
+}
+
+// MergeVulnScans merges the latest scan of each scanner and applies the
+// statements of the VEX documents about the image with the digest to the
+// findings. Findings of the same vulnerability in the same package are
+// reported once, with the highest severity any scanner gave them.
+func MergeVulnScans(scans []attestation.CosignVulnPredicate, vex []*OpenVEX, digest string) (*ResidualReport, error) {
+	latest := map[string]attestation.CosignVulnPredicate{}
+	for _, scan := range scans {
+		if prev, ok := latest[scan.Scanner.URI]; !ok || scan.Metadata.ScanFinishedOn.After(prev.Metadata.ScanFinishedOn) {
+			latest[scan.Scanner.URI] = scan
+		}
+	}
+
+	report := &ResidualReport{
+		Scans:           []ScanSummary{},
+		Vulnerabilities: []Vulnerability{},
+		Suppressed:      []SuppressedVulnerability{},
+	}
+	type key struct{ id, pkg, version string }
+	findings := map[key]Vulnerability{}
+	for _, uri := range slices.Sorted(maps.Keys(latest)) {
+		scan := latest[uri]
+		report.Scans = append(report.Scans, ScanSummary{Scanner: uri, ScanFinishedOn: scan.Metadata.ScanFinishedOn})
+		vulns, err := ScanFindings(scan)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vulns {
+			k := key{v.ID, v.Package, v.Version}
+			if prev, ok := findings[k]; !ok || v.Severity > prev.Severity {
+				findings[k] = v
+			}
+		}
+	}
+
+	index := IndexVEX(vex, digest)
+	for _, v := range findings {
+		if s, ok := index.Lookup(v); ok && Suppressed(s.Status) {
+			report.Suppressed = append(report.Suppressed, SuppressedVulnerability{Vulnerability: v, Status: s.Status, Justification: s.Justification})
+			continue
+		}
+		report.Vulnerabilities = append(report.Vulnerabilities, v)
+	}
+	slices.SortFunc(report.Vulnerabilities, compareVulnerabilities)
+	slices.SortFunc(report.Suppressed, func(a, b SuppressedVulnerability) int {
+		return compareVulnerabilities(a.Vulnerability, b.Vulnerability)
+	})
+	return report, nil
+}
+
+// compareVulnerabilities orders the most severe first, then by ID and
+// package.
+func compareVulnerabilities(a, b Vulnerability) int {
+	return cmp.Or(
+		cmp.Compare(b.Severity, a.Severity),
+		cmp.Compare(a.ID, b.ID),
+		cmp.Compare(a.Package, b.Package),
+		cmp.Compare(a.Version, b.Version),
+	)
+}
+
+// VulnScans returns the predicates of the vulnerability scan attestations.
+func VulnScans[T PayloadProvider](ctx context.Context, atts []T) ([]attestation.CosignVulnPredicate, error) {
+	var scans []attestation.CosignVulnPredicate
+	for _, att := range atts {
+		payload, _, err := AttestationToPayloadJSON(ctx, options.PredicateVuln, att)
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) == 0 {
+			continue
+		}
+		var statement attestation.CosignVulnStatement
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			return nil, fmt.Errorf("unmarshaling CosignVulnStatement: %w", err)
+		}
+		scans = append(scans, statement.Predicate)
+	}
+	return scans, nil
+}
+
+// OpenVEXDocuments returns the documents of the OpenVEX attestations.
+func OpenVEXDocuments[T PayloadProvider](ctx context.Context, atts []T) ([]*OpenVEX, error) {
+	var docs []*OpenVEX
+	for _, att := range atts {
+		payload, _, err := AttestationToPayloadJSON(ctx, options.PredicateOpenVEX, att)
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) == 0 {
+			continue
+		}
+		var statement struct {
+			Predicate json.RawMessage `json:"predicate"`
+		}
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			return nil, err
+		}
+		doc, err := ParseOpenVEX(statement.Predicate)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+)
+
+func TestMergeVulnScans(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	stale := vulnPredicate(t, `{"matches": [{"vulnerability": {"id": "CVE-2020-9999", "severity": "Critical"}, "artifact": {"name": "old", "version": "1"}}]}`, day)
+	stale.Scanner.URI = "pkg:github/anchore/grype"
+	grype := vulnPredicate(t, `{"matches": [
+		{"vulnerability": {"id": "CVE-2024-0001", "severity": "Low"}, "artifact": {"name": "openssl", "version": "3.1.0"}},
+		{"vulnerability": {"id": "GHSA-xxxx", "severity": "High"}, "artifact": {"name": "lodash", "version": "4.17.0"}}
+	]}`, day.Add(24*time.Hour))
+	grype.Scanner.URI = "pkg:github/anchore/grype"
+	trivy := vulnPredicate(t, trivyResultJSON, day)
+	trivy.Scanner.URI = "pkg:github/aquasecurity/trivy"
+
+	vex, err := ParseOpenVEX([]byte(`{
+		"@context": "https://openvex.dev/ns/v0.2.0",
+		"statements": [
+			{"vulnerability": {"name": "CVE-2024-0002"}, "status": "fixed"},
+			{"vulnerability": {"name": "GHSA-xxxx"}, "status": "not_affected", "justification": "component_not_present"},
+			{"vulnerability": {"name": "GHSA-xxxx"}, "status": "under_investigation"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := MergeVulnScans([]attestation.CosignVulnPredicate{stale, grype, trivy}, []*OpenVEX{vex}, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Scans) != 2 || !report.LatestScan().Equal(day.Add(24*time.Hour)) {
+		t.Errorf("Scans = %+v", report.Scans)
+	}
+	// The stale Grype scan is dropped, the later GHSA statement overrides
+	// the earlier one and the openssl finding keeps Trivy's severity.
+	want := []Vulnerability{
+		{ID: "CVE-2024-0001", Severity: SeverityCritical, Package: "openssl", Version: "3.1.0"},
+		{ID: "GHSA-xxxx", Severity: SeverityHigh, Package: "lodash", Version: "4.17.0"},
+	}
+	if len(report.Vulnerabilities) != len(want) {
+		t.Fatalf("Vulnerabilities = %+v, want %+v", report.Vulnerabilities, want)
+	}
+	for i, v := range want {
+		if report.Vulnerabilities[i] != v {
+			t.Errorf("Vulnerabilities[%d] = %+v, want %+v", i, report.Vulnerabilities[i], v)
+		}
+	}
+	if len(report.Suppressed) != 1 || report.Suppressed[0].ID != "CVE-2024-0002" || report.Suppressed[0].Status != VEXStatusFixed {
+		t.Errorf("Suppressed = %+v", report.Suppressed)
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string][]map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["vulnerabilities"][0]["severity"] != "critical" || decoded["suppressed"][0]["status"] != "fixed" {
+		t.Errorf("unexpected report JSON %s", b)
+	}
+
+	empty, err := MergeVulnScans(nil, nil, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := json.Marshal(empty); string(b) != `{"scans":[],"vulnerabilities":[],"suppressed":[]}` {
+		t.Errorf("empty report = %s", b)
+	}
+}
@@ -0,0 +1,263 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+)
+
+// Severity is the severity of a vulnerability.
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityNegligible
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = []string{"unknown", "negligible", "low", "medium", "high", "critical"}
+
+// SeverityNames returns the accepted severity names, from least to most
+// severe.
+func SeverityNames() []string {
+	return slices.Clone(severityNames)
+}
+
+// ParseSeverity parses a severity name, ignoring case.
+func ParseSeverity(s string) (Severity, error) {
+	i := slices.Index(severityNames, strings.ToLower(s))
+	if i < 0 {
+		return SeverityUnknown, fmt.Errorf("invalid severity %q, expected one of %s", s, strings.Join(severityNames, ", "))
+	}
+	return Severity(i), nil
+}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return severityNames[SeverityUnknown]
+	}
+	return severityNames[s]
+}
+
+// MarshalJSON encodes the severity by name.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a severity name. Unrecognized names are unknown.
+func (s *Severity) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return err
+	}
+	*s, _ = ParseSeverity(name)
+	return nil
+}
+
+// Vulnerability is a scanner finding.
+type Vulnerability struct {
+	ID       string   `json:"id"`
+	Severity Severity `json:"severity"`
+	Package  string   `json:"package,omitempty"`
+	Version  string   `json:"version,omitempty"`
+}
+
+// trivyResult is the part of a Trivy JSON report holding findings, as
+// produced by `trivy image --format cosign-vuln`.
+type trivyResult struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			Severity         string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// grypeResult is the part of a Grype JSON report holding findings.
+type grypeResult struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+// ScanFindings returns the findings in the scanner result of a
+// vulnerability scan predicate. Trivy and Grype JSON reports are supported.
+func ScanFindings(predicate attestation.CosignVulnPredicate) ([]Vulnerability, error) {
+	if predicate.Scanner.Result == nil {
+		return nil, errors.New("vulnerability scan has no scanner result")
+	}
+	b, err := json.Marshal(predicate.Scanner.Result)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("parsing scanner result: %w", err)
+	}
+
+	var vulns []Vulnerability
+	switch {
+	case fields["Results"] != nil || fields["SchemaVersion"] != nil:
+		var r trivyResult
+		if err := json.Unmarshal(b, &r); err != nil {
+			return nil, fmt.Errorf("parsing Trivy result: %w", err)
+		}
+		for _, res := range r.Results {
+			for _, v := range res.Vulnerabilities {
+				sev, err := findingSeverity(v.VulnerabilityID, v.Severity)
+				if err != nil {
+					return nil, err
+				}
+				vulns = append(vulns, Vulnerability{ID: v.VulnerabilityID, Severity: sev, Package: v.PkgName, Version: v.InstalledVersion})
+			}
+		}
+	case fields["matches"] != nil:
+		var r grypeResult
+		if err := json.Unmarshal(b, &r); err != nil {
+			return nil, fmt.Errorf("parsing Grype result: %w", err)
+		}
+		for _, m := range r.Matches {
+			sev, err := findingSeverity(m.Vulnerability.ID, m.Vulnerability.Severity)
+			if err != nil {
+				return nil, err
+			}
+			vulns = append(vulns, Vulnerability{ID: m.Vulnerability.ID, Severity: sev, Package: m.Artifact.Name, Version: m.Artifact.Version})
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized result format of scanner %s", predicate.Scanner.URI)
+	}
+	return vulns, nil
+}
+
+// findingSeverity parses the severity a scanner gave a finding. A missing
+// severity is unknown; one that is not recognized is an error, so that it
+// cannot slip under a maximum severity.
+func findingSeverity(id, s string) (Severity, error) {
+	if s == "" {
+		return SeverityUnknown, nil
+	}
+	sev, err := ParseSeverity(s)
+	if err != nil {
+		return SeverityUnknown, fmt.Errorf("finding %s: %w", id, err)
+	}
+	return sev, nil
+}
+
+// ParseMaxAge parses a maximum age: a Go duration or a number of days such
+// as "7d".
+func ParseMaxAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid maximum age %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid maximum age %q", s)
+	}
+	return d, nil
+}
+
+// VulnPolicy gates merged vulnerability scans on the age of the latest scan
+// and the severity of their residual findings.
+type VulnPolicy struct {
+	// MaxAge is the maximum time since the latest scan finished, or zero
+	// for any.
+	MaxAge time.Duration
+	// MaxSeverity is the most severe unsuppressed finding allowed, or nil
+	// for any. Findings of unknown severity exceed it unless AllowUnknown
+	// is set.
+	MaxSeverity *Severity
+	// AllowUnknown lets findings of unknown severity pass MaxSeverity.
+	AllowUnknown bool
+	// Now returns the current time; time.Now if nil.
+	Now func() time.Time
+}
+
+// VulnPolicyError is returned when a scan does not satisfy a VulnPolicy.
+type VulnPolicyError struct {
+	// Age is set when the latest scan is too old.
+	Age time.Duration
+	// Offending are the unsuppressed findings above the maximum severity.
+	Offending []Vulnerability
+}
+
+func (e *VulnPolicyError) Error() string {
+	var problems []string
+	if e.Age > 0 {
+		problems = append(problems, fmt.Sprintf("vulnerability scan is %s old", e.Age.Round(time.Minute)))
+	}
+	if len(e.Offending) > 0 {
+		ids := make([]string, len(e.Offending))
+		for i, v := range e.Offending {
+			ids[i] = fmt.Sprintf("%s (%s)", v.ID, v.Severity)
+		}
+		problems = append(problems, fmt.Sprintf("%d vulnerabilities exceed the maximum severity: %s", len(ids), strings.Join(ids, ", ")))
+	}
+	return strings.Join(problems, "; ")
+}
+
+// Evaluate checks the merged scans against the policy, returning a
+// *VulnPolicyError listing the offending vulnerabilities if it fails.
+func (p VulnPolicy) Evaluate(report *ResidualReport) error {
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	policyErr := &VulnPolicyError{}
+	if p.MaxAge > 0 {
+		finished := report.LatestScan()
+		if finished.IsZero() {
+			return errors.New("vulnerability scan has no scanFinishedOn time")
+		}
+		if age := now().Sub(finished); age > p.MaxAge {
+			policyErr.Age = age
+		}
+	}
+	if p.MaxSeverity != nil {
+		for _, v := range report.Vulnerabilities {
+			if v.Severity > *p.MaxSeverity || (v.Severity == SeverityUnknown && !p.AllowUnknown) {
+				policyErr.Offending = append(policyErr.Offending, v)
+			}
+		}
+	}
+	if policyErr.Age > 0 || len(policyErr.Offending) > 0 {
+		return policyErr
+	}
+	return nil
+}
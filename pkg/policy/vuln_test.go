@@ -0,0 +1,256 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+)
+
+const trivyResultJSON = `{
+	"SchemaVersion": 2,
+	"Results": [
+		{"Target": "alpine", "Vulnerabilities": [
+			{"VulnerabilityID": "CVE-2024-0001", "PkgName": "openssl", "InstalledVersion": "3.1.0", "Severity": "CRITICAL"},
+			{"VulnerabilityID": "CVE-2024-0002", "PkgName": "busybox", "InstalledVersion": "1.36", "Severity": "MEDIUM"}
+		]},
+		{"Target": "app"}
+	]
+}`
+
+const grypeResultJSON = `{
+	"matches": [
+		{"vulnerability": {"id": "GHSA-xxxx", "severity": "High"}, "artifact": {"name": "lodash", "version": "4.17.0"}},
+		{"vulnerability": {"id": "CVE-2024-0003", "severity": "Unknown"}, "artifact": {"name": "zlib", "version": "1.2"}}
+	]
+}`
+
+const testDigest = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+func vulnPredicate(t *testing.T, result string, finished time.Time) attestation.CosignVulnPredicate {
+	t.Helper()
+	var r any
+	if err := json.Unmarshal([]byte(result), &r); err != nil {
+		t.Fatal(err)
+	}
+	return attestation.CosignVulnPredicate{
+		Scanner:  attestation.Scanner{URI: "pkg:github/example/scanner", Result: r},
+		Metadata: attestation.Metadata{ScanFinishedOn: finished},
+	}
+}
+
+func TestScanFindings(t *testing.T) {
+	vulns, err := ScanFindings(vulnPredicate(t, trivyResultJSON, time.Time{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vulns) != 2 || vulns[0].ID != "CVE-2024-0001" || vulns[0].Severity != SeverityCritical || vulns[0].Package != "openssl" {
+		t.Errorf("unexpected Trivy findings %+v", vulns)
+	}
+
+	vulns, err = ScanFindings(vulnPredicate(t, grypeResultJSON, time.Time{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vulns) != 2 || vulns[0].Severity != SeverityHigh || vulns[1].Severity != SeverityUnknown {
+		t.Errorf("unexpected Grype findings %+v", vulns)
+	}
+
+	weird := strings.Replace(grypeResultJSON, `"Unknown"`, `"Weird"`, 1)
+	if _, err := ScanFindings(vulnPredicate(t, weird, time.Time{})); err == nil || !strings.Contains(err.Error(), "CVE-2024-0003") {
+		t.Errorf("ScanFindings() with an unrecognized severity error = %v", err)
+	}
+	if _, err := ScanFindings(vulnPredicate(t, `{"foo": []}`, time.Time{})); err == nil {
+		t.Error("expected error for an unknown result format")
+	}
+	if _, err := ScanFindings(attestation.CosignVulnPredicate{}); err == nil {
+		t.Error("expected error for a scan without result")
+	}
+}
+
+func TestVulnPolicyEvaluate(t *testing.T) {
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	high, critical := SeverityHigh, SeverityCritical
+	vex, err := ParseOpenVEX([]byte(`{
+		"@context": "https://openvex.dev/ns/v0.2.0",
+		"statements": [
+			{"vulnerability": {"name": "GHSA-yyyy", "aliases": ["CVE-2024-0001"]}, "status": "not_affected", "justification": "vulnerable_code_not_in_execute_path"},
+			{"vulnerability": "CVE-2024-0002", "status": "affected"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		policy    VulnPolicy
+		vex       []*OpenVEX
+		finished  time.Time
+		wantAge   bool
+		offending []string
+	}{
+		{"fresh", VulnPolicy{MaxAge: 7 * 24 * time.Hour}, nil, now.Add(-24 * time.Hour), false, nil},
+		{"stale", VulnPolicy{MaxAge: 7 * 24 * time.Hour}, nil, now.Add(-8 * 24 * time.Hour), true, nil},
+		{"critical allowed", VulnPolicy{MaxSeverity: &critical}, nil, now, false, nil},
+		{"critical denied", VulnPolicy{MaxSeverity: &high}, nil, now, false, []string{"CVE-2024-0001"}},
+		{"suppressed by alias", VulnPolicy{MaxSeverity: &high}, []*OpenVEX{vex}, now, false, nil},
+		{"stale and denied", VulnPolicy{MaxAge: time.Hour, MaxSeverity: &high}, nil, now.Add(-2 * time.Hour), true, []string{"CVE-2024-0001"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.policy.Now = func() time.Time { return now }
+			report, err := MergeVulnScans([]attestation.CosignVulnPredicate{vulnPredicate(t, trivyResultJSON, tt.finished)}, tt.vex, testDigest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tt.policy.Evaluate(report)
+			if !tt.wantAge && tt.offending == nil {
+				if err != nil {
+					t.Fatalf("Evaluate() error = %v", err)
+				}
+				return
+			}
+			var policyErr *VulnPolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Evaluate() error = %v, want VulnPolicyError", err)
+			}
+			if (policyErr.Age > 0) != tt.wantAge {
+				t.Errorf("Age = %v", policyErr.Age)
+			}
+			var ids []string
+			for _, v := range policyErr.Offending {
+				ids = append(ids, v.ID)
+				if !strings.Contains(err.Error(), v.ID) {
+					t.Errorf("error %q does not name %s", err, v.ID)
+				}
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.offending, ",") {
+				t.Errorf("Offending = %v, want %v", ids, tt.offending)
+			}
+		})
+	}
+
+	report, err := MergeVulnScans([]attestation.CosignVulnPredicate{vulnPredicate(t, trivyResultJSON, time.Time{})}, nil, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (VulnPolicy{MaxAge: time.Hour}).Evaluate(report); err == nil {
+		t.Error("expected error for a scan without scanFinishedOn")
+	}
+
+	// Findings of unknown severity fail a maximum severity unless allowed.
+	report, err = MergeVulnScans([]attestation.CosignVulnPredicate{vulnPredicate(t, grypeResultJSON, time.Time{})}, nil, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var policyErr *VulnPolicyError
+	if err := (VulnPolicy{MaxSeverity: &critical}).Evaluate(report); !errors.As(err, &policyErr) ||
+		len(policyErr.Offending) != 1 || policyErr.Offending[0].ID != "CVE-2024-0003" {
+		t.Errorf("Evaluate() of an unknown severity finding error = %v", err)
+	}
+	if err := (VulnPolicy{MaxSeverity: &critical, AllowUnknown: true}).Evaluate(report); err != nil {
+		t.Errorf("Evaluate() with AllowUnknown error = %v", err)
+	}
+}
+
+func TestParseMaxAge(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"0d", 0, false},
+		{"-1d", 0, true},
+		{"week", 0, true},
+		{"xd", 0, true},
+	} {
+		got, err := ParseMaxAge(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMaxAge(%s) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestParseOpenVEX(t *testing.T) {
+	if _, err := ParseOpenVEX([]byte(`{"@context": "https://example.com", "statements": []}`)); err == nil {
+		t.Error("expected error for a non-OpenVEX document")
+	}
+	if _, err := ParseOpenVEX([]byte(`{`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestIndexVEX(t *testing.T) {
+	other := "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	older, err := ParseOpenVEX([]byte(`{
+		"@context": "https://openvex.dev/ns/v0.2.0",
+		"timestamp": "2024-05-02T00:00:00Z",
+		"statements": [
+			{"vulnerability": {"name": "CVE-1"}, "products": [{"@id": "pkg:oci/app@sha256%3Aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa?repository_url=ghcr.io/org/app"}], "status": "not_affected", "justification": "component_not_present"},
+			{"vulnerability": {"name": "CVE-2"}, "products": [{"@id": "ghcr.io/org/app@` + other + `"}], "status": "not_affected", "justification": "component_not_present"},
+			{"vulnerability": {"name": "CVE-3"}, "products": [{"hashes": {"sha-256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+				"subcomponents": [{"@id": "pkg:apk/alpine/busybox@1.36"}]}], "status": "fixed"},
+			{"vulnerability": {"name": "CVE-4"}, "products": ["ghcr.io/org/app@` + testDigest + `0"], "status": "fixed"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The newer document is listed first; its statements still win.
+	newer, err := ParseOpenVEX([]byte(`{
+		"@context": "https://openvex.dev/ns/v0.2.0",
+		"timestamp": "2024-05-03T00:00:00Z",
+		"statements": [
+			{"vulnerability": {"name": "CVE-1"}, "products": [{"@id": "ghcr.io/org/app@` + testDigest + `"}], "status": "affected"},
+			{"vulnerability": {"name": "CVE-5"}, "status": "not_affected", "justification": "component_not_present"},
+			{"vulnerability": {"name": "CVE-5"}, "status": "affected", "timestamp": "2024-05-01T00:00:00Z"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := IndexVEX([]*OpenVEX{newer, older}, testDigest)
+
+	for _, tt := range []struct {
+		vuln       Vulnerability
+		wantStatus string
+	}{
+		// The later statement about the image overrides the earlier one.
+		{Vulnerability{ID: "CVE-1", Package: "openssl"}, "affected"},
+		// Statements about other images do not apply.
+		{Vulnerability{ID: "CVE-2", Package: "openssl"}, ""},
+		// Statements about a subcomponent only apply to its package.
+		{Vulnerability{ID: "CVE-3", Package: "busybox", Version: "1.36"}, VEXStatusFixed},
+		{Vulnerability{ID: "CVE-3", Package: "busybox", Version: "1.35"}, ""},
+		{Vulnerability{ID: "CVE-3", Package: "openssl"}, ""},
+		// A longer digest is another image.
+		{Vulnerability{ID: "CVE-4", Package: "openssl"}, ""},
+		// Statements without products apply, the latest one winning.
+		{Vulnerability{ID: "CVE-5", Package: "openssl"}, VEXStatusNotAffected},
+	} {
+		s, ok := index.Lookup(tt.vuln)
+		if s.Status != tt.wantStatus || ok != (tt.wantStatus != "") {
+			t.Errorf("Lookup(%+v) = %q, %v, want %q", tt.vuln, s.Status, ok, tt.wantStatus)
+		}
+	}
+}
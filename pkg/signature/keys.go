@@ -24,9 +24,15 @@ import (
 	"github.com/sigstore/cosign/v3/pkg/blob"
 	"github.com/sigstore/cosign/v3/pkg/cosign"
 	"github.com/sigstore/cosign/v3/pkg/cosign/git"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/azuredevops"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/bitbucket"
+	"github.com/sigstore/cosign/v3/pkg/cosign/git/gitea"
 	"github.com/sigstore/cosign/v3/pkg/cosign/git/gitlab"
 	"github.com/sigstore/cosign/v3/pkg/cosign/kubernetes"
 	"github.com/sigstore/cosign/v3/pkg/cosign/pkcs11key"
+	"github.com/sigstore/cosign/v3/pkg/cosign/plugin"
+	"github.com/sigstore/cosign/v3/pkg/cosign/secrets"
+	"github.com/sigstore/cosign/v3/pkg/cosign/sshagent"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
 
@@ -63,16 +69,12 @@ func VerifierForKeyRef(ctx context.Context, keyRef string, hashAlgorithm crypto.
 		return nil, err
 	}
 
-	// PEM encoded file.
-	pubKey, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
+	// PEM encoded file, or OpenSSH public keys.
+	verifier, err = LoadPublicKeyRaw(raw, hashAlgorithm)
 	if err != nil {
 		return nil, fmt.Errorf("pem to public key: %w", err)
 	}
-
-	if hashAlgorithm == 0 {
-		return signature.LoadDefaultVerifier(pubKey)
-	}
-	return signature.LoadVerifier(pubKey, hashAlgorithm)
+	return verifier, nil
 }
 
 func loadKey(keyPath string, pf cosign.PassFunc, defaultLoadOptions *[]signature.LoadOption) (signature.SignerVerifier, error) {
@@ -90,12 +92,37 @@ func loadKey(keyPath string, pf cosign.PassFunc, defaultLoadOptions *[]signature
 	return cosign.LoadPrivateKey(kb, pass, defaultLoadOptions)
 }
 
-// LoadPublicKeyRaw loads a verifier from a PEM-encoded public key
+// LoadPublicKeyRaw loads a verifier from a PEM-encoded public key, or from
+// OpenSSH public keys in authorized_keys format. If there is more than one
+// OpenSSH key, the verifier accepts signatures made by any of them.
 func LoadPublicKeyRaw(raw []byte, hashAlgorithm crypto.Hash) (signature.Verifier, error) {
-	pub, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
+	if !isSSHPublicKeys(raw) {
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		return loadVerifier(pub, hashAlgorithm)
+	}
+
+	pubs, err := parseSSHPublicKeys(raw)
 	if err != nil {
 		return nil, err
 	}
+	verifiers := make([]signature.Verifier, 0, len(pubs))
+	for _, pub := range pubs {
+		v, err := loadVerifier(pub, hashAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, v)
+	}
+	if len(verifiers) == 1 {
+		return verifiers[0], nil
+	}
+	return cosign.NewMultiKeyVerifier(verifiers...)
+}
+
+func loadVerifier(pub crypto.PublicKey, hashAlgorithm crypto.Hash) (signature.Verifier, error) {
 	if hashAlgorithm == 0 {
 		return signature.LoadDefaultVerifier(pub)
 	}
@@ -127,16 +154,29 @@ func SignerVerifierFromKeyRef(ctx context.Context, keyRef string, pf cosign.Pass
 			return nil, fmt.Errorf("initializing pkcs11 token signer verifier: %w", err)
 		}
 
+		return sv, nil
+	case plugin.IsReference(keyRef):
+		sv, err := plugin.NewSigner(ctx, keyRef, crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("initializing signer plugin: %w", err)
+		}
+		return sv, nil
+	case sshagent.IsReference(keyRef):
+		sv, err := sshagent.NewSigner(keyRef)
+		if err != nil {
+			return nil, fmt.Errorf("initializing ssh-agent signer: %w", err)
+		}
 		return sv, nil
 	case strings.HasPrefix(keyRef, kubernetes.KeyReference):
-		s, err := kubernetes.GetKeyPairSecret(ctx, keyRef)
+		kp, err := kubernetes.GetKeyPair(ctx, keyRef)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(s.Data) > 0 {
-			return cosign.LoadPrivateKey(s.Data["cosign.key"], s.Data["cosign.password"], defaultLoadOptions)
+		if len(kp.PrivateKey) == 0 {
+			return nil, fmt.Errorf("no private key found in %s", keyRef)
 		}
+		return cosign.LoadPrivateKey(kp.PrivateKey, kp.Password, defaultLoadOptions)
 	case strings.HasPrefix(keyRef, gitlab.ReferenceScheme):
 		split := strings.Split(keyRef, "://")
 
@@ -156,6 +196,20 @@ func SignerVerifierFromKeyRef(ctx context.Context, keyRef string, pf cosign.Pass
 			return nil, err
 		}
 
+		return cosign.LoadPrivateKey([]byte(pk), []byte(pass), defaultLoadOptions)
+	case secrets.IsReference(keyRef):
+		provider, targetRef, _ := strings.Cut(keyRef, "://")
+
+		pk, err := secrets.GetProvider(provider).GetSecret(ctx, targetRef, secrets.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		pass, err := secrets.GetProvider(provider).GetSecret(ctx, targetRef, secrets.Password)
+		if err != nil {
+			return nil, err
+		}
+
 		return cosign.LoadPrivateKey([]byte(pk), []byte(pass), defaultLoadOptions)
 	}
 
@@ -179,15 +233,33 @@ func PublicKeyFromKeyRef(ctx context.Context, keyRef string) (signature.Verifier
 }
 
 func PublicKeyFromKeyRefWithHashAlgo(ctx context.Context, keyRef string, hashAlgorithm crypto.Hash) (signature.Verifier, error) {
+	if plugin.IsReference(keyRef) {
+		// Only the public key is used; signatures are verified locally.
+		v, err := plugin.NewSigner(ctx, keyRef, hashAlgorithm)
+		if err != nil {
+			return nil, fmt.Errorf("initializing signer plugin: %w", err)
+		}
+		return v, nil
+	}
+
+	if sshagent.IsReference(keyRef) {
+		v, err := sshagent.NewVerifier(keyRef)
+		if err != nil {
+			return nil, fmt.Errorf("loading ssh-agent key: %w", err)
+		}
+		return v, nil
+	}
+
 	if strings.HasPrefix(keyRef, kubernetes.KeyReference) {
-		s, err := kubernetes.GetKeyPairSecret(ctx, keyRef)
+		kp, err := kubernetes.GetKeyPair(ctx, keyRef)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(s.Data) > 0 {
-			return LoadPublicKeyRaw(s.Data["cosign.pub"], hashAlgorithm)
+		if len(kp.PublicKey) == 0 {
+			return nil, fmt.Errorf("no public key found in %s", keyRef)
 		}
+		return LoadPublicKeyRaw(kp.PublicKey, hashAlgorithm)
 	}
 
 	if strings.HasPrefix(keyRef, pkcs11key.ReferenceScheme) {
@@ -210,7 +282,7 @@ func PublicKeyFromKeyRefWithHashAlgo(ctx context.Context, keyRef string, hashAlg
 		}
 
 		return v, nil
-	} else if strings.HasPrefix(keyRef, gitlab.ReferenceScheme) {
+	} else if isGitPublicKeyReference(keyRef) {
 		split := strings.Split(keyRef, "://")
 
 		if len(split) < 2 {
@@ -227,11 +299,31 @@ func PublicKeyFromKeyRefWithHashAlgo(ctx context.Context, keyRef string, hashAlg
 		if len(pubKey) > 0 {
 			return LoadPublicKeyRaw([]byte(pubKey), hashAlgorithm)
 		}
+	} else if secrets.IsReference(keyRef) {
+		provider, targetRef, _ := strings.Cut(keyRef, "://")
+
+		pubKey, err := secrets.GetProvider(provider).GetSecret(ctx, targetRef, secrets.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return LoadPublicKeyRaw([]byte(pubKey), hashAlgorithm)
 	}
 
 	return VerifierForKeyRef(ctx, keyRef, hashAlgorithm)
 }
 
+// isGitPublicKeyReference returns true if keyRef refers to a git provider
+// that stores a readable COSIGN_PUBLIC_KEY.
+func isGitPublicKeyReference(keyRef string) bool {
+	for _, scheme := range []string{gitlab.ReferenceScheme, gitea.ReferenceScheme, gitea.ForgejoReferenceScheme, bitbucket.ReferenceScheme, azuredevops.ReferenceScheme} {
+		if strings.HasPrefix(keyRef, scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
+
 func PublicKeyPem(key signature.PublicKeyProvider, pkOpts ...signature.PublicKeyOption) ([]byte, error) {
 	pub, err := key.PublicKey(pkOpts...)
 	if err != nil {
@@ -0,0 +1,76 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils/goodkey"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeyTypes are the OpenSSH public key types usable as cosign keys.
+// Security key types (sk-*) sign a different message and are skipped.
+var sshKeyTypes = []string{
+	ssh.KeyAlgoED25519,
+	ssh.KeyAlgoECDSA256,
+	ssh.KeyAlgoECDSA384,
+	ssh.KeyAlgoECDSA521,
+	ssh.KeyAlgoRSA,
+}
+
+// isSSHPublicKeys reports whether raw holds OpenSSH public keys rather than
+// a PEM block.
+func isSSHPublicKeys(raw []byte) bool {
+	return !bytes.Contains(raw, []byte("-----BEGIN "))
+}
+
+// parseSSHPublicKeys parses OpenSSH public keys, one per line, as in
+// authorized_keys files or the keys GitHub publishes for a user. Blank lines,
+// comments, unsupported or weak keys and certificate authorities are skipped.
+func parseSSHPublicKeys(raw []byte) ([]crypto.PublicKey, error) {
+	var pubs []crypto.PublicKey
+	var skipped int
+	for rest := raw; len(bytes.TrimSpace(rest)) > 0; {
+		key, _, options, next, err := ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			// ParseAuthorizedKey skips lines it cannot parse, so this
+			// means only comments or invalid lines were left.
+			break
+		}
+		rest = next
+		if !slices.Contains(sshKeyTypes, key.Type()) || slices.Contains(options, "cert-authority") {
+			skipped++
+			continue
+		}
+		pub := key.(ssh.CryptoPublicKey).CryptoPublicKey()
+		if err := goodkey.ValidatePubKey(pub); err != nil {
+			skipped++
+			continue
+		}
+		pubs = append(pubs, pub)
+	}
+	if len(pubs) == 0 {
+		if skipped > 0 {
+			return nil, fmt.Errorf("none of the %d OpenSSH public keys is supported", skipped)
+		}
+		return nil, errors.New("no PEM block or OpenSSH public key found")
+	}
+	return pubs, nil
+}
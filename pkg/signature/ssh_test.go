@@ -0,0 +1,159 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"golang.org/x/crypto/ssh"
+)
+
+func authorizedKey(t *testing.T, pub crypto.PublicKey, comment string) string {
+	t.Helper()
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))) + " " + comment + "\n"
+}
+
+func TestLoadPublicKeyRawSSH(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadECDSASignerVerifier(ecKey, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("payload")
+	sig, err := signer.SignMessage(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("single key", func(t *testing.T) {
+		v, err := LoadPublicKeyRaw([]byte(authorizedKey(t, ecKey.Public(), "dev@laptop")), crypto.SHA256)
+		if err != nil {
+			t.Fatalf("LoadPublicKeyRaw() error = %v", err)
+		}
+		if _, ok := v.(*cosign.MultiKeyVerifier); ok {
+			t.Error("LoadPublicKeyRaw() returned a multi-key verifier for one key")
+		}
+		if err := v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)); err != nil {
+			t.Errorf("VerifySignature() error = %v", err)
+		}
+	})
+
+	t.Run("authorized_keys", func(t *testing.T) {
+		raw := "# team keys\n\n" +
+			authorizedKey(t, edPub, "dev@desktop") +
+			"cert-authority " + authorizedKey(t, caPub, "ca") +
+			authorizedKey(t, ecKey.Public(), "dev@laptop")
+		v, err := LoadPublicKeyRaw([]byte(raw), crypto.SHA256)
+		if err != nil {
+			t.Fatalf("LoadPublicKeyRaw() error = %v", err)
+		}
+		mv, ok := v.(*cosign.MultiKeyVerifier)
+		if !ok {
+			t.Fatalf("LoadPublicKeyRaw() returned %T, want *cosign.MultiKeyVerifier", v)
+		}
+		if n := len(mv.Verifiers()); n != 2 {
+			t.Errorf("got %d keys, want 2", n)
+		}
+		digest := sha256.Sum256(msg)
+		if err := v.VerifySignature(bytes.NewReader(sig), nil, options.WithDigest(digest[:])); err != nil {
+			t.Errorf("VerifySignature() error = %v", err)
+		}
+		other := sha256.Sum256([]byte("other"))
+		if err := v.VerifySignature(bytes.NewReader(sig), nil, options.WithDigest(other[:])); err == nil {
+			t.Error("VerifySignature() accepted a signature over another message")
+		}
+	})
+
+	t.Run("no usable keys", func(t *testing.T) {
+		for raw, wantErr := range map[string]string{
+			"# nothing here\n": "no PEM block or OpenSSH public key found",
+			"cert-authority " + authorizedKey(t, caPub, "ca"): "none of the 1 OpenSSH public keys is supported",
+		} {
+			if _, err := LoadPublicKeyRaw([]byte(raw), crypto.SHA256); err == nil || !strings.Contains(err.Error(), wantErr) {
+				t.Errorf("LoadPublicKeyRaw(%q) error = %v, want %q", raw, err, wantErr)
+			}
+		}
+	})
+}
+
+func TestSignerVerifierFromOpenSSHKeyFile(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(pk, "dev@laptop", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(tmpDir, "id_ecdsa")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	pubFile := keyFile + ".pub"
+	if err := os.WriteFile(pubFile, []byte(authorizedKey(t, pk.Public(), "dev@laptop")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sv, err := SignerVerifierFromKeyRef(ctx, keyFile, pass("hello"), nil)
+	if err != nil {
+		t.Fatalf("SignerVerifierFromKeyRef() error = %v", err)
+	}
+	msg := []byte("payload")
+	sig, err := sv.SignMessage(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := VerifierForKeyRef(ctx, pubFile, crypto.SHA256)
+	if err != nil {
+		t.Fatalf("VerifierForKeyRef() error = %v", err)
+	}
+	if err := v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+}
@@ -1186,7 +1186,7 @@ func TestSignVerifyContainerWithSigningConfigWithCertificate(t *testing.T) {
 	so := options.SignOptions{
 		Upload:          true,
 		NewBundleFormat: true,
-		Key:             importKeyPath,
+		Keys:            []string{importKeyPath},
 		Cert:            certPath,
 		TlogUpload:      false,
 	}
@@ -1308,7 +1308,7 @@ func TestSignVerifyContainerWithCertificateChain(t *testing.T) {
 				so := options.SignOptions{
 					Upload:          true,
 					NewBundleFormat: true,
-					Key:             leafKeyPath,
+					Keys:            []string{leafKeyPath},
 					Cert:            leafCertPath,
 					CertChain:       signChainPath,
 					TlogUpload:      false,
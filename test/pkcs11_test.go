@@ -18,6 +18,7 @@
 // DANGER
 // This test requires SoftHSMv2 to be installed. An initialized token should already exist.
 // This test will import an RSA key pair, using the specified token label.
+// It will also generate, then delete, key pairs labeled after the key label.
 // By default, the test assumes the following :
 //	- The SoftHSMv2 library is located at "/usr/local/lib/softhsm/libsofthsm2.so"
 //	- The initialized token has the label "My Token"
@@ -40,16 +41,22 @@ package test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	// Import the functions directly for testing.
 
@@ -333,6 +340,89 @@ func TestSignAndVerify(t *testing.T) {
 	}
 }
 
+func TestGenerateImportDeleteKey(t *testing.T) {
+	ctx := context.Background()
+
+	tokens, err := GetTokens(ctx, modulePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bTokenFound := false
+	var slotID uint
+	for _, token := range tokens {
+		if token.TokenInfo.Label == tokenLabel {
+			bTokenFound = true
+			slotID = token.Slot
+			break
+		}
+	}
+	if !bTokenFound {
+		t.Fatalf("token with label '%s' not found", tokenLabel)
+	}
+
+	const generatedKeyID = "c05160"
+	generatedKeyLabel := keyLabel + " generated"
+	for _, algorithm := range []string{"ecdsa-p256", "ecdsa-p384", "rsa-2048"} {
+		t.Run(algorithm, func(t *testing.T) {
+			must(GenerateKeyCmd(ctx, modulePath, slotID, pin, generatedKeyLabel, generatedKeyID, algorithm), t)
+			defer DeleteKeyCmd(ctx, modulePath, slotID, pin, "", generatedKeyID, true)
+
+			// Key ids and labels must be unique.
+			mustErr(GenerateKeyCmd(ctx, modulePath, slotID, pin, "", generatedKeyID, algorithm), t)
+			mustErr(GenerateKeyCmd(ctx, modulePath, slotID, pin, generatedKeyLabel, "", algorithm), t)
+
+			slot := int(slotID)
+			keyIDBytes, _ := hex.DecodeString(generatedKeyID)
+			pkcs11UriConfig := pkcs11key.NewPkcs11UriConfigFromInput(modulePath, &slot, tokenLabel, []byte(generatedKeyLabel), keyIDBytes, pin)
+			sk, err := pkcs11key.GetKeyWithURIConfig(pkcs11UriConfig, true)
+			must(err, t)
+			pub, err := sk.PublicKey()
+			must(err, t)
+			sk.Close()
+
+			// Import a certificate for the key issued by a throwaway CA.
+			caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			must(err, t)
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "cosign pkcs11 test"},
+				NotBefore:    time.Now(),
+				NotAfter:     time.Now().Add(time.Hour),
+			}
+			certDER, err := x509.CreateCertificate(rand.Reader, template, template, pub, caKey)
+			must(err, t)
+			certPath := filepath.Join(t.TempDir(), "cert.pem")
+			must(os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600), t)
+			must(ImportCertificateCmd(ctx, modulePath, slotID, pin, generatedKeyLabel, "", certPath), t)
+			// Importing again replaces the certificate.
+			must(ImportCertificateCmd(ctx, modulePath, slotID, pin, "", generatedKeyID, certPath), t)
+
+			sk, err = pkcs11key.GetKeyWithURIConfig(pkcs11UriConfig, true)
+			must(err, t)
+			cert, err := sk.Certificate()
+			sk.Close()
+			must(err, t)
+			if cert == nil || !bytes.Equal(cert.Raw, certDER) {
+				t.Fatal("imported certificate not found")
+			}
+
+			// A certificate for another key is refused.
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			must(err, t)
+			otherDER, err := x509.CreateCertificate(rand.Reader, template, template, otherKey.Public(), caKey)
+			must(err, t)
+			must(os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: otherDER}), 0600), t)
+			mustErr(ImportCertificateCmd(ctx, modulePath, slotID, pin, generatedKeyLabel, "", certPath), t)
+
+			must(DeleteKeyCmd(ctx, modulePath, slotID, pin, generatedKeyLabel, "", true), t)
+			_, err = pkcs11key.GetKeyWithURIConfig(pkcs11UriConfig, true)
+			mustErr(err, t)
+			mustErr(DeleteKeyCmd(ctx, modulePath, slotID, pin, generatedKeyLabel, "", true), t)
+		})
+	}
+}
+
 var newPublicKeyAttrs = []*pkcs11.Attribute{
 	pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
 	pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),